@@ -69,15 +69,102 @@ type Host interface {
 
 	HostPlanet() Planet
 
-	// Registers an App for invocation by its AppURI and AttrModelURIs.
+	// Registers an App for invocation by its AppURI and AttrModelURIs, starting it as a child process of this Host (see App.StartApp).
+	// Multiple versions of an App can be registered, but if another App already handles one of its AttrModelURIs, ErrCode_AppConflict is returned.
+	// Apps are stopped in the reverse order they were registered when this Host closes.
 	RegisterApp(app App) error
 
 	// Selects an App, typically based on schema.AttrModelURI (or schema.AppURI if given).
+	// Of the registered versions that match, the newest is selected (see AttrSchema.AppURI).
+	// If schema.AppURI is given and no registered App matches it, ErrCode_AppNotFound is returned.
 	// The given schema is READ ONLY.
 	SelectAppForSchema(schema *AttrSchema) (App, error)
 
 	// StartNewSession creates a new HostSession and binds its Msg transport to the given steam.
 	StartNewSession(parent HostService, via ServerStream) (HostSession, error)
+
+	// ListPlanets returns info about each planet stored by this host.
+	ListPlanets() ([]PlanetInfo, error)
+
+	// CreatePlanet creates and mounts a new planet from the given genesis epoch.
+	// If genesis.EpochTID is not set, a random one is assigned.
+	CreatePlanet(genesis *PlanetEpoch) (Planet, error)
+
+	// UnmountPlanet closes the given planet (if mounted), blocking until it has closed.
+	// The planet is mounted again when next accessed.
+	UnmountPlanet(planetID uint64) error
+
+	// DeletePlanet unmounts the given planet and permanently deletes its storage, along with the host's records of it.
+	// A user's home planet can't be deleted.
+	DeletePlanet(planetID uint64) error
+
+	// GrantPlanetRole sets a user's role on a planet on behalf of the host (so unlike MsgOp_GrantPlanetRole, no role is required).
+	// A user that has yet to log in can be granted a role.
+	GrantPlanetRole(grant *PlanetGrant) error
+
+	// GetPlanetRole returns the given user's role on the given planet.
+	GetPlanetRole(planetID uint64, userUID []byte) (PlanetRole, error)
+
+	// SubQueueStats returns how many times this host has acted on a sub whose client isn't keeping up with updates.
+	SubQueueStats() SubQueueStats
+
+	// HostStatus returns a snapshot of this host's open sessions, mounted planets, active cells, and registered Apps.
+	HostStatus() HostStatus
+
+	// HostStatusChanged returns a channel that is closed once what HostStatus returns changes after this call.
+	HostStatusChanged() <-chan struct{}
+}
+
+// SubQueueStats counts the actions a Host has taken when a live cell update didn't fit in a sub's outbound queue.
+type SubQueueStats struct {
+	Coalesced   uint64 // updates merged into updates still queued
+	DroppedSubs uint64 // subs closed with an error
+	Disconnects uint64 // sessions closed
+}
+
+// PlanetInfo describes a planet stored by a Host.
+type PlanetInfo struct {
+	PlanetID uint64 // symbol ID (as known by the host's symbol table)
+	Name     string // name of the planet's storage (based on its CommonName)
+	Mounted  bool   // set if the planet is currently mounted
+}
+
+// HostStatus is a snapshot of what a Host is currently running (see Host.HostStatus).
+type HostStatus struct {
+	Sessions []SessionInfo // open sessions
+	Planets  []PlanetInfo  // mounted planets
+	Cells    []CellInfo    // active cells of mounted planets
+	Apps     []AppInfo     // registered Apps
+}
+
+// SessionInfo describes a HostSession that is open.
+type SessionInfo struct {
+	SessionID    uint64    // issued by the host (unique for the life of the host)
+	Desc         string    // describes the stream carrying the session ("" while awaiting resumption)
+	HomePlanetID uint64    // home planet of the logged in user (or 0 if not logged in)
+	OpenReqs     []ReqInfo // pinned requests that are open
+}
+
+// ReqInfo describes a pinned request open in a HostSession.
+type ReqInfo struct {
+	ReqID    uint64 // client-set request ID
+	PlanetID uint64 // planet of the pinned cell
+	PinCell  CellID // the pinned cell
+	ModelURI string // AttrModelURI of the request's content schema
+}
+
+// CellInfo describes a cell that is active in a mounted planet.
+type CellInfo struct {
+	PlanetID uint64 // planet the cell belongs to
+	CellID   CellID // the cell, as issued by the App serving it
+	AppURI   string // App serving the cell ("" if served from the planet's cell store)
+	Subs     int    // number of open requests subscribed to the cell
+}
+
+// AppInfo describes an App registered with a Host.
+type AppInfo struct {
+	AppURI        string   // see App.AppURI
+	AttrModelURIs []string // see App.AttrModelURIs
 }
 
 // HostSession in an open session instance with a Host.
@@ -147,6 +234,12 @@ type Planet interface {
 	GetSymbolID(value []byte, autoIssue bool) (ID uint64)
 	LookupID(ID uint64) []byte
 
+	// ReadCell pushes the stored attrs of the given cell (as described by the given schema).
+	ReadCell(cellID CellID, schema *AttrSchema, push func(msg *Msg) error) error
+
+	// WriteCell atomically stores the given PushAttr Msgs for the given cell, where each Msg.AttrID is described by the given schema.
+	WriteCell(cellID CellID, schema *AttrSchema, msgs []*Msg) error
+
 	//GetCell(ID CellID) (CellInstance, error)
 
 	// BlobStore offers access to this planet's blob store (referenced via ValueType_BlobID).
@@ -170,6 +263,7 @@ type CellReq struct {
 	ParentApp     App           // Runtime-set via SelectAppForSchema()
 	ParentReq     *CellReq      // Runtime-set so App.ResolveRequest() has access the parent context
 	PinnedCell    AppCell       // App-set during App.ResolveRequest()
+	User          User          // Runtime-set to the logged in user making this request
 	PlanetID      uint64        // Persistent storage binding
 }
 
@@ -183,13 +277,26 @@ const DefaultAppForDataModel = "."
 type App interface {
 
 	// Identifies this App and usually has the form: "{domain_name}/{app_identifier}/v{MAJOR}.{MINOR}.{REV}"
+	// Versions of the same App share the same "{domain_name}/{app_identifier}" and are ordered by version number.
 	AppURI() string
 
 	// AttrModelURIs lists data models that this app handles.
 	// When the host session receives a client request with a specific data model URI, it will route it to the app that registered for it here.
 	AttrModelURIs() []string
 
+	// StartApp is called once when this App is registered, before any request is routed to it.
+	// ctx is this App's child process of the host, under which it can run goroutines and child processes.
+	// If an error is returned, the App is not registered.
+	StartApp(ctx AppContext) error
+
+	// StopApp is called once this App's context begins closing (e.g. when the host closes), after which no requests are routed to it.
+	// The host waits for this App's context to close before stopping the App registered before it.
+	StopApp()
+
 	// Resolves the given request to final target Planet, CellID, and AppCell.
+	// Called by one of the session's resolve workers (see HostOpts.MaxPinResolves), so it can block (e.g. on network access)
+	// without holding up the client's other requests, but should return promptly once req.Done() is closed.
+	// req.Done() is closed if the client closes the request or its session closes, after which whatever this returns is discarded.
 	ResolveRequest(req *CellReq) error
 
 	// Creates a new App instance that is bound to the given channel and starts it as a "child process" of the host / bound channel
@@ -198,24 +305,61 @@ type App interface {
 	//StartAppInstance(sess CellSession) (AppCell, error)
 }
 
+// AppContext is the child process a Host starts for each App it registers (see App.StartApp).
+type AppContext interface {
+	Context
+
+	// Host returns the Host this App is registered with.
+	Host() Host
+
+	// Settings returns the settings the host was configured with for this App (or nil if none).
+	Settings() AppSettings
+
+	// GetAppValue returns the value stored under the given key in this App's private storage on the host's home planet (or nil if not found).
+	GetAppValue(key []byte) ([]byte, error)
+
+	// PutAppValue stores the given value under the given key in this App's private storage, where a nil value removes the key.
+	PutAppValue(key, value []byte) error
+}
+
+// AppSettings are name-value pairs that configure an App, keyed by setting name.
+type AppSettings map[string]string
+
 // AppCell is how an App offers a cell instance to the planet runtime.
 type AppCell interface {
 
 	// Called when the sub is pushing full cell state (IAW the specified schemas)
-	// Makes calls to sub.PushUpdate() to dispatch state.
+	// Makes calls to req.PushMsg() to dispatch state.
 	// Called on the goroutine owned by the the target cell.
 	PushCellState(req *CellReq) error
 }
 
 type CellSub interface {
 
-	// Sets msg.ReqID and pushes the given msg to client, blocking until "complete" (queued) or canceled.
+	// Sets msg.ReqID and queues the given msg for the client without blocking.
+	// If the client is too far behind, the request is closed (see HostOpts.SubOverflow) and an error is returned.
 	// This msg is reclaimed after it is sent, so it should be accessed following this call.
 	PushMsg(msg *Msg) error
+
+	// PublishUpdate pushes the given batch of changes to every open sub of the pinned cell, followed by a checkpoint.
+	// AttrIDs and SchemaIDs in the batch are interpreted via this request's schemas and are remapped for each sub.
+	// A PushAttr msg not preceded by an InsertCell msg refers to the pinned cell (CellReq.ContentSchema).
+	// The batch is reclaimed once published, so it should not be accessed following this call.
+	// PublishUpdate does not block, so it can be called from any goroutine (including from AppCell.PushCellState).
+	PublishUpdate(batch *MsgBatch) error
+
+	// Done returns a channel that is closed once this request is closed, after which updates are no longer pushed.
+	Done() <-chan struct{}
 }
 
 type User interface {
 	HomePlanet() Planet
+
+	// UserUID identifies this user (see LoginReq.UserUID).
+	UserUID() []byte
+
+	// PlanetRole returns this user's role on the given planet.
+	PlanetRole(planetID uint64) (PlanetRole, error)
 }
 
 // MsgBatch is an ordered list os Msgs
@@ -41,6 +41,12 @@ func ConvertToTimeFS(t time.Time) TimeFS {
 	return TimeFS(timeFS | int64(frac))
 }
 
+// Time converts this TimeFS to a time.Time.
+func (t TimeFS) Time() time.Time {
+	frac := int64(t) & 0xFFFF
+	return time.Unix(int64(t)>>16, (frac*1e9)>>16)
+}
+
 // TID is a convenience function that returns the TID contained within this TIDBuf.
 func (tid *TIDBuf) TID() TID {
 	return tid[:]
@@ -217,6 +223,16 @@ func (schema *AttrSchema) LookupAttr(attrURI string) *AttrSpec {
 	return nil
 }
 
+// LookupAttrByID returns the attr bound to the given AttrID, also matching BoundSI for SeriesType_Fixed attrs.
+func (schema *AttrSchema) LookupAttrByID(attrID int32, SI int64) *AttrSpec {
+	for _, attr := range schema.Attrs {
+		if attr.AttrID == attrID && (attr.SeriesType != SeriesType_Fixed || attr.BoundSI == SI) {
+			return attr
+		}
+	}
+	return nil
+}
+
 func (req *CellReq) GetChildSchema(modelURI string) *AttrSchema {
 	for _, schema := range req.ChildSchemas {
 		if schema.AttrModelURI == modelURI {
@@ -0,0 +1,17 @@
+//go:build darwin || freebsd || netbsd
+// +build darwin freebsd netbsd
+
+package filesys
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileGen distinguishes the given file from others that have had the same inode, using its creation time.
+func fileGen(pathname string, fi os.FileInfo) int64 {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return st.Birthtimespec.Nano()
+	}
+	return 0
+}
@@ -0,0 +1,23 @@
+package filesys
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// fileGen distinguishes the given file from others that have had the same inode, using its creation time.
+// If the file system doesn't report creation times, 0 is returned, so a file that reuses the inode of a deleted file
+// is issued that file's CellID.
+func fileGen(pathname string, fi os.FileInfo) int64 {
+	flags := unix.AT_STATX_SYNC_AS_STAT
+	if fi.Mode()&os.ModeSymlink != 0 {
+		flags |= unix.AT_SYMLINK_NOFOLLOW // fi describes the link itself (e.g. from Readdir)
+	}
+	var stx unix.Statx_t
+	err := unix.Statx(unix.AT_FDCWD, pathname, flags, unix.STATX_BTIME, &stx)
+	if err == nil && stx.Mask&unix.STATX_BTIME != 0 {
+		return stx.Btime.Sec*1e9 + int64(stx.Btime.Nsec)
+	}
+	return 0
+}
@@ -0,0 +1,12 @@
+//go:build openbsd || dragonfly
+// +build openbsd dragonfly
+
+package filesys
+
+import "os"
+
+// fileGen returns 0 since creation times aren't available, so a file that reuses the inode of a deleted file is
+// issued that file's CellID.
+func fileGen(pathname string, fi os.FileInfo) int64 {
+	return 0
+}
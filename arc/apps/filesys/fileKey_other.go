@@ -0,0 +1,16 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package filesys
+
+import "os"
+
+// fileKey identifies the given file by its canonical pathname since inodes aren't available.
+func fileKey(pathname string, fi os.FileInfo) string {
+	return pathKey(pathname)
+}
+
+// fileGen returns 0 since a pathname key isn't reused by another file while that file exists.
+func fileGen(pathname string, fi os.FileInfo) int64 {
+	return 0
+}
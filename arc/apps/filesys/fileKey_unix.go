@@ -0,0 +1,19 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package filesys
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fileKey identifies the given file by its device and inode so that its key is unaffected by renames.
+// Since an inode is reused once its file is deleted, a key is only valid alongside the file's generation (see fileGen).
+func fileKey(pathname string, fi os.FileInfo) string {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return fmt.Sprintf("ino/%d/%d", uint64(st.Dev), uint64(st.Ino))
+	}
+	return pathKey(pathname)
+}
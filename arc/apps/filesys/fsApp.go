@@ -1,13 +1,14 @@
 package filesys
 
 import (
+	"encoding/binary"
 	"mime"
 	"os"
 	"path"
 	"path/filepath"
 	"sort"
 	"strings"
-	"sync/atomic"
+	"sync"
 	"time"
 
 	"github.com/arcspace/go-arcspace/arc"
@@ -16,9 +17,13 @@ import (
 type fsApp struct {
 	// openMu  sync.Mutex
 	// openDirs map[string]*pinnedDir
-	nextID uint64
+	ctx    arc.AppContext // stores the persistent CellID of each file (see IssueCellID)
+	cellMu sync.Mutex     // serializes issuing CellIDs
 }
 
+// kNextCellID is the app storage key of the next CellID to issue.
+var kNextCellID = []byte("nextCellID")
+
 func (app *fsApp) AppURI() string {
 	return AppURI
 }
@@ -27,9 +32,64 @@ func (app *fsApp) AttrModelURIs() []string {
 	return DataModels[1:]
 }
 
-// IssueEphemeralID issued a new ID that will persist
-func (app *fsApp) IssueCellID() arc.CellID {
-	return arc.CellID(atomic.AddUint64(&app.nextID, 1) + 100)
+func (app *fsApp) StartApp(ctx arc.AppContext) error {
+	app.ctx = ctx
+	return nil
+}
+
+func (app *fsApp) StopApp() {
+}
+
+// IssueCellID returns the CellID of the given file, which persists across restarts and renames (see fileKey).
+// Each file's CellID is stored with its generation (see fileGen), so where the file system reports creation times,
+// a file that reuses the inode of a deleted file is issued a new CellID rather than the deleted file's.
+func (app *fsApp) IssueCellID(pathname string, fi os.FileInfo) (arc.CellID, error) {
+	key := []byte(fileKey(pathname, fi))
+	gen := uint64(fileGen(pathname, fi))
+
+	app.cellMu.Lock()
+	defer app.cellMu.Unlock()
+
+	// Each entry is the file's CellID followed by its generation
+	entry, err := app.ctx.GetAppValue(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(entry) == 16 && binary.BigEndian.Uint64(entry[8:]) == gen {
+		return arc.CellID(binary.BigEndian.Uint64(entry)), nil
+	}
+
+	next, err := app.ctx.GetAppValue(kNextCellID)
+	if err != nil {
+		return 0, err
+	}
+	cellID := uint64(1)
+	if len(next) == 8 {
+		cellID = binary.BigEndian.Uint64(next)
+	}
+
+	entry = make([]byte, 16)
+	binary.BigEndian.PutUint64(entry, cellID+1)
+	if err = app.ctx.PutAppValue(kNextCellID, entry[:8]); err != nil {
+		return 0, err
+	}
+	binary.BigEndian.PutUint64(entry, cellID)
+	binary.BigEndian.PutUint64(entry[8:], gen)
+	if err = app.ctx.PutAppValue(key, entry); err != nil {
+		return 0, err
+	}
+	return arc.CellID(cellID), nil
+}
+
+// pathKey identifies the given file by its canonical pathname.
+func pathKey(pathname string) string {
+	if abs, err := filepath.Abs(pathname); err == nil {
+		pathname = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(pathname); err == nil {
+		pathname = resolved
+	}
+	return "path/" + filepath.ToSlash(pathname)
 }
 
 func (app *fsApp) ResolveRequest(req *arc.CellReq) error {
@@ -46,7 +106,10 @@ func (app *fsApp) ResolveRequest(req *arc.CellReq) error {
 		if err != nil {
 			return arc.ErrCode_InvalidCell.Errorf("path not found: %q", item.pathname)
 		}
-		req.PinCell = app.IssueCellID()
+		req.PinCell, err = app.IssueCellID(item.pathname, fi)
+		if err != nil {
+			return err
+		}
 		item.setFrom(fi)
 		item.CellID = req.PinCell
 
@@ -159,7 +222,9 @@ func (dir *pinnedDir) readDir(req *arc.CellReq) error {
 			// preserve items that have not changed
 			old := lookup[sub.name]
 			if old == nil || old.Compare(sub) != 0 {
-				sub.CellID = app.IssueCellID()
+				if sub.CellID, err = app.IssueCellID(path.Join(dir.pathname, sub.name), fi); err != nil {
+					return err
+				}
 				tmp = nil
 			} else {
 				sub = old
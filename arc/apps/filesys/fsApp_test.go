@@ -0,0 +1,160 @@
+package filesys
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/host"
+	"github.com/arcspace/go-arcspace/arc/host/hosttest"
+)
+
+var (
+	fsDirSchema = &arc.AttrSchema{
+		AttrModelURI: DataModels[DirItem],
+		SchemaName:   "dir",
+		SchemaID:     1,
+		Attrs: []*arc.AttrSpec{
+			{AttrURI: "name.string", AttrID: 1},
+		},
+	}
+	fsFileSchema = &arc.AttrSchema{
+		AttrModelURI: DataModels[FileItem],
+		SchemaName:   "file",
+		SchemaID:     2,
+		Attrs: []*arc.AttrSpec{
+			{AttrURI: "name.string", AttrID: 1},
+		},
+	}
+)
+
+// testSub collects the msgs pushed to a request.
+type testSub struct {
+	msgs []*arc.Msg
+}
+
+func (sub *testSub) PushMsg(msg *arc.Msg) error {
+	sub.msgs = append(sub.msgs, msg)
+	return nil
+}
+
+func (sub *testSub) PublishUpdate(batch *arc.MsgBatch) error {
+	return nil
+}
+
+func (sub *testSub) Done() <-chan struct{} {
+	return nil
+}
+
+// pinDir pins the given dir via the given App, returning the CellID of the dir and each item in it by name.
+func pinDir(t *testing.T, app *fsApp, pathname string) map[string]uint64 {
+	t.Helper()
+	sub := &testSub{}
+	req := &arc.CellReq{
+		CellSub:       sub,
+		PinURI:        pathname,
+		ContentSchema: fsDirSchema,
+		ChildSchemas:  []*arc.AttrSchema{fsFileSchema},
+		ParentApp:     app,
+	}
+	if err := app.ResolveRequest(req); err != nil {
+		t.Fatal(err)
+	}
+	if err := req.PinnedCell.PushCellState(req); err != nil {
+		t.Fatal(err)
+	}
+	items := make(map[string]uint64)
+	for _, msg := range sub.msgs {
+		if msg.Op == arc.MsgOp_PushAttr && msg.AttrID == 1 {
+			items[string(msg.ValBuf)] = msg.CellID
+		}
+	}
+	return items
+}
+
+func TestCellIDs(t *testing.T) {
+	dir := t.TempDir()
+	files := dir + "/files"
+	if err := os.Mkdir(files, 0700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(files+"/"+name, []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each host started keeps its state in the same dir
+	startApp := func() (arc.Host, *fsApp) {
+		h := hosttest.StartTestHost(t, func(opts *host.HostOpts) {
+			opts.StatePath = dir + "/state"
+			opts.CachePath = dir + "/cache"
+		})
+		app := NewApp().(*fsApp)
+		if err := h.RegisterApp(app); err != nil {
+			t.Fatal(err)
+		}
+		return h, app
+	}
+
+	h, app := startApp()
+	before := pinDir(t, app, files)
+	if len(before) != 3 || before["a.txt"] == 0 || before["a.txt"] == before["b.txt"] {
+		t.Fatalf("unexpected items: %v", before)
+	}
+	h.Close()
+	<-h.Done()
+
+	// CellIDs persist across restarts and renames
+	if err := os.Rename(files+"/b.txt", files+"/c.txt"); err != nil {
+		t.Fatal(err)
+	}
+	_, app = startApp()
+	after := pinDir(t, app, files)
+	if after["files"] != before["files"] {
+		t.Fatalf("expected dir to keep CellID %d, got %d", before["files"], after["files"])
+	}
+	if after["a.txt"] != before["a.txt"] {
+		t.Fatalf("expected a.txt to keep CellID %d, got %d", before["a.txt"], after["a.txt"])
+	}
+	if after["c.txt"] != before["b.txt"] {
+		t.Fatalf("expected renamed file to keep CellID %d, got %d", before["b.txt"], after["c.txt"])
+	}
+
+	// Rewriting a file keeps its CellID
+	if err := os.WriteFile(files+"/a.txt", []byte("rewritten"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(files + "/a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cellID, err := app.IssueCellID(files+"/a.txt", fi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(cellID) != before["a.txt"] {
+		t.Fatalf("expected rewritten a.txt to keep CellID %d, got %d", before["a.txt"], cellID)
+	}
+
+	// A file that reuses the inode of a deleted file (i.e. of another generation) is issued a new CellID
+	key := []byte(fileKey(files+"/a.txt", fi))
+	entry, err := app.ctx.GetAppValue(key)
+	if err != nil || len(entry) != 16 {
+		t.Fatalf("expected stored CellID, got %v %v", entry, err)
+	}
+	binary.BigEndian.PutUint64(entry[8:], binary.BigEndian.Uint64(entry[8:])+1)
+	if err = app.ctx.PutAppValue(key, entry); err != nil {
+		t.Fatal(err)
+	}
+	cellID, err = app.IssueCellID(files+"/a.txt", fi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, prev := range after {
+		if uint64(cellID) == prev {
+			t.Fatalf("expected a new CellID, got %d (of %s)", cellID, name)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package sys
+
+import "github.com/arcspace/go-arcspace/arc"
+
+// NewApp returns the system App, allowing clients to manage the host it's registered with and monitor what that host is running.
+func NewApp() arc.App {
+	return &sysApp{}
+}
+
+const (
+	AppBaseName = "sys"
+	AppURI      = "arcspace.systems/sys.app/v1.2023.1"
+)
+
+// AttrModelURIs
+const (
+	// PlanetsModel lists the host's planets as child cells (see MsgOp_CreatePlanet and MsgOp_DeletePlanet).
+	// Like HostModel, pinning it requires PlanetRole_Owner on the host's planet.
+	PlanetsModel = "sys/planets"
+	PlanetModel  = "sys/planet" // a planet listed by a PlanetsModel or HostModel cell
+
+	// HostModel is a live view of what the host is running (see arc.HostStatus), pushing changes as they occur.
+	// Its child cells are the host's open sessions, their open requests, mounted planets, active cells, and registered Apps,
+	// where only the child models given in PinReq.ChildSchemas are pushed.
+	// A child cell that goes away is pushed a final status (attr_Status) and is not pushed again.
+	// Pinning it requires PlanetRole_Owner on the host's planet (see arc.Host.HostPlanet).
+	HostModel    = "sys/host"
+	SessionModel = "sys/session" // an open session
+	ReqModel     = "sys/req"     // a request open in a session
+	CellModel    = "sys/cell"    // an active cell of a mounted planet
+	AppModel     = "sys/app"     // a registered App
+)
+
+// AttrURIs
+const (
+	attr_PlanetName = "name.string"
+	attr_PlanetID   = "planet-id.int"
+	attr_Status     = "status.string" // "mounted" or "unmounted" for planets, otherwise "open" or "closed"
+
+	attr_NumSessions  = "sessions.int"
+	attr_NumPlanets   = "planets.int"
+	attr_NumCells     = "cells.int"
+	attr_NumApps      = "apps.int"
+	attr_SessionID    = "session-id.int"
+	attr_Desc         = "desc.string" // describes the stream carrying a session ("" while awaiting resumption)
+	attr_HomePlanetID = "home-planet-id.int"
+	attr_NumReqs      = "reqs.int"
+	attr_ReqID        = "req-id.int"
+	attr_CellID       = "cell-id.int"
+	attr_ModelURI     = "model.string"
+	attr_NumSubs      = "subs.int"
+	attr_AppURI       = "app-uri.string"
+	attr_ModelURIs    = "models.string" // comma separated
+)
@@ -0,0 +1,252 @@
+package sys
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// statusSettle is how long a pinned HostModel cell waits after the host's status changes before pushing,
+// so that a burst of changes (e.g. a session opening and pinning cells) is pushed as one update.
+const statusSettle = 100 * time.Millisecond
+
+// hostStatus is a pinned HostModel cell that pushes changes to the host's status until its request closes.
+type hostStatus struct {
+	app     *sysApp
+	cellIDs map[string]arc.CellID      // child CellIDs by statusItem.key
+	pushed  map[arc.CellID]*statusItem // state of each cell last pushed
+}
+
+// statusItem is the state of the pinned cell or one of its child cells.
+type statusItem struct {
+	key    string
+	cellID arc.CellID
+	schema *arc.AttrSchema
+	attrs  []statusAttr
+}
+
+type statusAttr struct {
+	attrURI string
+	val     interface{} // string or int64
+}
+
+func newHostStatus(app *sysApp) *hostStatus {
+	return &hostStatus{
+		app:     app,
+		cellIDs: make(map[string]arc.CellID),
+	}
+}
+
+func (cell *hostStatus) PushCellState(req *arc.CellReq) error {
+	changed := cell.app.host.HostStatusChanged()
+	items := cell.items(req, cell.app.host.HostStatus())
+	for _, item := range items {
+		req.PushInsertCell(item.cellID, item.schema)
+		for _, attr := range item.attrs {
+			req.PushAttr(item.cellID, item.schema, attr.attrURI, attr.val)
+		}
+	}
+	cell.pushed = itemsByID(items)
+
+	go cell.pushChanges(req, changed)
+	return nil
+}
+
+// pushChanges publishes what has changed each time the host's status changes after it was last pushed.
+func (cell *hostStatus) pushChanges(req *arc.CellReq, changed <-chan struct{}) {
+	for {
+		select {
+		case <-changed:
+		case <-req.Done():
+			return
+		case <-cell.app.ctx.Closing():
+			return
+		}
+
+		select {
+		case <-time.After(statusSettle):
+		case <-req.Done():
+			return
+		case <-cell.app.ctx.Closing():
+			return
+		}
+
+		changed = cell.app.host.HostStatusChanged()
+		items := cell.items(req, cell.app.host.HostStatus())
+		batch := cell.changes(items)
+		cell.pushed = itemsByID(items)
+		if batch == nil {
+			continue
+		}
+		if err := req.PublishUpdate(batch); err != nil {
+			return
+		}
+	}
+}
+
+// changes returns a batch of the given items that differ from what was last pushed (or nil if none).
+// Items that are no longer present are pushed their final status.
+func (cell *hostStatus) changes(items []*statusItem) *arc.MsgBatch {
+	var msgs []*arc.Msg
+	for _, item := range items {
+		prev := cell.pushed[item.cellID]
+		start := len(msgs)
+		for i, attr := range item.attrs {
+			if prev == nil || prev.attrs[i].val != attr.val {
+				msgs = appendAttr(msgs, item, attr.attrURI, attr.val)
+			}
+		}
+		if len(msgs) > start {
+			msgs = insertCellAt(msgs, start, item)
+		}
+	}
+
+	current := itemsByID(items)
+	for cellID, prev := range cell.pushed {
+		if current[cellID] == nil {
+			msgs = append(msgs, insertCell(prev))
+			msgs = appendAttr(msgs, prev, attr_Status, closedStatus(prev.schema))
+			delete(cell.cellIDs, prev.key)
+		}
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+	batch := arc.NewMsgBatch()
+	batch.AddMsgs(msgs)
+	return batch
+}
+
+// items returns the pinned cell's item followed by an item for each child cell of a model the given req pins.
+func (cell *hostStatus) items(req *arc.CellReq, status arc.HostStatus) []*statusItem {
+	items := []*statusItem{{
+		cellID: req.PinCell,
+		schema: req.ContentSchema,
+		attrs: []statusAttr{
+			{attr_NumSessions, int64(len(status.Sessions))},
+			{attr_NumPlanets, int64(len(status.Planets))},
+			{attr_NumCells, int64(len(status.Cells))},
+			{attr_NumApps, int64(len(status.Apps))},
+		},
+	}}
+
+	add := func(model, key string, attrs ...statusAttr) {
+		schema := req.GetChildSchema(model)
+		if schema == nil {
+			return
+		}
+		cellID := cell.cellIDs[key]
+		if cellID == 0 {
+			cellID = cell.app.IssueCellID()
+			cell.cellIDs[key] = cellID
+		}
+		items = append(items, &statusItem{
+			key:    key,
+			cellID: cellID,
+			schema: schema,
+			attrs:  attrs,
+		})
+	}
+
+	for _, sess := range status.Sessions {
+		add(SessionModel, fmt.Sprintf("session/%d", sess.SessionID),
+			statusAttr{attr_SessionID, int64(sess.SessionID)},
+			statusAttr{attr_Desc, sess.Desc},
+			statusAttr{attr_HomePlanetID, int64(sess.HomePlanetID)},
+			statusAttr{attr_NumReqs, int64(len(sess.OpenReqs))},
+			statusAttr{attr_Status, "open"},
+		)
+		for _, req := range sess.OpenReqs {
+			add(ReqModel, fmt.Sprintf("req/%d/%d", sess.SessionID, req.ReqID),
+				statusAttr{attr_SessionID, int64(sess.SessionID)},
+				statusAttr{attr_ReqID, int64(req.ReqID)},
+				statusAttr{attr_PlanetID, int64(req.PlanetID)},
+				statusAttr{attr_CellID, int64(req.PinCell)},
+				statusAttr{attr_ModelURI, req.ModelURI},
+				statusAttr{attr_Status, "open"},
+			)
+		}
+	}
+	for _, pl := range status.Planets {
+		add(PlanetModel, fmt.Sprintf("planet/%d", pl.PlanetID),
+			statusAttr{attr_PlanetName, pl.Name},
+			statusAttr{attr_PlanetID, int64(pl.PlanetID)},
+			statusAttr{attr_Status, "mounted"},
+		)
+	}
+	for _, c := range status.Cells {
+		add(CellModel, fmt.Sprintf("cell/%d/%s/%d", c.PlanetID, c.AppURI, c.CellID),
+			statusAttr{attr_PlanetID, int64(c.PlanetID)},
+			statusAttr{attr_CellID, int64(c.CellID)},
+			statusAttr{attr_AppURI, c.AppURI},
+			statusAttr{attr_NumSubs, int64(c.Subs)},
+			statusAttr{attr_Status, "open"},
+		)
+	}
+	for _, app := range status.Apps {
+		add(AppModel, "app/"+app.AppURI,
+			statusAttr{attr_AppURI, app.AppURI},
+			statusAttr{attr_ModelURIs, strings.Join(app.AttrModelURIs, ", ")},
+			statusAttr{attr_Status, "open"},
+		)
+	}
+
+	return items
+}
+
+// closedStatus returns the final status of a child cell of the given schema that has gone away.
+func closedStatus(schema *arc.AttrSchema) string {
+	if schema.AttrModelURI == PlanetModel {
+		return "unmounted"
+	}
+	return "closed"
+}
+
+func itemsByID(items []*statusItem) map[arc.CellID]*statusItem {
+	byID := make(map[arc.CellID]*statusItem, len(items))
+	for _, item := range items {
+		byID[item.cellID] = item
+	}
+	return byID
+}
+
+func insertCell(item *statusItem) *arc.Msg {
+	msg := arc.NewMsg()
+	msg.CellID = item.cellID.U64()
+	msg.Op = arc.MsgOp_InsertCell
+	msg.ValType = int32(arc.ValType_SchemaID)
+	msg.ValInt = int64(item.schema.SchemaID)
+	return msg
+}
+
+// insertCellAt inserts an InsertCell msg for the given item at index i.
+func insertCellAt(msgs []*arc.Msg, i int, item *statusItem) []*arc.Msg {
+	msgs = append(msgs, nil)
+	copy(msgs[i+1:], msgs[i:])
+	msgs[i] = insertCell(item)
+	return msgs
+}
+
+// appendAttr appends a PushAttr msg setting the given attr of the given item (if its schema has the attr).
+func appendAttr(msgs []*arc.Msg, item *statusItem, attrURI string, val interface{}) []*arc.Msg {
+	attr := item.schema.LookupAttr(attrURI)
+	if attr == nil {
+		return msgs
+	}
+
+	msg := arc.NewMsg()
+	msg.CellID = item.cellID.U64()
+	msg.Op = arc.MsgOp_PushAttr
+	msg.AttrID = attr.AttrID
+	if attr.SeriesType == arc.SeriesType_Fixed {
+		msg.SI = attr.BoundSI
+	}
+	msg.SetVal(val)
+	if attr.ValTypeID != 0 {
+		msg.ValType = int32(attr.ValTypeID)
+	}
+	return append(msgs, msg)
+}
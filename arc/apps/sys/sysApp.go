@@ -0,0 +1,105 @@
+package sys
+
+import (
+	"sync/atomic"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+type sysApp struct {
+	ctx    arc.AppContext
+	host   arc.Host
+	nextID uint64
+}
+
+func (app *sysApp) AppURI() string {
+	return AppURI
+}
+
+func (app *sysApp) AttrModelURIs() []string {
+	return []string{
+		PlanetsModel,
+		HostModel,
+	}
+}
+
+func (app *sysApp) StartApp(ctx arc.AppContext) error {
+	app.ctx = ctx
+	app.host = ctx.Host()
+	return nil
+}
+
+func (app *sysApp) StopApp() {
+}
+
+// IssueCellID issues a new ephemeral CellID
+func (app *sysApp) IssueCellID() arc.CellID {
+	return arc.CellID(atomic.AddUint64(&app.nextID, 1) + 100)
+}
+
+func (app *sysApp) ResolveRequest(req *arc.CellReq) error {
+
+	// Sys cells expose every planet on the host, so only those who own the host's planet can view them
+	if err := checkOwner(req.User, app.host.HostPlanet().PlanetID()); err != nil {
+		return err
+	}
+
+	switch req.ContentSchema.AttrModelURI {
+	case HostModel:
+		req.PinnedCell = newHostStatus(app)
+	default:
+		planets, err := app.host.ListPlanets()
+		if err != nil {
+			return err
+		}
+		req.PinnedCell = &planetList{
+			planets: planets,
+		}
+	}
+
+	// Sys cells describe the host rather than a user's planet, so they're served from the host's planet
+	req.PlanetID = app.host.HostPlanet().PlanetID()
+	req.PinCell = app.IssueCellID()
+	return nil
+}
+
+// checkOwner returns ErrCode_InsufficientPermissions unless the given user owns the given planet.
+func checkOwner(user arc.User, planetID uint64) error {
+	if user == nil {
+		return arc.ErrCode_InvalidLogin.Error("not logged in")
+	}
+	role, err := user.PlanetRole(planetID)
+	if err != nil {
+		return err
+	}
+	if role < arc.PlanetRole_Owner {
+		return arc.ErrCode_InsufficientPermissions.Errorf("%v required on planet ID=%v", arc.PlanetRole_Owner, planetID)
+	}
+	return nil
+}
+
+type planetList struct {
+	planets []arc.PlanetInfo
+}
+
+func (list *planetList) PushCellState(req *arc.CellReq) error {
+	req.PushInsertCell(req.PinCell, req.ContentSchema)
+
+	schema := req.GetChildSchema(PlanetModel)
+	if schema == nil {
+		return nil
+	}
+
+	for _, pl := range list.planets {
+		cellID := arc.CellID(pl.PlanetID)
+		req.PushInsertCell(cellID, schema)
+		req.PushAttr(cellID, schema, attr_PlanetName, pl.Name)
+		req.PushAttr(cellID, schema, attr_PlanetID, int64(pl.PlanetID))
+		status := "unmounted"
+		if pl.Mounted {
+			status = "mounted"
+		}
+		req.PushAttr(cellID, schema, attr_Status, status)
+	}
+	return nil
+}
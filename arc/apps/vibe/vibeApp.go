@@ -23,6 +23,13 @@ func (app *vibeApp) AttrModelURIs() []string {
 	}
 }
 
+func (app *vibeApp) StartApp(ctx arc.AppContext) error {
+	return nil
+}
+
+func (app *vibeApp) StopApp() {
+}
+
 // IssueEphemeralID issued a new ID that will persist
 func (app *vibeApp) IssueEphemeralID() arc.CellID {
 	return arc.CellID(atomic.AddUint64(&app.nextID, 1) + 100)
@@ -61,6 +61,8 @@ const (
 	Const_TIDTimestampSz Const = 8
 	// DefaultGrpcServicePort is the TCP port the service HostGrpc should run on by default.
 	Const_DefaultGrpcServicePort Const = 5192
+	// DefaultWsServicePort is the TCP port the WebSocket HostService should run on by default.
+	Const_DefaultWsServicePort Const = 5193
 )
 
 var Const_name = map[int32]string{
@@ -71,6 +73,7 @@ var Const_name = map[int32]string{
 	48:   "Const_TIDStringLen",
 	8:    "Const_TIDTimestampSz",
 	5192: "Const_DefaultGrpcServicePort",
+	5193: "Const_DefaultWsServicePort",
 }
 
 var Const_value = map[string]int32{
@@ -81,6 +84,7 @@ var Const_value = map[string]int32{
 	"Const_TIDStringLen":           48,
 	"Const_TIDTimestampSz":         8,
 	"Const_DefaultGrpcServicePort": 5192,
+	"Const_DefaultWsServicePort":   5193,
 }
 
 func (Const) EnumDescriptor() ([]byte, []int) {
@@ -91,29 +95,31 @@ func (Const) EnumDescriptor() ([]byte, []int) {
 type ValType int32
 
 const (
-	ValType_nil         ValType = 0
-	ValType_int         ValType = 4
-	ValType_bytes       ValType = 6
-	ValType_string      ValType = 7
-	ValType_TID         ValType = 16
-	ValType_SchemaID    ValType = 18
-	ValType_Blob        ValType = 22
-	ValType_DateTime    ValType = 23
-	ValType_Duration    ValType = 24
-	ValType_AssetURI    ValType = 25
-	ValType_URL         ValType = 26
-	ValType_Err         ValType = 50
-	ValType_DataSegment ValType = 52
-	ValType_Content     ValType = 54
-	ValType_CryptoKey   ValType = 56
-	ValType_Txn         ValType = 58
-	ValType_LoginReq    ValType = 60
-	ValType_Defs        ValType = 62
-	ValType_PinReq      ValType = 64
-	ValType_AttrRange   ValType = 66
-	ValType_Link        ValType = 80
-	ValType_GeoFix      ValType = 82
-	ValType_TRS         ValType = 84
+	ValType_nil            ValType = 0
+	ValType_int            ValType = 4
+	ValType_bytes          ValType = 6
+	ValType_string         ValType = 7
+	ValType_TID            ValType = 16
+	ValType_SchemaID       ValType = 18
+	ValType_Blob           ValType = 22
+	ValType_DateTime       ValType = 23
+	ValType_Duration       ValType = 24
+	ValType_AssetURI       ValType = 25
+	ValType_URL            ValType = 26
+	ValType_Err            ValType = 50
+	ValType_DataSegment    ValType = 52
+	ValType_Content        ValType = 54
+	ValType_CryptoKey      ValType = 56
+	ValType_Txn            ValType = 58
+	ValType_LoginReq       ValType = 60
+	ValType_Defs           ValType = 62
+	ValType_PinReq         ValType = 64
+	ValType_AttrRange      ValType = 66
+	ValType_LoginChallenge ValType = 68
+	ValType_PlanetGrant    ValType = 70
+	ValType_Link           ValType = 80
+	ValType_GeoFix         ValType = 82
+	ValType_TRS            ValType = 84
 	// Clients have above this value to bind their own ValTypeIDs
 	ValType_BuiltinMax ValType = 999
 )
@@ -139,6 +145,8 @@ var ValType_name = map[int32]string{
 	62:  "ValType_Defs",
 	64:  "ValType_PinReq",
 	66:  "ValType_AttrRange",
+	68:  "ValType_LoginChallenge",
+	70:  "ValType_PlanetGrant",
 	80:  "ValType_Link",
 	82:  "ValType_GeoFix",
 	84:  "ValType_TRS",
@@ -146,30 +154,32 @@ var ValType_name = map[int32]string{
 }
 
 var ValType_value = map[string]int32{
-	"ValType_nil":         0,
-	"ValType_int":         4,
-	"ValType_bytes":       6,
-	"ValType_string":      7,
-	"ValType_TID":         16,
-	"ValType_SchemaID":    18,
-	"ValType_Blob":        22,
-	"ValType_DateTime":    23,
-	"ValType_Duration":    24,
-	"ValType_AssetURI":    25,
-	"ValType_URL":         26,
-	"ValType_Err":         50,
-	"ValType_DataSegment": 52,
-	"ValType_Content":     54,
-	"ValType_CryptoKey":   56,
-	"ValType_Txn":         58,
-	"ValType_LoginReq":    60,
-	"ValType_Defs":        62,
-	"ValType_PinReq":      64,
-	"ValType_AttrRange":   66,
-	"ValType_Link":        80,
-	"ValType_GeoFix":      82,
-	"ValType_TRS":         84,
-	"ValType_BuiltinMax":  999,
+	"ValType_nil":            0,
+	"ValType_int":            4,
+	"ValType_bytes":          6,
+	"ValType_string":         7,
+	"ValType_TID":            16,
+	"ValType_SchemaID":       18,
+	"ValType_Blob":           22,
+	"ValType_DateTime":       23,
+	"ValType_Duration":       24,
+	"ValType_AssetURI":       25,
+	"ValType_URL":            26,
+	"ValType_Err":            50,
+	"ValType_DataSegment":    52,
+	"ValType_Content":        54,
+	"ValType_CryptoKey":      56,
+	"ValType_Txn":            58,
+	"ValType_LoginReq":       60,
+	"ValType_Defs":           62,
+	"ValType_PinReq":         64,
+	"ValType_AttrRange":      66,
+	"ValType_LoginChallenge": 68,
+	"ValType_PlanetGrant":    70,
+	"ValType_Link":           80,
+	"ValType_GeoFix":         82,
+	"ValType_TRS":            84,
+	"ValType_BuiltinMax":     999,
 }
 
 func (ValType) EnumDescriptor() ([]byte, []int) {
@@ -182,11 +192,16 @@ type MsgOp int32
 const (
 	MsgOp_NoOp MsgOp = 0
 	// From the client to host, this requests to login to the host.
-	// From the host to client, this is a reply with a challenge.
+	// From the host to client, this is a reply with a challenge (a LoginChallenge containing a Nonce).
+	// The client then replies with MsgOp_Login (using the same ReqID) containing the Nonce and its signature,
+	// signed using the private key paired with LoginReq.PubKey.
+	// On success, the host sends MsgOp_Login (a LoginChallenge containing a ResumeToken) and then MsgOp_CloseReq,
+	// otherwise MsgOp_CloseReq carrying ErrCode_InvalidLogin.
+	// If a stream drops, a new stream can resume the session by sending a LoginReq containing only its ResumeToken.
 	//
 	// Params:
 	//      Msg.ReqID:        client-generated (unique) request ID
-	//      Msg.ValType:      ValType_LoginReq
+	//      Msg.ValType:      ValType_LoginReq or ValType_LoginChallenge
 	//      Msg.ValBuf:       req params
 	MsgOp_Login MsgOp = 1
 	// MsgOp_ResolveAndRegister allows a client to send the host a set of Defs to the Host, defining all session CellTypes and identifiers.
@@ -206,6 +221,17 @@ const (
 	//      Msg.ValType:    ValType_PinReq          (client to host, otherwise nil)
 	//      Msg.ValBuf:     PinReq                  (client to host, otherwise nil)
 	MsgOp_PinCell MsgOp = 6
+	// From client to host, this pins a SI range of a series attr of the cell pinned by an open PinCell request.
+	// Stored attr items within the range are pushed (newest to oldest), followed by MsgOp_Commit.
+	// Subsequently committed items that fall within the pinned range(s) are pushed as they arrive.
+	//
+	// Params:
+	//      Msg.ReqID:      ReqID of the open MsgOp_PinCell request
+	//      Msg.CellID:     which cell (or 0 for the pinned cell)
+	//      Msg.AttrID:     which series attr (as specified by PinReq.ContentSchema)
+	//      Msg.ValType:    ValType_AttrRange
+	//      Msg.ValBuf:     AttrRange
+	MsgOp_PinAttrRange MsgOp = 8
 	// Used to push attr values.
 	// A cell attr item us specified by the host via ReqID+AttrID+SI and its value type via ValType.
 	//
@@ -229,10 +255,49 @@ const (
 	// Used by the Host to signal that the request associated with ReqID is up to date and in a state to be processed by the client.
 	// This msg is typically used to drive UI updates or other aggregate cell dependencies.
 	//
+	// From client to host, this atomically commits the MsgOp_InsertCell and MsgOp_PushAttr msgs previously sent under ReqID,
+	// along with any msgs in the accompanying Txn.  On success, the host replies with MsgOp_Commit,
+	// otherwise the host replies with MsgOp_CloseReq carrying ErrCode_CommitFailed.
+	//
 	// Params:
 	//      Msg.ReqID:      originating request ID
 	//      Msg.CellID:     which cell is an an updated state
+	//      Msg.ValType:    ValType_Txn             (client to host, optional)
+	//      Msg.ValBuf:     Txn                     (client to host, optional)
 	MsgOp_Commit MsgOp = 24
+	// From client to host, this sets a user's role on a planet, which requires the logged in user own the planet.
+	// Granting PlanetRole_None revokes the user's role.  The host replies with MsgOp_CloseReq (carrying an Err on failure).
+	//
+	// Params:
+	//      Msg.ReqID:      client-generated (unique) request ID
+	//      Msg.ValType:    ValType_PlanetGrant
+	//      Msg.ValBuf:     PlanetGrant
+	MsgOp_GrantPlanetRole MsgOp = 30
+	// From client to host, this creates a new planet owned by the logged in user (see HostOpts.MaxUserPlanets).
+	// The host replies with MsgOp_CreatePlanet carrying the new planet's ID, followed by MsgOp_CloseReq (carrying an Err on failure).
+	//
+	// Params:
+	//      Msg.ReqID:      client-generated (unique) request ID
+	//      Msg.ValType:    ValType_string (client to host), ValType_int (host to client)
+	//      Msg.ValBuf:     CommonName of the new planet (client to host)
+	//      Msg.ValInt:     PlanetID of the new planet (host to client)
+	MsgOp_CreatePlanet MsgOp = 31
+	// From client to host, this closes the given planet (it is mounted again when next accessed), which requires the logged in user own the planet.
+	// The host replies with MsgOp_CloseReq (carrying an Err on failure).
+	//
+	// Params:
+	//      Msg.ReqID:      client-generated (unique) request ID
+	//      Msg.ValType:    ValType_int
+	//      Msg.ValInt:     PlanetID
+	MsgOp_UnmountPlanet MsgOp = 32
+	// From client to host, this unmounts and permanently deletes the given planet, which requires the logged in user own the planet.
+	// A user's home planet can't be deleted.  The host replies with MsgOp_CloseReq (carrying an Err on failure).
+	//
+	// Params:
+	//      Msg.ReqID:      client-generated (unique) request ID
+	//      Msg.ValType:    ValType_int
+	//      Msg.ValInt:     PlanetID
+	MsgOp_DeletePlanet MsgOp = 33
 	// From the client to host, this signals to cancel the operation(s) associated with the given request ID (PinID).
 	// From the host to client, this signals that the given request ID has been canceled / discarded (and is now closed).
 	// if Msg.ValType == ValType_Err, amplifying info in included as to why the request was closed.
@@ -248,9 +313,14 @@ var MsgOp_name = map[int32]string{
 	1:   "MsgOp_Login",
 	5:   "MsgOp_ResolveAndRegister",
 	6:   "MsgOp_PinCell",
+	8:   "MsgOp_PinAttrRange",
 	10:  "MsgOp_PushAttr",
 	14:  "MsgOp_InsertCell",
 	24:  "MsgOp_Commit",
+	30:  "MsgOp_GrantPlanetRole",
+	31:  "MsgOp_CreatePlanet",
+	32:  "MsgOp_UnmountPlanet",
+	33:  "MsgOp_DeletePlanet",
 	255: "MsgOp_CloseReq",
 }
 
@@ -259,9 +329,14 @@ var MsgOp_value = map[string]int32{
 	"MsgOp_Login":              1,
 	"MsgOp_ResolveAndRegister": 5,
 	"MsgOp_PinCell":            6,
+	"MsgOp_PinAttrRange":       8,
 	"MsgOp_PushAttr":           10,
 	"MsgOp_InsertCell":         14,
 	"MsgOp_Commit":             24,
+	"MsgOp_GrantPlanetRole":    30,
+	"MsgOp_CreatePlanet":       31,
+	"MsgOp_UnmountPlanet":      32,
+	"MsgOp_DeletePlanet":       33,
 	"MsgOp_CloseReq":           255,
 }
 
@@ -401,6 +476,7 @@ const (
 	ErrCode_PlanetNotFound          ErrCode = 5032
 	ErrCode_PlanetFailure           ErrCode = 5033
 	ErrCode_AppNotFound             ErrCode = 5034
+	ErrCode_AppConflict             ErrCode = 5035
 	ErrCode_NoAttrsFound            ErrCode = 5036
 	ErrCode_MalformedTx             ErrCode = 5040
 	ErrCode_TypeNotFound            ErrCode = 5050
@@ -433,6 +509,7 @@ var ErrCode_name = map[int32]string{
 	5032: "ErrCode_PlanetNotFound",
 	5033: "ErrCode_PlanetFailure",
 	5034: "ErrCode_AppNotFound",
+	5035: "ErrCode_AppConflict",
 	5036: "ErrCode_NoAttrsFound",
 	5040: "ErrCode_MalformedTx",
 	5050: "ErrCode_TypeNotFound",
@@ -465,6 +542,7 @@ var ErrCode_value = map[string]int32{
 	"ErrCode_PlanetNotFound":          5032,
 	"ErrCode_PlanetFailure":           5033,
 	"ErrCode_AppNotFound":             5034,
+	"ErrCode_AppConflict":             5035,
 	"ErrCode_NoAttrsFound":            5036,
 	"ErrCode_MalformedTx":             5040,
 	"ErrCode_TypeNotFound":            5050,
@@ -482,6 +560,57 @@ func (ErrCode) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_655fece6a71483b6, []int{7}
 }
 
+// MsgDir is the direction a Msg travels over a session's stream.
+type MsgDir int32
+
+const (
+	MsgDir_ToHost   MsgDir = 0
+	MsgDir_ToClient MsgDir = 1
+)
+
+var MsgDir_name = map[int32]string{
+	0: "MsgDir_ToHost",
+	1: "MsgDir_ToClient",
+}
+
+var MsgDir_value = map[string]int32{
+	"MsgDir_ToHost":   0,
+	"MsgDir_ToClient": 1,
+}
+
+func (MsgDir) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_655fece6a71483b6, []int{8}
+}
+
+// PlanetRole is a user's access to a planet, where each role includes the access of the roles below it.
+// A user owns their home planet, otherwise a user's role is as granted by the planet's owner (see MsgOp_GrantPlanetRole).
+type PlanetRole int32
+
+const (
+	PlanetRole_None     PlanetRole = 0
+	PlanetRole_ReadOnly PlanetRole = 1
+	PlanetRole_Member   PlanetRole = 2
+	PlanetRole_Owner    PlanetRole = 3
+)
+
+var PlanetRole_name = map[int32]string{
+	0: "PlanetRole_None",
+	1: "PlanetRole_ReadOnly",
+	2: "PlanetRole_Member",
+	3: "PlanetRole_Owner",
+}
+
+var PlanetRole_value = map[string]int32{
+	"PlanetRole_None":     0,
+	"PlanetRole_ReadOnly": 1,
+	"PlanetRole_Member":   2,
+	"PlanetRole_Owner":    3,
+}
+
+func (PlanetRole) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_655fece6a71483b6, []int{9}
+}
+
 type TRS_VisualScaleMode int32
 
 const (
@@ -500,7 +629,7 @@ var TRS_VisualScaleMode_value = map[string]int32{
 }
 
 func (TRS_VisualScaleMode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{14, 0}
+	return fileDescriptor_655fece6a71483b6, []int{16, 0}
 }
 
 type Msg struct {
@@ -704,6 +833,8 @@ func (m *PlanetEpoch) GetCommonName() string {
 type UserSeat struct {
 	UserID       uint64 `protobuf:"varint,2,opt,name=UserID,proto3" json:"UserID,omitempty"`
 	HomePlanetID uint64 `protobuf:"varint,4,opt,name=HomePlanetID,proto3" json:"HomePlanetID,omitempty"`
+	// The public key that login challenges must be signed with (registered at first login)
+	PubKey *CryptoKey `protobuf:"bytes,6,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
 }
 
 func (m *UserSeat) Reset()      { *m = UserSeat{} }
@@ -752,10 +883,23 @@ func (m *UserSeat) GetHomePlanetID() uint64 {
 	return 0
 }
 
+func (m *UserSeat) GetPubKey() *CryptoKey {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
 type LoginReq struct {
 	// A byte string identifying user who is logging in (lot limited to UTF8)
 	// This is typically the persistent UID given by the device OS that only changes when the app is reinstalled.
 	UserUID []byte `protobuf:"bytes,1,opt,name=UserUID,proto3" json:"UserUID,omitempty"`
+	// The signing public key of the user logging in.
+	// This is registered when a user first logs in, after which login challenges must be signed by the paired private key.
+	PubKey *CryptoKey `protobuf:"bytes,3,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
+	// If set, this stream resumes the logged in session that was issued this token (and all other fields are ignored).
+	// A session can be resumed for HostOpts.ResumeGrace after its previous stream drops, picking up its open requests and registered types.
+	ResumeToken []byte `protobuf:"bytes,4,opt,name=ResumeToken,proto3" json:"ResumeToken,omitempty"`
 }
 
 func (m *LoginReq) Reset()      { *m = LoginReq{} }
@@ -797,6 +941,136 @@ func (m *LoginReq) GetUserUID() []byte {
 	return nil
 }
 
+func (m *LoginReq) GetPubKey() *CryptoKey {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *LoginReq) GetResumeToken() []byte {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return nil
+}
+
+// LoginChallenge is sent from the host to the client (containing Nonce) and then back to the host (also containing Signature).
+type LoginChallenge struct {
+	Nonce []byte `protobuf:"bytes,1,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	// Signature of Nonce, signed using the private key paired with LoginReq.PubKey
+	Signature []byte `protobuf:"bytes,2,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	// Sent from the host once a login succeeds, allowing the client to resume the session over a new stream (see LoginReq.ResumeToken).
+	ResumeToken []byte `protobuf:"bytes,3,opt,name=ResumeToken,proto3" json:"ResumeToken,omitempty"`
+}
+
+func (m *LoginChallenge) Reset()      { *m = LoginChallenge{} }
+func (*LoginChallenge) ProtoMessage() {}
+func (*LoginChallenge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_655fece6a71483b6, []int{4}
+}
+func (m *LoginChallenge) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LoginChallenge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LoginChallenge.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LoginChallenge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoginChallenge.Merge(m, src)
+}
+func (m *LoginChallenge) XXX_Size() int {
+	return m.Size()
+}
+func (m *LoginChallenge) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoginChallenge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoginChallenge proto.InternalMessageInfo
+
+func (m *LoginChallenge) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *LoginChallenge) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *LoginChallenge) GetResumeToken() []byte {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return nil
+}
+
+// Txn is a set of cell changes sent from a client to be atomically committed (see MsgOp_Commit).
+type Txn struct {
+	// The planet to commit to (or 0 to denote the logged in user's home planet)
+	PlanetID uint64 `protobuf:"varint,1,opt,name=PlanetID,proto3" json:"PlanetID,omitempty"`
+	// MsgOp_InsertCell and MsgOp_PushAttr msgs, where each InsertCell specifies the CellID and SchemaID of the PushAttr msgs that follow.
+	Msgs []*Msg `protobuf:"bytes,2,rep,name=Msgs,proto3" json:"Msgs,omitempty"`
+}
+
+func (m *Txn) Reset()      { *m = Txn{} }
+func (*Txn) ProtoMessage() {}
+func (*Txn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_655fece6a71483b6, []int{5}
+}
+func (m *Txn) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Txn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Txn.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Txn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Txn.Merge(m, src)
+}
+func (m *Txn) XXX_Size() int {
+	return m.Size()
+}
+func (m *Txn) XXX_DiscardUnknown() {
+	xxx_messageInfo_Txn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Txn proto.InternalMessageInfo
+
+func (m *Txn) GetPlanetID() uint64 {
+	if m != nil {
+		return m.PlanetID
+	}
+	return 0
+}
+
+func (m *Txn) GetMsgs() []*Msg {
+	if m != nil {
+		return m.Msgs
+	}
+	return nil
+}
+
 type Symbol struct {
 	ID    uint64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	Value []byte `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
@@ -805,7 +1079,7 @@ type Symbol struct {
 func (m *Symbol) Reset()      { *m = Symbol{} }
 func (*Symbol) ProtoMessage() {}
 func (*Symbol) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{4}
+	return fileDescriptor_655fece6a71483b6, []int{6}
 }
 func (m *Symbol) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -856,7 +1130,7 @@ type Defs struct {
 func (m *Defs) Reset()      { *m = Defs{} }
 func (*Defs) ProtoMessage() {}
 func (*Defs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{5}
+	return fileDescriptor_655fece6a71483b6, []int{7}
 }
 func (m *Defs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -908,10 +1182,13 @@ func (m *Defs) GetSchemas() []*AttrSchema {
 type AttrSchema struct {
 	// AppURI specifies which specific app and optional version should handle requests with this schema.
 	// The reserved value "." tells the host to choose the default app registered to handle the below AttrModelURI (common).
+	// The domain and any trailing version components can be omitted, selecting the newest registered version that matches.
 	// e.g.
 	//      "."
 	//      "planet.tools/vibe.app"
+	//      "planet.tools/vibe.app/v1"
 	//      "planet.tools/vibe.app/v1.2022.1"
+	//      "vibe.app/v1.2022"
 	//      "planet.tools/hfs.app/v1.2.3"
 	AppURI string `protobuf:"bytes,1,opt,name=AppURI,proto3" json:"AppURI,omitempty"`
 	// AttrModelURI names a particular data model used by this schema, in effect specifying a namespace / scope for the attached Attrs.
@@ -938,7 +1215,7 @@ type AttrSchema struct {
 func (m *AttrSchema) Reset()      { *m = AttrSchema{} }
 func (*AttrSchema) ProtoMessage() {}
 func (*AttrSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{6}
+	return fileDescriptor_655fece6a71483b6, []int{8}
 }
 func (m *AttrSchema) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1033,7 +1310,7 @@ type AttrSpec struct {
 func (m *AttrSpec) Reset()      { *m = AttrSpec{} }
 func (*AttrSpec) ProtoMessage() {}
 func (*AttrSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{7}
+	return fileDescriptor_655fece6a71483b6, []int{9}
 }
 func (m *AttrSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1111,12 +1388,15 @@ type PinReq struct {
 	// Specifies which child cell types should be pushed (and which attr schema they should be pushed with).
 	// If empty, no child cells are pushed.
 	ChildSchemas []int32 `protobuf:"varint,9,rep,packed,name=ChildSchemas,proto3" json:"ChildSchemas,omitempty"`
+	// The planet to pin from (or 0 to denote the logged in user's home planet).
+	// The logged in user must have at least PlanetRole_ReadOnly on this planet.
+	PlanetID uint64 `protobuf:"varint,11,opt,name=PlanetID,proto3" json:"PlanetID,omitempty"`
 }
 
 func (m *PinReq) Reset()      { *m = PinReq{} }
 func (*PinReq) ProtoMessage() {}
 func (*PinReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{8}
+	return fileDescriptor_655fece6a71483b6, []int{10}
 }
 func (m *PinReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1180,18 +1460,31 @@ func (m *PinReq) GetChildSchemas() []int32 {
 	return nil
 }
 
+func (m *PinReq) GetPlanetID() uint64 {
+	if m != nil {
+		return m.PlanetID
+	}
+	return 0
+}
+
 type AttrRange struct {
 	// Specifies what time series index to start and stop reading at (inclusive).
+	// SI values are int64 values cast to uint64, and if SI_SeekTo is 0, reading starts at the newest item.
 	SI_SeekTo uint64 `protobuf:"varint,24,opt,name=SI_SeekTo,json=SISeekTo,proto3" json:"SI_SeekTo,omitempty"`
 	SI_StopAt uint64 `protobuf:"varint,25,opt,name=SI_StopAt,json=SIStopAt,proto3" json:"SI_StopAt,omitempty"`
+	// If set, SI_StopAt is the lower bound (even if 0), otherwise reading continues through the oldest item (including negative SIs).
+	SI_StopAtSet bool `protobuf:"varint,26,opt,name=SI_StopAtSet,json=SIStopAtSet,proto3" json:"SI_StopAtSet,omitempty"`
 	// If set, this limits the number of entries returned for each unique from.cell.attr.  (0 denotes unlimited)
 	SI_BatchLimit uint64 `protobuf:"varint,27,opt,name=SI_BatchLimit,json=SIBatchLimit,proto3" json:"SI_BatchLimit,omitempty"`
+	// Paging cursor: if set, reading resumes after the item at SI_SeekTo (even if 0) having this FromID.
+	// So to read the batch following a limited read, pass the SI and FromID of the last item pushed.
+	FromID_SeekAfter uint64 `protobuf:"varint,28,opt,name=FromID_SeekAfter,json=FromIDSeekAfter,proto3" json:"FromID_SeekAfter,omitempty"`
 }
 
 func (m *AttrRange) Reset()      { *m = AttrRange{} }
 func (*AttrRange) ProtoMessage() {}
 func (*AttrRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{9}
+	return fileDescriptor_655fece6a71483b6, []int{11}
 }
 func (m *AttrRange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1234,6 +1527,13 @@ func (m *AttrRange) GetSI_StopAt() uint64 {
 	return 0
 }
 
+func (m *AttrRange) GetSI_StopAtSet() bool {
+	if m != nil {
+		return m.SI_StopAtSet
+	}
+	return false
+}
+
 func (m *AttrRange) GetSI_BatchLimit() uint64 {
 	if m != nil {
 		return m.SI_BatchLimit
@@ -1241,6 +1541,13 @@ func (m *AttrRange) GetSI_BatchLimit() uint64 {
 	return 0
 }
 
+func (m *AttrRange) GetFromID_SeekAfter() uint64 {
+	if m != nil {
+		return m.FromID_SeekAfter
+	}
+	return 0
+}
+
 type GeoFix struct {
 	Model  GeoModel `protobuf:"varint,1,opt,name=Model,proto3,enum=arc.GeoModel" json:"Model,omitempty"`
 	Lat    float64  `protobuf:"fixed64,4,opt,name=Lat,proto3" json:"Lat,omitempty"`
@@ -1253,7 +1560,7 @@ type GeoFix struct {
 func (m *GeoFix) Reset()      { *m = GeoFix{} }
 func (*GeoFix) ProtoMessage() {}
 func (*GeoFix) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{10}
+	return fileDescriptor_655fece6a71483b6, []int{12}
 }
 func (m *GeoFix) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1336,7 +1643,7 @@ type Content struct {
 func (m *Content) Reset()      { *m = Content{} }
 func (*Content) ProtoMessage() {}
 func (*Content) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{11}
+	return fileDescriptor_655fece6a71483b6, []int{13}
 }
 func (m *Content) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1408,7 +1715,7 @@ type CryptoKey struct {
 func (m *CryptoKey) Reset()      { *m = CryptoKey{} }
 func (*CryptoKey) ProtoMessage() {}
 func (*CryptoKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{12}
+	return fileDescriptor_655fece6a71483b6, []int{14}
 }
 func (m *CryptoKey) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1463,7 +1770,7 @@ type Link struct {
 func (m *Link) Reset()      { *m = Link{} }
 func (*Link) ProtoMessage() {}
 func (*Link) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{13}
+	return fileDescriptor_655fece6a71483b6, []int{15}
 }
 func (m *Link) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1559,7 +1866,7 @@ type TRS struct {
 func (m *TRS) Reset()      { *m = TRS{} }
 func (*TRS) ProtoMessage() {}
 func (*TRS) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{14}
+	return fileDescriptor_655fece6a71483b6, []int{16}
 }
 func (m *TRS) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1666,7 +1973,7 @@ type FeedParams struct {
 func (m *FeedParams) Reset()      { *m = FeedParams{} }
 func (*FeedParams) ProtoMessage() {}
 func (*FeedParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{15}
+	return fileDescriptor_655fece6a71483b6, []int{17}
 }
 func (m *FeedParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1720,7 +2027,7 @@ type DataSegment struct {
 func (m *DataSegment) Reset()      { *m = DataSegment{} }
 func (*DataSegment) ProtoMessage() {}
 func (*DataSegment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{16}
+	return fileDescriptor_655fece6a71483b6, []int{18}
 }
 func (m *DataSegment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1795,7 +2102,7 @@ type Err struct {
 func (m *Err) Reset()      { *m = Err{} }
 func (*Err) ProtoMessage() {}
 func (*Err) Descriptor() ([]byte, []int) {
-	return fileDescriptor_655fece6a71483b6, []int{17}
+	return fileDescriptor_655fece6a71483b6, []int{19}
 }
 func (m *Err) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1838,6 +2145,133 @@ func (m *Err) GetMsg() string {
 	return ""
 }
 
+// MsgRecord is a Msg sent or received over a session's stream, as captured by a recorder.
+// A recording is a sequence of MsgRecords, each preceded by its byte length as a uvarint.
+type MsgRecord struct {
+	// TimeFS is when the Msg was sent or received (see ConvertToTimeFS).
+	TimeFS int64 `protobuf:"varint,1,opt,name=TimeFS,proto3" json:"TimeFS,omitempty"`
+	// Dir is the direction the Msg traveled.
+	Dir MsgDir `protobuf:"varint,2,opt,name=Dir,proto3,enum=arc.MsgDir" json:"Dir,omitempty"`
+	// Msg is the recorded Msg.
+	Msg *Msg `protobuf:"bytes,3,opt,name=Msg,proto3" json:"Msg,omitempty"`
+}
+
+func (m *MsgRecord) Reset()      { *m = MsgRecord{} }
+func (*MsgRecord) ProtoMessage() {}
+func (*MsgRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_655fece6a71483b6, []int{20}
+}
+func (m *MsgRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgRecord.Merge(m, src)
+}
+func (m *MsgRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgRecord proto.InternalMessageInfo
+
+func (m *MsgRecord) GetTimeFS() int64 {
+	if m != nil {
+		return m.TimeFS
+	}
+	return 0
+}
+
+func (m *MsgRecord) GetDir() MsgDir {
+	if m != nil {
+		return m.Dir
+	}
+	return MsgDir_ToHost
+}
+
+func (m *MsgRecord) GetMsg() *Msg {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+// PlanetGrant sets a user's role on a planet (see MsgOp_GrantPlanetRole).
+type PlanetGrant struct {
+	// The planet to grant access to (or 0 to denote the logged in user's home planet).
+	PlanetID uint64 `protobuf:"varint,1,opt,name=PlanetID,proto3" json:"PlanetID,omitempty"`
+	// The user being granted access (see LoginReq.UserUID).
+	UserUID []byte `protobuf:"bytes,2,opt,name=UserUID,proto3" json:"UserUID,omitempty"`
+	// The role granted (PlanetRole_None revokes the user's role).
+	Role PlanetRole `protobuf:"varint,3,opt,name=Role,proto3,enum=arc.PlanetRole" json:"Role,omitempty"`
+}
+
+func (m *PlanetGrant) Reset()      { *m = PlanetGrant{} }
+func (*PlanetGrant) ProtoMessage() {}
+func (*PlanetGrant) Descriptor() ([]byte, []int) {
+	return fileDescriptor_655fece6a71483b6, []int{21}
+}
+func (m *PlanetGrant) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PlanetGrant) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PlanetGrant.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PlanetGrant) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanetGrant.Merge(m, src)
+}
+func (m *PlanetGrant) XXX_Size() int {
+	return m.Size()
+}
+func (m *PlanetGrant) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanetGrant.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanetGrant proto.InternalMessageInfo
+
+func (m *PlanetGrant) GetPlanetID() uint64 {
+	if m != nil {
+		return m.PlanetID
+	}
+	return 0
+}
+
+func (m *PlanetGrant) GetUserUID() []byte {
+	if m != nil {
+		return m.UserUID
+	}
+	return nil
+}
+
+func (m *PlanetGrant) GetRole() PlanetRole {
+	if m != nil {
+		return m.Role
+	}
+	return PlanetRole_None
+}
+
 func init() {
 	proto.RegisterEnum("arc.Const", Const_name, Const_value)
 	proto.RegisterEnum("arc.ValType", ValType_name, ValType_value)
@@ -1847,11 +2281,15 @@ func init() {
 	proto.RegisterEnum("arc.GeoModel", GeoModel_name, GeoModel_value)
 	proto.RegisterEnum("arc.CryptoKitID", CryptoKitID_name, CryptoKitID_value)
 	proto.RegisterEnum("arc.ErrCode", ErrCode_name, ErrCode_value)
+	proto.RegisterEnum("arc.MsgDir", MsgDir_name, MsgDir_value)
+	proto.RegisterEnum("arc.PlanetRole", PlanetRole_name, PlanetRole_value)
 	proto.RegisterEnum("arc.TRS_VisualScaleMode", TRS_VisualScaleMode_name, TRS_VisualScaleMode_value)
 	proto.RegisterType((*Msg)(nil), "arc.Msg")
 	proto.RegisterType((*PlanetEpoch)(nil), "arc.PlanetEpoch")
 	proto.RegisterType((*UserSeat)(nil), "arc.UserSeat")
 	proto.RegisterType((*LoginReq)(nil), "arc.LoginReq")
+	proto.RegisterType((*LoginChallenge)(nil), "arc.LoginChallenge")
+	proto.RegisterType((*Txn)(nil), "arc.Txn")
 	proto.RegisterType((*Symbol)(nil), "arc.Symbol")
 	proto.RegisterType((*Defs)(nil), "arc.Defs")
 	proto.RegisterType((*AttrSchema)(nil), "arc.AttrSchema")
@@ -1866,152 +2304,178 @@ func init() {
 	proto.RegisterType((*FeedParams)(nil), "arc.FeedParams")
 	proto.RegisterType((*DataSegment)(nil), "arc.DataSegment")
 	proto.RegisterType((*Err)(nil), "arc.Err")
+	proto.RegisterType((*MsgRecord)(nil), "arc.MsgRecord")
+	proto.RegisterType((*PlanetGrant)(nil), "arc.PlanetGrant")
 }
 
 func init() { proto.RegisterFile("arc/arc.proto", fileDescriptor_655fece6a71483b6) }
 
 var fileDescriptor_655fece6a71483b6 = []byte{
-	// 2240 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x58, 0xcd, 0x6f, 0x1b, 0xc7,
-	0xf9, 0xe6, 0x52, 0xa2, 0x24, 0x8e, 0x3e, 0x3c, 0x1e, 0xdb, 0xf2, 0x5a, 0x16, 0x68, 0xfe, 0x18,
-	0x07, 0x54, 0xf4, 0x0b, 0x1c, 0x8b, 0xb2, 0x8d, 0xa6, 0x68, 0xd3, 0x48, 0xa4, 0xe4, 0x2c, 0xa2,
-	0x2f, 0xcc, 0x52, 0x82, 0x81, 0x1e, 0x84, 0x11, 0x39, 0x22, 0xb7, 0x5e, 0xce, 0xac, 0x77, 0x87,
-	0x8e, 0xe4, 0x53, 0x8e, 0xfd, 0x48, 0x3f, 0xd0, 0xa2, 0x3d, 0xa5, 0x3d, 0xb5, 0x69, 0x62, 0xf4,
-	0xd0, 0x4b, 0x51, 0xa0, 0xdf, 0xc7, 0xa0, 0x27, 0xf7, 0x96, 0x63, 0x2d, 0x1f, 0x9a, 0x43, 0x0b,
-	0xe4, 0x3f, 0x68, 0xf1, 0xce, 0xec, 0x2e, 0x77, 0xe5, 0xdc, 0xe6, 0x79, 0x9e, 0xf9, 0x7c, 0xe7,
-	0x79, 0xdf, 0x59, 0x12, 0xcd, 0xb2, 0xb0, 0xf3, 0x06, 0x0b, 0x3b, 0xb7, 0x82, 0x50, 0x2a, 0x49,
-	0xc6, 0x58, 0xd8, 0xa9, 0x7d, 0x58, 0x44, 0x63, 0xdb, 0x51, 0x8f, 0x2c, 0xa0, 0xe2, 0x6e, 0x60,
-	0x5b, 0x55, 0x6b, 0x69, 0xae, 0x81, 0x6e, 0x41, 0xa7, 0xed, 0xa8, 0xb7, 0x1b, 0xd0, 0xe2, 0x6e,
-	0x40, 0x2e, 0xa3, 0x12, 0xe5, 0x8f, 0x9c, 0x96, 0x3d, 0x56, 0xb5, 0x96, 0xc6, 0xa9, 0x01, 0x64,
-	0x1e, 0x4d, 0x34, 0xb9, 0xef, 0x3b, 0x2d, 0x7b, 0x42, 0xd3, 0x31, 0x02, 0x7e, 0x33, 0x94, 0x03,
-	0xa7, 0x65, 0x4f, 0x1b, 0xde, 0x20, 0xe0, 0xd7, 0x94, 0x0a, 0x9d, 0x96, 0x7d, 0xa1, 0x6a, 0x2d,
-	0x95, 0x68, 0x8c, 0xc8, 0x1c, 0x2a, 0xba, 0x8e, 0x8d, 0xab, 0xd6, 0xd2, 0x18, 0x2d, 0xba, 0x0e,
-	0xb1, 0xd1, 0xe4, 0x01, 0xf3, 0xdb, 0xa7, 0x01, 0xb7, 0x2f, 0xeb, 0x8e, 0x09, 0x84, 0x19, 0x0e,
-	0x98, 0xbf, 0x3e, 0x3c, 0xb6, 0xaf, 0x54, 0xad, 0xa5, 0x19, 0x1a, 0xa3, 0x98, 0x77, 0x84, 0xb2,
-	0xe7, 0xf5, 0x2c, 0x31, 0x22, 0xaf, 0xa0, 0xd2, 0xa6, 0xcf, 0x7a, 0x91, 0x6d, 0xeb, 0x63, 0xcd,
-	0x26, 0xc7, 0xd2, 0x24, 0x35, 0x1a, 0x59, 0x44, 0xe3, 0x3b, 0xfc, 0x44, 0xd9, 0xd5, 0xaa, 0xb5,
-	0x34, 0xdd, 0x98, 0x4a, 0xfa, 0x50, 0xcd, 0xd6, 0xde, 0x43, 0xd3, 0x7b, 0x3e, 0x13, 0x5c, 0x6d,
-	0x04, 0xb2, 0xd3, 0x27, 0x0b, 0x68, 0x4a, 0x37, 0xda, 0x4e, 0x4b, 0xc7, 0x6a, 0x86, 0xa6, 0x98,
-	0xbc, 0x8e, 0x66, 0x74, 0x7b, 0x43, 0xa8, 0xd0, 0xe3, 0x91, 0x5d, 0xac, 0x8e, 0xe5, 0x26, 0xcc,
-	0xa9, 0xa4, 0x82, 0x50, 0x53, 0x0e, 0x06, 0x52, 0xec, 0xb0, 0x01, 0xd7, 0x81, 0x2d, 0xd3, 0x0c,
-	0x53, 0xdb, 0x44, 0x53, 0xfb, 0x11, 0x0f, 0x5d, 0xce, 0x14, 0x9c, 0x0f, 0xda, 0x4e, 0xcb, 0x2e,
-	0x9a, 0x88, 0x1a, 0x44, 0x6a, 0x68, 0xe6, 0x1d, 0x39, 0xe0, 0x66, 0x83, 0x4e, 0xcb, 0x1e, 0xd7,
-	0x6a, 0x8e, 0xab, 0xdd, 0x44, 0x53, 0x5b, 0xb2, 0xe7, 0x09, 0xca, 0x1f, 0x41, 0x64, 0x61, 0xe4,
-	0x7e, 0xba, 0xf9, 0x04, 0xd6, 0x6e, 0xa1, 0x09, 0xf7, 0x74, 0x70, 0x24, 0x7d, 0xb8, 0x8d, 0x58,
-	0x1e, 0xa7, 0x45, 0xa7, 0x05, 0x77, 0x7f, 0xc0, 0xfc, 0x21, 0xd7, 0x4b, 0xcf, 0x50, 0x03, 0x6a,
-	0x0f, 0xd0, 0x78, 0x8b, 0x1f, 0x47, 0xe4, 0x55, 0x34, 0x69, 0xc6, 0x45, 0xb6, 0xa5, 0x8f, 0x3b,
-	0xad, 0x8f, 0x6b, 0x38, 0x9a, 0x68, 0xe4, 0x35, 0x34, 0xe9, 0x76, 0xfa, 0x7c, 0xc0, 0x92, 0xa8,
-	0x5c, 0xd0, 0xdd, 0xc0, 0x00, 0x86, 0xa7, 0x89, 0x5e, 0xfb, 0xc4, 0x42, 0x68, 0xc4, 0x6b, 0xd3,
-	0x04, 0xc1, 0x3e, 0x75, 0xf4, 0x96, 0xca, 0x34, 0x46, 0x70, 0x74, 0xe8, 0xb5, 0x2d, 0xbb, 0xdc,
-	0x07, 0xd5, 0x04, 0x30, 0xc7, 0x41, 0x88, 0xcd, 0x2c, 0x3a, 0xc4, 0xe3, 0x26, 0xc4, 0x23, 0x06,
-	0x2e, 0xd3, 0xa0, 0xd8, 0xc2, 0x25, 0x9a, 0x62, 0xb0, 0x0e, 0xcc, 0x15, 0xd9, 0x53, 0x7a, 0xbf,
-	0xb3, 0xa3, 0xfd, 0x06, 0xbc, 0x43, 0x8d, 0x56, 0xfb, 0x95, 0x85, 0xa6, 0x12, 0x0e, 0x82, 0x0b,
-	0x6d, 0xd8, 0x4c, 0x51, 0x2f, 0x95, 0xc0, 0x8c, 0xf1, 0xc7, 0x73, 0xc6, 0x7f, 0x03, 0x21, 0x97,
-	0x83, 0x19, 0xb4, 0xd7, 0x27, 0xb4, 0x47, 0x4d, 0x60, 0x46, 0x34, 0xcd, 0x74, 0x81, 0x25, 0xd6,
-	0xe5, 0x50, 0x74, 0x5d, 0xc7, 0x9e, 0xd4, 0x46, 0x4f, 0x20, 0x59, 0x44, 0xe5, 0x38, 0x49, 0x9c,
-	0x96, 0x3d, 0xab, 0x57, 0x19, 0x11, 0xb5, 0x8f, 0x2c, 0x34, 0xb1, 0x67, 0x2c, 0x50, 0x45, 0xd3,
-	0x7b, 0x2c, 0xe4, 0x42, 0x99, 0x84, 0x36, 0xf7, 0x9c, 0xa5, 0x60, 0xb7, 0x7b, 0x9e, 0x18, 0xc5,
-	0x34, 0x46, 0xb0, 0xf8, 0x9e, 0x27, 0x20, 0xc7, 0xed, 0x92, 0x1e, 0x95, 0x40, 0x72, 0x13, 0xcd,
-	0x36, 0xa5, 0x50, 0x5c, 0x28, 0x13, 0x3e, 0xbd, 0xb9, 0x12, 0xcd, 0x93, 0x70, 0x63, 0xcd, 0xbe,
-	0xe7, 0x77, 0x13, 0x23, 0x94, 0xab, 0x63, 0x4b, 0x25, 0x9a, 0xe3, 0x6a, 0xdf, 0x42, 0x65, 0x88,
-	0x0d, 0x65, 0xa2, 0xc7, 0xc9, 0x75, 0x54, 0x76, 0x9d, 0x43, 0x97, 0xf3, 0x87, 0x6d, 0xa9, 0x33,
-	0x78, 0x9c, 0x4e, 0xb9, 0x8e, 0xc1, 0x89, 0xa8, 0x64, 0xb0, 0xa6, 0xec, 0x6b, 0xa9, 0xa8, 0x31,
-	0x79, 0x05, 0xcd, 0xba, 0xce, 0xe1, 0x3a, 0x53, 0x9d, 0xfe, 0x96, 0x37, 0xf0, 0x94, 0x7d, 0xdd,
-	0x24, 0x86, 0xeb, 0x8c, 0xb8, 0xda, 0x4f, 0x2c, 0x34, 0x71, 0x9f, 0xcb, 0x4d, 0xef, 0x04, 0x2e,
-	0x5b, 0x9b, 0x26, 0x2e, 0x7f, 0xe6, 0xb2, 0xef, 0x73, 0xa9, 0x49, 0x6a, 0x34, 0x82, 0xd1, 0xd8,
-	0x16, 0x53, 0xfa, 0x0a, 0x2d, 0x0a, 0x4d, 0xcd, 0x88, 0x9e, 0x8e, 0x06, 0x30, 0xa2, 0x07, 0xcc,
-	0x9a, 0xaf, 0xf4, 0x55, 0x5a, 0x14, 0x9a, 0xfa, 0xee, 0x7d, 0x45, 0x77, 0xf7, 0x6d, 0x54, 0xb5,
-	0x96, 0x8a, 0x34, 0x46, 0x3a, 0xca, 0x32, 0x02, 0x7e, 0xda, 0xf0, 0x06, 0xd5, 0xfe, 0x62, 0xa1,
-	0xc9, 0x38, 0x6e, 0x70, 0x57, 0x71, 0xb3, 0xc5, 0x14, 0xd3, 0xd7, 0x31, 0x43, 0xb3, 0x54, 0xa6,
-	0x87, 0xb6, 0x90, 0xb1, 0x78, 0x96, 0xca, 0xdc, 0x4d, 0x6c, 0x8e, 0x92, 0x36, 0x4e, 0x9e, 0x84,
-	0x79, 0xb6, 0x3c, 0xf1, 0x30, 0x8a, 0xeb, 0x39, 0xd2, 0x7d, 0xb2, 0x14, 0xa9, 0x43, 0x19, 0xe9,
-	0x30, 0xe5, 0x49, 0xa1, 0x77, 0x9c, 0x64, 0xba, 0x89, 0x20, 0x4d, 0xc5, 0xda, 0x37, 0x51, 0xb9,
-	0x19, 0x9e, 0x06, 0x4a, 0xbe, 0xcb, 0x4f, 0x49, 0x03, 0x4d, 0xc7, 0xc0, 0x53, 0xb1, 0xdb, 0xe6,
-	0x1a, 0x58, 0x0f, 0xcc, 0xf0, 0x34, 0xdb, 0x09, 0xb2, 0xf2, 0x5d, 0x7e, 0xba, 0x7e, 0xaa, 0x78,
-	0xa4, 0x0f, 0x34, 0x43, 0x53, 0x5c, 0xfb, 0xc0, 0x42, 0xe3, 0xb0, 0x2b, 0x9d, 0xba, 0x7d, 0x16,
-	0xf0, 0x51, 0x61, 0x48, 0x31, 0x18, 0xd5, 0x7d, 0x68, 0x1c, 0x1c, 0x27, 0x62, 0x0c, 0xe1, 0x7a,
-	0xf6, 0xe9, 0x96, 0x0e, 0x41, 0x99, 0x42, 0x13, 0xaa, 0xdb, 0x16, 0x3b, 0xe2, 0xbe, 0xb6, 0x6c,
-	0x99, 0x1a, 0x40, 0x08, 0x54, 0xb7, 0xa8, 0xa3, 0xe3, 0x50, 0xa6, 0xba, 0x0d, 0x5c, 0x1b, 0x9e,
-	0x92, 0x19, 0xc3, 0x41, 0xbb, 0xf6, 0xbb, 0x22, 0x1a, 0x6b, 0x53, 0x17, 0x6a, 0xe6, 0x83, 0x15,
-	0xfb, 0x35, 0x7d, 0xeb, 0xc5, 0x07, 0x2b, 0x1a, 0x37, 0xec, 0xe5, 0x18, 0x37, 0x34, 0x5e, 0xb5,
-	0xff, 0x3f, 0xc6, 0xab, 0xe4, 0x1e, 0x2a, 0xbb, 0x1d, 0xe6, 0x73, 0x30, 0x96, 0xdd, 0xd0, 0x41,
-	0xb1, 0x75, 0x50, 0xda, 0xd4, 0xbd, 0x75, 0xe0, 0x45, 0x43, 0xe6, 0xa7, 0x3a, 0x1d, 0x75, 0x05,
-	0xd3, 0x68, 0xb0, 0x62, 0xaf, 0x1a, 0xd3, 0x18, 0x94, 0xf2, 0x0d, 0xfb, 0x4e, 0x86, 0x6f, 0xa4,
-	0xfc, 0xaa, 0x7d, 0x37, 0xc3, 0xaf, 0x42, 0x84, 0xa8, 0x54, 0x4c, 0xf1, 0x15, 0xfb, 0xeb, 0x5a,
-	0x48, 0xe0, 0x48, 0x69, 0xd8, 0x6f, 0x65, 0x95, 0xc6, 0x48, 0x59, 0xb5, 0xbf, 0x91, 0x55, 0x56,
-	0x6b, 0xb7, 0xd1, 0x85, 0x73, 0x7b, 0x26, 0xb3, 0xa8, 0xbc, 0x36, 0x54, 0x52, 0x13, 0xb8, 0x40,
-	0xe6, 0x10, 0xda, 0xf4, 0x4e, 0x78, 0xd7, 0x60, 0xab, 0xd6, 0x47, 0x68, 0x93, 0xf3, 0xee, 0x1e,
-	0x0b, 0xd9, 0x20, 0x22, 0xaf, 0xa3, 0x8b, 0xfb, 0x41, 0x97, 0x29, 0xee, 0x08, 0xc5, 0xc3, 0xc7,
-	0xcc, 0xdf, 0xf6, 0x84, 0xbe, 0xb9, 0x22, 0x7d, 0x59, 0xf8, 0x92, 0xde, 0xec, 0x44, 0xa7, 0xc6,
-	0xcb, 0xbd, 0xd9, 0x49, 0xed, 0xa7, 0x16, 0x9a, 0x86, 0x4c, 0x71, 0x79, 0x6f, 0x00, 0x29, 0x05,
-	0x15, 0xf4, 0x54, 0xf1, 0xdd, 0xe3, 0x28, 0x29, 0x62, 0x31, 0x84, 0x58, 0x41, 0xd3, 0x7d, 0x92,
-	0x7c, 0xcd, 0x18, 0x04, 0x8f, 0x88, 0x23, 0x7c, 0x4f, 0x70, 0x9d, 0x83, 0x93, 0xda, 0x90, 0x19,
-	0x06, 0x2a, 0xaf, 0xab, 0x42, 0xce, 0x06, 0xe0, 0xb7, 0xb2, 0x36, 0xc7, 0x88, 0xd0, 0xb3, 0xfa,
-	0xf2, 0x28, 0xcd, 0xa9, 0x18, 0xd5, 0xde, 0x44, 0x63, 0x1b, 0x61, 0x48, 0xaa, 0x68, 0xbc, 0x09,
-	0x1e, 0x30, 0x89, 0x31, 0xa3, 0x3d, 0xb0, 0x11, 0x86, 0xc0, 0x51, 0xad, 0x80, 0x65, 0xb7, 0xa3,
-	0x5e, 0x6c, 0x64, 0x68, 0x2e, 0xff, 0xde, 0x42, 0xa5, 0xa6, 0x14, 0x91, 0x82, 0xb0, 0xea, 0xc6,
-	0x21, 0x3c, 0xc5, 0xb8, 0x40, 0xae, 0xa3, 0xab, 0x06, 0xbf, 0x23, 0x23, 0xe5, 0xf2, 0x28, 0xf2,
-	0xa4, 0x30, 0xe9, 0x8b, 0xc7, 0xc8, 0x65, 0x84, 0x8d, 0x48, 0xa5, 0x54, 0x31, 0x3b, 0x41, 0xe6,
-	0x11, 0x31, 0x6c, 0xdb, 0x69, 0xad, 0x7b, 0x82, 0x85, 0xa7, 0x5b, 0x5c, 0xe0, 0x4a, 0x8e, 0x77,
-	0x55, 0xe8, 0x89, 0x1e, 0xf0, 0xb7, 0x89, 0x8d, 0x2e, 0xa7, 0x7c, 0xdb, 0x1b, 0xf0, 0x48, 0xb1,
-	0x41, 0xe0, 0x3e, 0xc1, 0x53, 0xe4, 0xff, 0xd0, 0x62, 0xba, 0x19, 0x36, 0xf4, 0xd5, 0xfd, 0x30,
-	0xe8, 0xb8, 0x3c, 0x7c, 0xec, 0x75, 0xf8, 0x9e, 0x0c, 0x15, 0xfe, 0x74, 0x69, 0xf9, 0xf3, 0xb1,
-	0xf4, 0xcb, 0x8e, 0x5c, 0x40, 0xd3, 0x71, 0xf3, 0x50, 0x78, 0x3e, 0x2e, 0x64, 0x09, 0x4f, 0x28,
-	0x3c, 0x4e, 0x2e, 0xa2, 0xd9, 0x84, 0x38, 0x82, 0xe4, 0xc7, 0x13, 0x84, 0xa0, 0xb9, 0x84, 0x8a,
-	0xf4, 0xa6, 0xf0, 0x64, 0x76, 0x5c, 0xdb, 0x69, 0x61, 0x0c, 0x07, 0x4d, 0x88, 0xe4, 0x35, 0xc7,
-	0x84, 0x60, 0x34, 0x93, 0xb0, 0x70, 0x05, 0x78, 0x3e, 0xdb, 0xaf, 0xc5, 0x14, 0x87, 0xd3, 0xe0,
-	0xab, 0x39, 0x76, 0x18, 0xea, 0x92, 0x86, 0xed, 0x2c, 0xbb, 0x16, 0x45, 0x5c, 0xed, 0x53, 0x07,
-	0x5f, 0xcb, 0x2e, 0xbd, 0x4f, 0xb7, 0xf0, 0x42, 0x96, 0xd8, 0x08, 0x43, 0xdc, 0x20, 0x57, 0xd1,
-	0xa5, 0xcc, 0x1a, 0x89, 0x0b, 0xf1, 0x1d, 0x72, 0x09, 0x5d, 0x48, 0x84, 0xb8, 0x12, 0xe3, 0x7b,
-	0xe4, 0x0a, 0xba, 0x98, 0x92, 0x49, 0x09, 0xc5, 0x5f, 0xc9, 0x9d, 0xf0, 0x44, 0xe0, 0xaf, 0x66,
-	0x77, 0x93, 0x7c, 0xda, 0xe1, 0xaf, 0x65, 0x4f, 0xa8, 0xfd, 0xf0, 0x56, 0x36, 0x5c, 0xe6, 0xf5,
-	0xc7, 0x6f, 0x67, 0xd7, 0x48, 0x5f, 0x5a, 0xbc, 0x9e, 0x1d, 0x0c, 0xf5, 0x15, 0xef, 0x65, 0x07,
-	0x9b, 0x1a, 0x8f, 0x69, 0x6e, 0x27, 0xd4, 0xc5, 0x6d, 0x72, 0x15, 0x91, 0x34, 0xaa, 0x43, 0xcf,
-	0x57, 0x9e, 0xd8, 0x66, 0x27, 0xf8, 0x5f, 0x93, 0xcb, 0x4f, 0x2d, 0x54, 0xd2, 0xbf, 0x1f, 0xc0,
-	0xa4, 0xba, 0x71, 0xb8, 0x23, 0x77, 0x03, 0x73, 0xcf, 0x06, 0xeb, 0xad, 0x63, 0x8b, 0x2c, 0x22,
-	0xdb, 0x10, 0x94, 0x47, 0xd2, 0x7f, 0xcc, 0xd7, 0x44, 0x97, 0xf2, 0x9e, 0x17, 0x29, 0x1e, 0xe2,
-	0x12, 0xb8, 0xc0, 0xa8, 0xf1, 0xc7, 0x86, 0x71, 0x41, 0x4c, 0x0d, 0xa3, 0x3e, 0x1c, 0x02, 0x23,
-	0x08, 0x89, 0xe1, 0x1c, 0x11, 0xf1, 0x50, 0xfb, 0x1b, 0xcf, 0xc1, 0xa9, 0x0c, 0x0b, 0xdf, 0xd5,
-	0x9e, 0xc2, 0x36, 0xb9, 0x94, 0x8c, 0x6d, 0xfa, 0x32, 0xe2, 0x10, 0x92, 0xff, 0x5a, 0xcb, 0x07,
-	0x68, 0x2a, 0xf9, 0x51, 0x10, 0xaf, 0xa7, 0xdb, 0x87, 0x3b, 0x52, 0x70, 0x93, 0x56, 0x29, 0x05,
-	0x13, 0x37, 0xfb, 0xbc, 0xf3, 0x30, 0x90, 0xe0, 0x52, 0x8b, 0x2c, 0xa0, 0x2b, 0xa9, 0x68, 0x7e,
-	0x8d, 0xb8, 0x7d, 0x16, 0xf2, 0x2e, 0x7e, 0xbf, 0xb8, 0xdc, 0xc9, 0x7e, 0xdf, 0xc1, 0x16, 0x47,
-	0xe8, 0x50, 0xd7, 0x43, 0x5c, 0x80, 0xc3, 0x64, 0x58, 0xe7, 0xde, 0x1d, 0x5c, 0x84, 0x3b, 0xca,
-	0x70, 0x60, 0xcc, 0x95, 0x7b, 0xb8, 0x74, 0x6e, 0x82, 0xfd, 0x76, 0x73, 0xe5, 0x1e, 0x9e, 0x58,
-	0xbe, 0x81, 0xa6, 0x92, 0x2f, 0x15, 0x70, 0x55, 0xd2, 0x3e, 0x74, 0x83, 0x3e, 0x0f, 0x39, 0x2e,
-	0x2c, 0xff, 0xcc, 0xca, 0x3d, 0xc2, 0x70, 0xc2, 0x14, 0x1e, 0xee, 0xe8, 0xdc, 0x5b, 0x44, 0xf6,
-	0x88, 0x72, 0x79, 0x27, 0xe4, 0x6a, 0x5d, 0x9e, 0x1c, 0xee, 0xb0, 0xa6, 0x8f, 0xbb, 0x64, 0x01,
-	0xcd, 0x8f, 0xd4, 0xb5, 0xe8, 0x74, 0xb0, 0x1d, 0xf5, 0x8c, 0xc6, 0xf3, 0x9a, 0xeb, 0xf5, 0x84,
-	0x27, 0x62, 0xed, 0x98, 0x54, 0xd0, 0xb5, 0x97, 0xb5, 0x8d, 0x56, 0xe3, 0xee, 0xdd, 0x95, 0x37,
-	0xf1, 0xdf, 0xad, 0xe5, 0x5f, 0x4e, 0xa0, 0xc9, 0xb8, 0xd8, 0xc1, 0xa6, 0xe2, 0xe6, 0xe1, 0x8e,
-	0x84, 0xdc, 0x29, 0x80, 0xb7, 0x12, 0x6a, 0x5f, 0x08, 0x36, 0xe0, 0x5d, 0xe0, 0xbf, 0x5d, 0x27,
-	0x36, 0xba, 0x94, 0x08, 0xba, 0xd4, 0x0b, 0xe6, 0x83, 0xf2, 0x9d, 0x3a, 0x5c, 0xc6, 0x68, 0x48,
-	0x34, 0x0c, 0x02, 0x19, 0x2a, 0xde, 0xdd, 0x0d, 0xf0, 0x77, 0xcf, 0x69, 0xde, 0x20, 0xf0, 0x39,
-	0xa4, 0x22, 0xef, 0xe2, 0xef, 0xe5, 0x66, 0xa4, 0xfc, 0x51, 0x93, 0x89, 0x0e, 0xf7, 0x79, 0x17,
-	0x7f, 0x50, 0x27, 0xd7, 0xd0, 0xe5, 0x44, 0x71, 0xfb, 0x43, 0xa5, 0x3c, 0xd1, 0x6b, 0xc9, 0xf7,
-	0x04, 0xfe, 0x7e, 0x4e, 0x6a, 0x79, 0x51, 0x47, 0x0a, 0xc1, 0x3b, 0x30, 0xdf, 0x0f, 0x72, 0x92,
-	0x23, 0x1e, 0x33, 0xdf, 0xeb, 0x1a, 0xb3, 0xff, 0xf0, 0xfc, 0x52, 0x3b, 0x52, 0x6d, 0xc2, 0x17,
-	0x3c, 0xfe, 0x71, 0x3d, 0x7b, 0xde, 0x78, 0x10, 0xd8, 0xf3, 0xc3, 0x2f, 0x13, 0xa0, 0xfc, 0xfc,
-	0xbc, 0x4e, 0xae, 0x20, 0x9c, 0x08, 0xeb, 0xac, 0xab, 0x7f, 0x98, 0xe1, 0x5f, 0xd4, 0xc9, 0x22,
-	0xba, 0x3a, 0x8a, 0xa5, 0xea, 0x7b, 0xa2, 0xd7, 0x96, 0x71, 0x02, 0x7c, 0x94, 0xdb, 0x9b, 0x21,
-	0x37, 0x99, 0x07, 0x87, 0xfd, 0x75, 0x9d, 0x5c, 0x47, 0xf3, 0x89, 0x64, 0x7e, 0x3e, 0xa6, 0xdb,
-	0xfb, 0x38, 0x17, 0x3f, 0x23, 0xc2, 0xb8, 0x61, 0xc8, 0xf1, 0x27, 0xb9, 0x43, 0xad, 0x05, 0x41,
-	0x3a, 0xea, 0x69, 0x6e, 0xb5, 0x1d, 0xa9, 0x7f, 0x34, 0x19, 0xe9, 0x37, 0xb9, 0x41, 0xdb, 0xcc,
-	0x3f, 0x96, 0xe1, 0x80, 0x77, 0xdb, 0x27, 0xf8, 0xb7, 0xb9, 0x41, 0x60, 0xf5, 0x74, 0xbe, 0x3f,
-	0xd4, 0xc1, 0x53, 0xe7, 0xa4, 0xa4, 0x56, 0xf0, 0x2e, 0xfe, 0x63, 0x9d, 0xcc, 0xa3, 0x8b, 0x99,
-	0x90, 0x98, 0xfa, 0x8f, 0xff, 0x94, 0x5b, 0x0c, 0x0a, 0x71, 0xb2, 0xf7, 0x3f, 0x9f, 0x73, 0x93,
-	0x8e, 0xae, 0x2e, 0x1e, 0x7f, 0xcd, 0x29, 0x3b, 0x52, 0xed, 0x79, 0x42, 0xb0, 0x23, 0x9f, 0xe3,
-	0xbf, 0xd5, 0xc9, 0x0d, 0xb4, 0x90, 0x28, 0x07, 0x9e, 0xf4, 0x99, 0xe2, 0xd1, 0x5a, 0x10, 0x70,
-	0xd1, 0xdd, 0x15, 0xfe, 0x29, 0xfe, 0x77, 0x9d, 0xdc, 0x44, 0x37, 0x46, 0x93, 0x46, 0xc3, 0xe3,
-	0x63, 0xaf, 0xe3, 0x71, 0xa1, 0xf6, 0x78, 0x38, 0xf0, 0xf4, 0xb3, 0x1c, 0xe1, 0xff, 0xe4, 0x7a,
-	0x35, 0xfb, 0x7b, 0xa1, 0x54, 0xb2, 0x23, 0x7d, 0x7d, 0xa4, 0x8e, 0xec, 0x09, 0xef, 0x09, 0xef,
-	0xe2, 0x7f, 0x2c, 0x35, 0x56, 0xd0, 0x14, 0xbc, 0xe7, 0xf0, 0x9e, 0x92, 0x57, 0xd1, 0x74, 0xe6,
-	0x6d, 0x27, 0xe9, 0x7f, 0x0b, 0x0b, 0x69, 0x6b, 0xc9, 0xba, 0x6d, 0xad, 0xbf, 0xfd, 0xec, 0x79,
-	0xa5, 0xf0, 0xd9, 0xf3, 0x4a, 0xe1, 0x8b, 0xe7, 0x15, 0xeb, 0xfd, 0xb3, 0x8a, 0xf5, 0xf1, 0x59,
-	0xc5, 0xfa, 0xf4, 0xac, 0x62, 0x3d, 0x3b, 0xab, 0x58, 0xff, 0x3c, 0xab, 0x58, 0x9f, 0x9f, 0x55,
-	0x0a, 0x5f, 0x9c, 0x55, 0xac, 0x1f, 0xbd, 0xa8, 0x14, 0x9e, 0xbd, 0xa8, 0x14, 0x3e, 0x7b, 0x51,
-	0x29, 0x3c, 0x2d, 0x96, 0xd7, 0xc2, 0xce, 0x03, 0x7a, 0x6b, 0x2d, 0xec, 0x1c, 0x4d, 0xe8, 0x7f,
-	0x88, 0x56, 0xff, 0x17, 0x00, 0x00, 0xff, 0xff, 0xf2, 0x9f, 0xb2, 0xb4, 0x32, 0x12, 0x00, 0x00,
+	// 2610 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x58, 0xcd, 0x6f, 0x1b, 0xc7,
+	0xf9, 0xd6, 0x92, 0xfa, 0xe2, 0x50, 0x92, 0xc7, 0x63, 0x5b, 0x5e, 0xcb, 0xfa, 0xd1, 0x0c, 0x93,
+	0xfc, 0xc4, 0xa8, 0x81, 0x63, 0x51, 0x89, 0xd1, 0x14, 0x6d, 0x12, 0x89, 0xb4, 0x9c, 0x45, 0xf4,
+	0x85, 0x59, 0xca, 0x35, 0x50, 0xa0, 0xc2, 0x68, 0x39, 0x22, 0x17, 0x5e, 0xce, 0x6c, 0x76, 0x87,
+	0x8e, 0x94, 0x53, 0x8e, 0xfd, 0x48, 0x3f, 0xd0, 0xa2, 0x3d, 0x25, 0xbd, 0x35, 0x4d, 0xd2, 0x1e,
+	0x7a, 0x29, 0x50, 0xf4, 0x23, 0x2d, 0x7a, 0x4a, 0x7b, 0x4a, 0x6f, 0x39, 0x36, 0xca, 0xa1, 0x3d,
+	0xb4, 0x40, 0xfe, 0x83, 0x16, 0xef, 0xcc, 0xee, 0x72, 0x57, 0x0e, 0x72, 0x9b, 0xf7, 0x79, 0xe6,
+	0xe3, 0x9d, 0x77, 0x9e, 0xf7, 0x9d, 0xd9, 0x45, 0xf3, 0x2c, 0xf2, 0x9e, 0x61, 0x91, 0x77, 0x33,
+	0x8c, 0xa4, 0x92, 0xa4, 0xcc, 0x22, 0xaf, 0xf1, 0x56, 0x09, 0x95, 0x77, 0xe2, 0x3e, 0x59, 0x42,
+	0xa5, 0xbd, 0xd0, 0xb6, 0xea, 0x56, 0x73, 0xa1, 0x85, 0x6e, 0x42, 0xa7, 0x9d, 0xb8, 0xbf, 0x17,
+	0xd2, 0xd2, 0x5e, 0x48, 0x2e, 0xa3, 0x29, 0xca, 0x5f, 0x75, 0x3a, 0x76, 0xb9, 0x6e, 0x35, 0x27,
+	0xa9, 0x31, 0xc8, 0x22, 0x9a, 0x6e, 0xf3, 0x20, 0x70, 0x3a, 0xf6, 0xb4, 0x86, 0x13, 0x0b, 0xf0,
+	0xad, 0x48, 0x0e, 0x9d, 0x8e, 0x5d, 0x35, 0xb8, 0xb1, 0x00, 0xdf, 0x50, 0x2a, 0x72, 0x3a, 0xf6,
+	0x85, 0xba, 0xd5, 0x9c, 0xa2, 0x89, 0x45, 0x16, 0x50, 0xc9, 0x75, 0x6c, 0x5c, 0xb7, 0x9a, 0x65,
+	0x5a, 0x72, 0x1d, 0x62, 0xa3, 0x99, 0x7b, 0x2c, 0xe8, 0x9e, 0x86, 0xdc, 0xbe, 0xac, 0x3b, 0xa6,
+	0x26, 0xcc, 0x70, 0x8f, 0x05, 0x9b, 0xa3, 0x63, 0xfb, 0x4a, 0xdd, 0x6a, 0xce, 0xd1, 0xc4, 0x4a,
+	0x70, 0x47, 0x28, 0x7b, 0x51, 0xcf, 0x92, 0x58, 0xe4, 0x71, 0x34, 0xb5, 0x15, 0xb0, 0x7e, 0x6c,
+	0xdb, 0x7a, 0x5b, 0xf3, 0xe9, 0xb6, 0x34, 0x48, 0x0d, 0x47, 0x96, 0xd1, 0xe4, 0x2e, 0x3f, 0x51,
+	0x76, 0xbd, 0x6e, 0x35, 0xab, 0xad, 0xd9, 0xb4, 0x0f, 0xd5, 0x68, 0xe3, 0x35, 0x54, 0xdd, 0x0f,
+	0x98, 0xe0, 0xea, 0x4e, 0x28, 0xbd, 0x01, 0x59, 0x42, 0xb3, 0xba, 0xd1, 0x75, 0x3a, 0x3a, 0x56,
+	0x73, 0x34, 0xb3, 0xc9, 0xd3, 0x68, 0x4e, 0xb7, 0xef, 0x08, 0x15, 0xf9, 0x3c, 0xb6, 0x4b, 0xf5,
+	0x72, 0x61, 0xc2, 0x02, 0x4b, 0x6a, 0x08, 0xb5, 0xe5, 0x70, 0x28, 0xc5, 0x2e, 0x1b, 0x72, 0x1d,
+	0xd8, 0x0a, 0xcd, 0x21, 0x0d, 0x81, 0x66, 0x0f, 0x62, 0x1e, 0xb9, 0x9c, 0x29, 0xd8, 0x1f, 0xb4,
+	0x9d, 0x8e, 0x5d, 0x32, 0x11, 0x35, 0x16, 0x69, 0xa0, 0xb9, 0x97, 0xe5, 0x90, 0x1b, 0x07, 0x9d,
+	0x8e, 0x3d, 0xa9, 0xd9, 0x02, 0x46, 0xfe, 0x1f, 0x4d, 0xef, 0x8f, 0x8e, 0x5e, 0xe1, 0xa7, 0xfa,
+	0x94, 0xaa, 0xad, 0x05, 0xed, 0x4f, 0x3b, 0x3a, 0x0d, 0x95, 0x7c, 0x85, 0x9f, 0xd2, 0x84, 0x85,
+	0xf5, 0xb6, 0x65, 0xdf, 0x17, 0x94, 0xbf, 0x0a, 0x27, 0x00, 0x2b, 0x1c, 0x64, 0x9b, 0x4c, 0xcd,
+	0xdc, 0x6c, 0xe5, 0x2f, 0x9a, 0x8d, 0xd4, 0x51, 0x95, 0xf2, 0x78, 0x34, 0xe4, 0x5d, 0xf9, 0x80,
+	0x0b, 0xed, 0xd8, 0x1c, 0xcd, 0x43, 0x8d, 0x63, 0xb4, 0xa0, 0xd7, 0x6b, 0x0f, 0x58, 0x10, 0x70,
+	0xd1, 0xe7, 0xa0, 0xb2, 0x5d, 0x29, 0x3c, 0x9e, 0xac, 0x69, 0x0c, 0xb2, 0x8c, 0x2a, 0xae, 0xdf,
+	0x17, 0x4c, 0x8d, 0x22, 0xae, 0xb7, 0x3f, 0x47, 0xc7, 0xc0, 0xf9, 0x75, 0xca, 0x8f, 0xae, 0xf3,
+	0x22, 0x2a, 0x77, 0x4f, 0x04, 0x1c, 0x5c, 0x16, 0x26, 0x4b, 0x87, 0x29, 0xb3, 0x41, 0x01, 0x3b,
+	0x71, 0xff, 0xd1, 0x03, 0xd3, 0x68, 0xe3, 0x26, 0x9a, 0x76, 0x4f, 0x87, 0x47, 0x32, 0x00, 0xa1,
+	0x66, 0xa3, 0x4b, 0x4e, 0x07, 0x1c, 0xbe, 0xc7, 0x82, 0x51, 0xea, 0x96, 0x31, 0x1a, 0xf7, 0xd1,
+	0x64, 0x87, 0x1f, 0xc7, 0xe4, 0x49, 0x34, 0x63, 0xc6, 0xc5, 0xb6, 0xa5, 0x27, 0xae, 0xea, 0x89,
+	0x0d, 0x46, 0x53, 0x8e, 0x3c, 0x85, 0x66, 0x5c, 0x6f, 0xc0, 0x87, 0x2c, 0x5d, 0xff, 0x82, 0xee,
+	0x06, 0xb9, 0x61, 0x70, 0x9a, 0xf2, 0x8d, 0xf7, 0x2c, 0x84, 0xc6, 0xb8, 0xce, 0xa7, 0x30, 0x3c,
+	0xa0, 0x8e, 0x76, 0xa9, 0x42, 0x13, 0x0b, 0x54, 0x01, 0xbd, 0x76, 0x64, 0x8f, 0x07, 0xc0, 0x1a,
+	0x6d, 0x15, 0x30, 0x50, 0x9f, 0x99, 0x45, 0xab, 0x6f, 0x52, 0xf7, 0xc8, 0x21, 0x10, 0x2e, 0x63,
+	0x25, 0xd9, 0x3d, 0x45, 0x33, 0x1b, 0xb2, 0x0a, 0xe6, 0x8a, 0xed, 0x59, 0xed, 0xef, 0xfc, 0xd8,
+	0xdf, 0x90, 0x7b, 0xd4, 0x70, 0x8d, 0x9f, 0x5b, 0x68, 0x36, 0xc5, 0x40, 0x4f, 0xd0, 0x06, 0x67,
+	0x4a, 0x7a, 0xa9, 0xd4, 0xcc, 0xd5, 0x84, 0xc9, 0x42, 0x4d, 0x78, 0x06, 0x21, 0x97, 0x43, 0x9e,
+	0xe8, 0x32, 0x30, 0xad, 0xd3, 0xd7, 0x04, 0x66, 0x0c, 0xd3, 0x5c, 0x17, 0x58, 0x62, 0x53, 0x8e,
+	0x44, 0xcf, 0x75, 0xec, 0x19, 0x5d, 0x03, 0x52, 0x13, 0x04, 0x94, 0xd4, 0x0f, 0xa7, 0x63, 0xcf,
+	0xeb, 0x55, 0xc6, 0x40, 0xe3, 0x2f, 0x16, 0x9a, 0xde, 0x37, 0xaa, 0xaf, 0xa3, 0xea, 0x3e, 0x8b,
+	0xb8, 0x50, 0xa6, 0xd6, 0x99, 0x73, 0xce, 0x43, 0xe0, 0xed, 0xbe, 0x2f, 0xc6, 0x31, 0x4d, 0x2c,
+	0x58, 0x7c, 0xdf, 0x17, 0x50, 0xfe, 0xec, 0x29, 0x3d, 0x2a, 0x35, 0xc9, 0x13, 0x68, 0xbe, 0x2d,
+	0x85, 0xe2, 0x42, 0x99, 0xf0, 0x69, 0xe7, 0xa6, 0x68, 0x11, 0x84, 0x13, 0x6b, 0x0f, 0xfc, 0xa0,
+	0x97, 0x0a, 0xa1, 0x52, 0x2f, 0x37, 0xa7, 0x68, 0x01, 0x2b, 0x08, 0xb8, 0x5a, 0x14, 0x70, 0xe3,
+	0xb7, 0x16, 0xaa, 0x40, 0xe0, 0x28, 0x83, 0x3c, 0xba, 0x8e, 0x2a, 0xae, 0x73, 0xe8, 0x72, 0xfe,
+	0xa0, 0x2b, 0x75, 0xe5, 0x9b, 0xa4, 0xb3, 0xae, 0x63, 0xec, 0x94, 0x54, 0x32, 0xdc, 0x50, 0xf6,
+	0xb5, 0x8c, 0xd4, 0x36, 0x79, 0x0c, 0xcd, 0x65, 0xa4, 0xcb, 0x95, 0xbd, 0x54, 0xb7, 0x9a, 0xb3,
+	0xb4, 0x9a, 0xf2, 0x2e, 0x87, 0x92, 0x3a, 0xef, 0x3a, 0x87, 0x9b, 0x4c, 0x79, 0x83, 0x6d, 0x7f,
+	0xe8, 0x2b, 0xfb, 0xba, 0xa9, 0x39, 0xae, 0x33, 0xc6, 0xc8, 0x53, 0x08, 0x9b, 0x9a, 0xaf, 0xbd,
+	0xd8, 0x38, 0x56, 0x3c, 0xb2, 0x97, 0x75, 0xbf, 0x0b, 0x06, 0xcf, 0xe0, 0xc6, 0x8f, 0x2d, 0x34,
+	0x7d, 0x97, 0xcb, 0x2d, 0xff, 0x04, 0x74, 0xa5, 0xf5, 0x99, 0x5c, 0x42, 0x46, 0x57, 0x77, 0xb9,
+	0xd4, 0x20, 0x35, 0x1c, 0xc1, 0xa8, 0xbc, 0xcd, 0x94, 0x56, 0x8b, 0x45, 0xa1, 0xa9, 0x11, 0xd1,
+	0xb7, 0xa7, 0x12, 0x44, 0xf4, 0x01, 0xd9, 0x08, 0x94, 0x56, 0x8d, 0x45, 0xa1, 0xa9, 0x65, 0x16,
+	0x28, 0xba, 0x77, 0x60, 0xa3, 0xba, 0xd5, 0x2c, 0xd1, 0xc4, 0xd2, 0x07, 0x2a, 0x63, 0xc0, 0xab,
+	0x06, 0x37, 0x56, 0xe3, 0x03, 0x0b, 0xcd, 0x24, 0x47, 0x04, 0xb2, 0x48, 0x9a, 0x1d, 0xa6, 0x58,
+	0x5a, 0x62, 0x72, 0x50, 0xae, 0x87, 0x56, 0xab, 0xc9, 0xa6, 0x3c, 0x94, 0x93, 0x41, 0xa2, 0xc3,
+	0x29, 0xad, 0xd1, 0x22, 0x08, 0xf3, 0x6c, 0xfb, 0xe2, 0x41, 0x9c, 0xdc, 0xaa, 0x48, 0xf7, 0xc9,
+	0x43, 0x64, 0x05, 0x8a, 0xb4, 0xc7, 0x94, 0x2f, 0x85, 0xf6, 0x38, 0x2d, 0x2a, 0x26, 0x82, 0x34,
+	0x23, 0x1b, 0xdf, 0x40, 0x95, 0xac, 0x28, 0x93, 0x16, 0xaa, 0x26, 0x86, 0x9f, 0x96, 0xbf, 0x85,
+	0x16, 0xce, 0x57, 0x6e, 0xc0, 0x69, 0xbe, 0x13, 0xc8, 0xed, 0x15, 0x7e, 0xba, 0x79, 0xaa, 0x78,
+	0x9c, 0x54, 0xef, 0xcc, 0x6e, 0xbc, 0x69, 0xa1, 0x49, 0xf0, 0x4a, 0x57, 0x89, 0x01, 0x0b, 0xf9,
+	0xb8, 0x06, 0x65, 0x36, 0xe4, 0x84, 0xfb, 0xc0, 0x17, 0xb9, 0x9c, 0x4f, 0x4c, 0x38, 0x9e, 0x03,
+	0xba, 0xad, 0x43, 0x50, 0xa1, 0xd0, 0x84, 0x42, 0xba, 0xcd, 0x8e, 0x78, 0xa0, 0xb3, 0xa3, 0x42,
+	0x8d, 0x41, 0x08, 0x14, 0xd2, 0xd8, 0xd3, 0x71, 0xa8, 0x50, 0xdd, 0x06, 0xac, 0x0b, 0x17, 0xfa,
+	0x9c, 0xc1, 0xa0, 0xdd, 0xf8, 0x4d, 0x09, 0x95, 0xbb, 0xd4, 0x85, 0xf2, 0x7c, 0x7f, 0xcd, 0x7e,
+	0x4a, 0x9f, 0x7a, 0xe9, 0xfe, 0x9a, 0xb6, 0x5b, 0xf6, 0x6a, 0x62, 0xb7, 0xb4, 0xbd, 0x6e, 0x7f,
+	0x29, 0xb1, 0xd7, 0xc9, 0x6d, 0x54, 0x71, 0x3d, 0x16, 0x70, 0x10, 0x96, 0xdd, 0xd2, 0x41, 0xb1,
+	0x75, 0x50, 0xba, 0xd4, 0xbd, 0x79, 0xcf, 0x8f, 0x47, 0x2c, 0xc8, 0x78, 0x3a, 0xee, 0x0a, 0xa2,
+	0xd1, 0xc6, 0x9a, 0xbd, 0x6e, 0x44, 0x63, 0xac, 0x0c, 0x6f, 0xd9, 0xcf, 0xe6, 0xf0, 0x56, 0x86,
+	0xaf, 0xdb, 0xcf, 0xe5, 0xf0, 0x75, 0x88, 0x10, 0x95, 0x8a, 0x29, 0xbe, 0x66, 0x7f, 0x4d, 0x13,
+	0xa9, 0x39, 0x66, 0x5a, 0xf6, 0x0b, 0x79, 0xa6, 0x35, 0x66, 0xd6, 0xed, 0x17, 0xf3, 0xcc, 0x7a,
+	0xe3, 0x16, 0xba, 0x70, 0xce, 0x67, 0x32, 0x8f, 0x2a, 0x1b, 0x23, 0x25, 0x35, 0x80, 0x27, 0xc8,
+	0x02, 0x42, 0x5b, 0xfe, 0x09, 0xef, 0x19, 0xdb, 0x6a, 0x0c, 0x10, 0xda, 0xe2, 0xbc, 0xb7, 0xcf,
+	0x22, 0x36, 0x8c, 0xc9, 0xd3, 0xe8, 0xe2, 0x41, 0xd8, 0x63, 0x8a, 0x3b, 0x42, 0xf1, 0xe8, 0x21,
+	0x0b, 0x76, 0x7c, 0xa1, 0x4f, 0xae, 0x44, 0x1f, 0x25, 0x3e, 0xa7, 0x37, 0x3b, 0xb1, 0xcb, 0x9f,
+	0xdb, 0x9b, 0x9d, 0x34, 0x7e, 0x62, 0xa1, 0x2a, 0x64, 0x8a, 0xcb, 0xfb, 0x43, 0x48, 0x29, 0x28,
+	0xd6, 0xa7, 0x8a, 0xef, 0x1d, 0xc7, 0x69, 0xbd, 0x4c, 0x4c, 0x88, 0x15, 0x34, 0xdd, 0xd7, 0xd3,
+	0x37, 0xa5, 0xb1, 0xe0, 0xbe, 0x72, 0x44, 0xe0, 0x0b, 0xae, 0x73, 0x70, 0x46, 0x0b, 0x32, 0x87,
+	0xe8, 0x57, 0x82, 0x8a, 0x38, 0x1b, 0x82, 0xde, 0x2a, 0x5a, 0x1c, 0x63, 0x40, 0xcf, 0x1a, 0xc8,
+	0xa3, 0x2c, 0xa7, 0x12, 0xab, 0xf1, 0x3c, 0x2a, 0xdf, 0x89, 0x22, 0x52, 0x47, 0x93, 0x6d, 0xd0,
+	0x80, 0x49, 0x8c, 0x39, 0xad, 0x81, 0x3b, 0x51, 0x04, 0x18, 0xd5, 0x0c, 0x48, 0x76, 0x27, 0xee,
+	0x27, 0x42, 0x86, 0x66, 0xe3, 0x9b, 0xa8, 0x02, 0x4f, 0x04, 0xee, 0xc9, 0xa8, 0x07, 0xf3, 0x77,
+	0xfd, 0x21, 0xdf, 0x72, 0xf5, 0x14, 0x65, 0x9a, 0x58, 0xe4, 0xff, 0x50, 0xb9, 0xe3, 0x47, 0x7a,
+	0xd8, 0x42, 0x92, 0xa9, 0x3b, 0x71, 0xbf, 0xe3, 0x47, 0x14, 0x70, 0xb2, 0x64, 0x66, 0x2d, 0x9f,
+	0x7b, 0x78, 0xea, 0xf9, 0x07, 0xe9, 0xbb, 0xf3, 0x6e, 0xc4, 0x84, 0xfa, 0xc2, 0xe7, 0x4b, 0xee,
+	0xb5, 0x56, 0x2a, 0xbe, 0xd6, 0x1e, 0x47, 0x93, 0x54, 0x06, 0xe6, 0x75, 0x99, 0xde, 0x9f, 0x66,
+	0x18, 0xc0, 0x54, 0x93, 0xab, 0x1f, 0x5b, 0x68, 0xaa, 0x2d, 0x45, 0xac, 0x40, 0x20, 0xba, 0x71,
+	0x08, 0xef, 0x17, 0x3c, 0x41, 0xae, 0xa3, 0xab, 0xc6, 0x7e, 0x59, 0xc6, 0xca, 0xe5, 0x71, 0xec,
+	0x4b, 0x61, 0x0a, 0x11, 0x2e, 0x93, 0xcb, 0x08, 0x1b, 0x92, 0x4a, 0xa9, 0x12, 0x74, 0x9a, 0x2c,
+	0x22, 0x62, 0xd0, 0xae, 0xd3, 0xd9, 0xf4, 0x05, 0x8b, 0x4e, 0xb7, 0xb9, 0xc0, 0xb5, 0x02, 0xee,
+	0xaa, 0xc8, 0x17, 0x7d, 0xc0, 0x6f, 0x11, 0x1b, 0x5d, 0xce, 0x70, 0x08, 0x5a, 0xac, 0xd8, 0x30,
+	0x74, 0x5f, 0xc7, 0xb3, 0xe4, 0x31, 0xb4, 0x9c, 0x39, 0xc3, 0x46, 0x81, 0xba, 0x1b, 0x85, 0x9e,
+	0xcb, 0xa3, 0x87, 0xbe, 0xc7, 0xf7, 0x65, 0xa4, 0xf0, 0x87, 0x4d, 0x72, 0x03, 0x2d, 0x15, 0xba,
+	0x7c, 0x3d, 0xce, 0x77, 0xf8, 0x6b, 0x73, 0xf5, 0xed, 0xc9, 0xec, 0x53, 0x82, 0x5c, 0x40, 0xd5,
+	0xa4, 0x79, 0x28, 0xfc, 0x00, 0x4f, 0xe4, 0x01, 0x5f, 0x28, 0x3c, 0x49, 0x2e, 0xa2, 0xf9, 0x14,
+	0x38, 0x82, 0x3a, 0x87, 0xa7, 0x09, 0x41, 0x0b, 0x29, 0x14, 0x6b, 0xaf, 0xf1, 0x4c, 0x7e, 0x5c,
+	0xd7, 0xe9, 0x60, 0x0c, 0x91, 0x48, 0x81, 0xf4, 0x8d, 0x84, 0x09, 0xc1, 0x68, 0x2e, 0x45, 0x41,
+	0x6d, 0x78, 0x31, 0xdf, 0xaf, 0xc3, 0x14, 0x87, 0xed, 0xe2, 0xab, 0x05, 0x74, 0x14, 0xe9, 0xea,
+	0x8d, 0xed, 0x3c, 0xba, 0x11, 0xc7, 0x5c, 0x1d, 0x50, 0x07, 0x5f, 0xcb, 0x2f, 0x7d, 0x40, 0xb7,
+	0xf1, 0x52, 0x1e, 0xb8, 0x13, 0x45, 0xb8, 0x45, 0xae, 0xa2, 0x4b, 0xb9, 0x35, 0xd2, 0x84, 0xc3,
+	0xcf, 0x92, 0x4b, 0xe8, 0x42, 0x4a, 0x24, 0x97, 0x0e, 0xbe, 0x4d, 0xae, 0xa0, 0x8b, 0x19, 0x98,
+	0xde, 0x16, 0xf8, 0xcb, 0x85, 0x1d, 0x9e, 0x08, 0xfc, 0x95, 0xbc, 0x37, 0xe9, 0x37, 0x02, 0xfe,
+	0x6a, 0x7e, 0x87, 0x5a, 0x30, 0x2f, 0xe4, 0xc3, 0x65, 0xde, 0x54, 0xf8, 0xa5, 0xfc, 0x1a, 0xd9,
+	0x13, 0x05, 0x6f, 0x92, 0x25, 0xb4, 0x58, 0x98, 0x32, 0xfb, 0x0c, 0xc0, 0x9d, 0xfc, 0x26, 0x72,
+	0x39, 0x80, 0xb7, 0xf2, 0x2b, 0xc2, 0xfd, 0x83, 0xf7, 0xf3, 0x2b, 0x9a, 0x3b, 0x10, 0xd3, 0x82,
+	0xfb, 0xd4, 0xc5, 0x5d, 0x72, 0x15, 0x91, 0xec, 0x28, 0x46, 0x7e, 0xa0, 0x7c, 0xb1, 0xc3, 0x4e,
+	0xf0, 0x3f, 0x67, 0x56, 0xdf, 0x29, 0xa1, 0x29, 0xfd, 0x95, 0x0b, 0xd2, 0xd7, 0x8d, 0xc3, 0x5d,
+	0xb9, 0x17, 0x1a, 0x71, 0x18, 0x5b, 0x3b, 0x87, 0x2d, 0xb2, 0x8c, 0x6c, 0x03, 0x50, 0x1e, 0xcb,
+	0xe0, 0x21, 0xdf, 0x10, 0x3d, 0xca, 0xfb, 0x7e, 0xac, 0x78, 0x84, 0xa7, 0x40, 0x3a, 0x86, 0x4d,
+	0xde, 0x7d, 0x26, 0x13, 0x32, 0x68, 0xbc, 0xf1, 0x59, 0xf0, 0x38, 0xc1, 0x47, 0xf1, 0x00, 0x08,
+	0x8c, 0x20, 0xbe, 0x06, 0x73, 0x44, 0xcc, 0x23, 0x9d, 0x4d, 0x78, 0x01, 0x76, 0x6b, 0x50, 0xf8,
+	0x2a, 0xf4, 0x15, 0xb6, 0xc9, 0x35, 0x74, 0xc5, 0x20, 0x3a, 0x20, 0xe3, 0x4c, 0x36, 0x09, 0x96,
+	0x74, 0x8e, 0x38, 0x53, 0xc9, 0xe7, 0x1f, 0xbe, 0x01, 0xb1, 0x34, 0xf8, 0x81, 0x18, 0xca, 0x51,
+	0x3a, 0x08, 0xd7, 0xc7, 0x03, 0x3a, 0x3c, 0xe0, 0xd9, 0x80, 0xc7, 0xc8, 0xa5, 0xd4, 0xbf, 0x76,
+	0x20, 0x63, 0x0e, 0x67, 0xf8, 0x5f, 0x6b, 0xf5, 0x1e, 0x9a, 0x4d, 0x3f, 0x9b, 0x93, 0xbd, 0xea,
+	0xf6, 0xe1, 0xae, 0x14, 0xdc, 0x14, 0x8a, 0x0c, 0x02, 0xe7, 0xdb, 0x03, 0xee, 0x3d, 0x08, 0x25,
+	0xa4, 0x95, 0x45, 0x96, 0xd0, 0x95, 0x8c, 0x34, 0xdf, 0xeb, 0xee, 0x80, 0x45, 0xbc, 0x87, 0xdf,
+	0x28, 0xad, 0x7a, 0xf9, 0x67, 0x3e, 0x84, 0x61, 0x6c, 0x1d, 0xea, 0xbb, 0x0a, 0x4f, 0x40, 0xc0,
+	0x72, 0xa8, 0x73, 0xfb, 0x59, 0x5c, 0x02, 0x51, 0xe5, 0x30, 0xc8, 0xa4, 0xb5, 0xdb, 0x78, 0xea,
+	0xdc, 0x04, 0x07, 0xdd, 0xf6, 0xda, 0x6d, 0x3c, 0xbd, 0x7a, 0x03, 0xcd, 0xa6, 0xaf, 0x48, 0x48,
+	0x83, 0xb4, 0x7d, 0xe8, 0x86, 0x03, 0x1e, 0x71, 0x3c, 0xb1, 0xfa, 0x53, 0xab, 0xf0, 0x40, 0x82,
+	0x1d, 0x66, 0xe6, 0xe1, 0xae, 0x2e, 0x16, 0xcb, 0xc8, 0x1e, 0x43, 0x2e, 0xf7, 0x22, 0xae, 0x36,
+	0xe5, 0xc9, 0xe1, 0x2e, 0x6b, 0x07, 0xb8, 0x07, 0x62, 0x1e, 0xb3, 0x1b, 0xf1, 0xe9, 0x70, 0x27,
+	0xee, 0x1b, 0x8e, 0x17, 0x39, 0xf8, 0x70, 0xf5, 0x45, 0xc2, 0x1d, 0x93, 0x1a, 0xba, 0xf6, 0x28,
+	0x77, 0xa7, 0xd3, 0x7a, 0xee, 0xb9, 0xb5, 0xe7, 0xf1, 0xdf, 0xac, 0xd5, 0x0f, 0xa6, 0xd1, 0x4c,
+	0x72, 0x11, 0x81, 0x53, 0x49, 0xf3, 0x70, 0x57, 0x42, 0xb2, 0x4f, 0x80, 0xae, 0x53, 0xe8, 0x40,
+	0x08, 0x36, 0xe4, 0x3d, 0xc0, 0xbf, 0xb5, 0x42, 0x6c, 0x74, 0x29, 0x25, 0xf4, 0x35, 0x2c, 0x58,
+	0x00, 0xcc, 0xb7, 0x57, 0xe0, 0x30, 0xc6, 0x43, 0xe2, 0x51, 0x18, 0xca, 0x48, 0xf1, 0xde, 0x5e,
+	0x88, 0xbf, 0x73, 0x8e, 0xf3, 0x87, 0x61, 0xc0, 0xa1, 0x76, 0xf0, 0x1e, 0xfe, 0x6e, 0x61, 0x46,
+	0xca, 0x5f, 0x6d, 0x33, 0xe1, 0xf1, 0x80, 0xf7, 0xf0, 0x9b, 0x2b, 0xe4, 0x1a, 0xba, 0x9c, 0x32,
+	0xee, 0x60, 0xa4, 0x94, 0x2f, 0xfa, 0x1d, 0xf9, 0x9a, 0xc0, 0xdf, 0x2b, 0x50, 0x1d, 0x3f, 0xf6,
+	0xa4, 0x10, 0xdc, 0x83, 0xf9, 0xbe, 0x5f, 0xa0, 0x1c, 0xf1, 0x90, 0x05, 0x7e, 0xcf, 0x24, 0xda,
+	0x0f, 0xce, 0x2f, 0xb5, 0x2b, 0xd5, 0x16, 0x7c, 0xc8, 0xe1, 0x1f, 0xad, 0xe4, 0xf7, 0x9b, 0x0c,
+	0x02, 0x79, 0xbe, 0xf5, 0x79, 0x04, 0xd4, 0xcb, 0xb7, 0x57, 0xc8, 0x15, 0x84, 0x53, 0x62, 0x93,
+	0xf5, 0xf4, 0xf7, 0x39, 0xfe, 0xd9, 0x0a, 0x59, 0x46, 0x57, 0xc7, 0xb1, 0x54, 0x03, 0x5f, 0xf4,
+	0xbb, 0x32, 0x49, 0xb2, 0x77, 0x0a, 0xbe, 0x19, 0x70, 0x8b, 0xf9, 0xb0, 0xd9, 0x5f, 0xac, 0x90,
+	0xeb, 0x68, 0x31, 0xa5, 0x4c, 0xc2, 0x64, 0xee, 0xbd, 0x5b, 0x88, 0x9f, 0x21, 0x61, 0xdc, 0x28,
+	0xe2, 0xf8, 0xbd, 0xc2, 0xa6, 0x36, 0xc2, 0x30, 0x1b, 0xf5, 0xfe, 0x79, 0xa6, 0x2d, 0xc5, 0x71,
+	0xe0, 0x7b, 0x0a, 0xff, 0xb2, 0xe0, 0xc7, 0xae, 0xd4, 0x5f, 0xd5, 0x66, 0xd0, 0xaf, 0x0a, 0x83,
+	0x76, 0x58, 0x70, 0x2c, 0xa3, 0x21, 0xef, 0x75, 0x4f, 0xf0, 0xaf, 0x0b, 0x83, 0x20, 0x09, 0xb2,
+	0x95, 0x7e, 0xb7, 0x02, 0x6a, 0x3b, 0x47, 0xa5, 0x15, 0x8c, 0xf7, 0xf0, 0xef, 0x57, 0xc8, 0x22,
+	0xba, 0x98, 0x0b, 0x96, 0xb9, 0xca, 0xf0, 0x1f, 0x0a, 0x8b, 0xc1, 0x9d, 0x92, 0xee, 0xea, 0x8f,
+	0xe7, 0x74, 0xa6, 0xe3, 0xae, 0x4b, 0xd7, 0x9f, 0x0a, 0xcc, 0xae, 0x54, 0xfb, 0xbe, 0x10, 0xec,
+	0x28, 0xe0, 0xf8, 0xcf, 0x2b, 0x70, 0x69, 0xa7, 0xcc, 0x3d, 0x5f, 0x06, 0x4c, 0xf1, 0x78, 0x23,
+	0x0c, 0xb9, 0xe8, 0xed, 0x89, 0xe0, 0x14, 0xff, 0x7b, 0x85, 0x3c, 0x81, 0x6e, 0x8c, 0x27, 0x8d,
+	0x47, 0xc7, 0xc7, 0xbe, 0xe7, 0x73, 0xa1, 0xf6, 0x79, 0x34, 0xf4, 0xf5, 0x13, 0x24, 0xc6, 0xff,
+	0x29, 0xf4, 0x6a, 0x0f, 0xf6, 0xe1, 0x77, 0xa6, 0x27, 0x03, 0xbd, 0x25, 0x4f, 0xf6, 0x85, 0xff,
+	0x3a, 0xef, 0xe1, 0xbf, 0x37, 0x57, 0x6f, 0xa1, 0x69, 0xf3, 0xe0, 0x4a, 0xaa, 0x56, 0xc7, 0x8f,
+	0x0e, 0xbb, 0x12, 0x9e, 0x33, 0x78, 0x02, 0x6a, 0x41, 0x06, 0xb5, 0x03, 0x58, 0x03, 0x5b, 0xab,
+	0x7d, 0x84, 0xc6, 0x75, 0x15, 0xba, 0x8c, 0xad, 0xb4, 0xda, 0x5d, 0x45, 0x97, 0x72, 0x20, 0xe5,
+	0xcc, 0xb8, 0x6e, 0x41, 0x55, 0xca, 0x11, 0x3b, 0x7c, 0x78, 0xc4, 0x23, 0x5c, 0x82, 0xaa, 0x94,
+	0x83, 0xf7, 0x5e, 0x13, 0x3c, 0xc2, 0xe5, 0xd6, 0x1a, 0x9a, 0x05, 0x3f, 0xe0, 0x59, 0x43, 0x9e,
+	0x44, 0xd5, 0xdc, 0x13, 0x8b, 0x64, 0x4f, 0xc1, 0xa5, 0xac, 0xd5, 0xb4, 0x6e, 0x59, 0x9b, 0x2f,
+	0xbd, 0x5f, 0xaa, 0x6c, 0x44, 0xde, 0x7d, 0x7a, 0x73, 0x23, 0xf2, 0x3e, 0xfa, 0xa4, 0x36, 0xf1,
+	0xf1, 0x27, 0xb5, 0x89, 0xcf, 0x3e, 0xa9, 0x59, 0x6f, 0x9c, 0xd5, 0xac, 0x77, 0xcf, 0x6a, 0xd6,
+	0x87, 0x67, 0x35, 0xeb, 0xa3, 0xb3, 0x9a, 0xf5, 0x8f, 0xb3, 0x9a, 0xf5, 0xaf, 0xb3, 0xda, 0xc4,
+	0x67, 0x67, 0x35, 0xeb, 0x87, 0x9f, 0xd6, 0x26, 0x3e, 0xfa, 0xb4, 0x36, 0xf1, 0xf1, 0xa7, 0xb5,
+	0x89, 0xa3, 0x69, 0xfd, 0xe3, 0x77, 0xfd, 0x7f, 0x03, 0x00, 0x33, 0xfe, 0x75, 0x40, 0x09, 0x16,
+	0x00, 0x00,
 }
 
 func (x Const) String() string {
@@ -2070,6 +2534,20 @@ func (x ErrCode) String() string {
 	}
 	return strconv.Itoa(int(x))
 }
+func (x MsgDir) String() string {
+	s, ok := MsgDir_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x PlanetRole) String() string {
+	s, ok := PlanetRole_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
 func (x TRS_VisualScaleMode) String() string {
 	s, ok := TRS_VisualScaleMode_name[int32(x)]
 	if ok {
@@ -2191,6 +2669,12 @@ func (this *UserSeat) Equal(that interface{}) bool {
 	if this.HomePlanetID != that1.HomePlanetID {
 		return false
 	}
+	if !this.PubKey.Equal(that1.PubKey) {
+		return false
+	}
+	if !bytes.Equal(this.ResumeToken, that1.ResumeToken) {
+		return false
+	}
 	return true
 }
 func (this *LoginReq) Equal(that interface{}) bool {
@@ -2215,16 +2699,19 @@ func (this *LoginReq) Equal(that interface{}) bool {
 	if !bytes.Equal(this.UserUID, that1.UserUID) {
 		return false
 	}
+	if !this.PubKey.Equal(that1.PubKey) {
+		return false
+	}
 	return true
 }
-func (this *Symbol) Equal(that interface{}) bool {
+func (this *LoginChallenge) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Symbol)
+	that1, ok := that.(*LoginChallenge)
 	if !ok {
-		that2, ok := that.(Symbol)
+		that2, ok := that.(LoginChallenge)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2236,10 +2723,72 @@ func (this *Symbol) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.ID != that1.ID {
+	if !bytes.Equal(this.Nonce, that1.Nonce) {
 		return false
 	}
-	if !bytes.Equal(this.Value, that1.Value) {
+	if !bytes.Equal(this.Signature, that1.Signature) {
+		return false
+	}
+	if !bytes.Equal(this.ResumeToken, that1.ResumeToken) {
+		return false
+	}
+	return true
+}
+func (this *Txn) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Txn)
+	if !ok {
+		that2, ok := that.(Txn)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.PlanetID != that1.PlanetID {
+		return false
+	}
+	if len(this.Msgs) != len(that1.Msgs) {
+		return false
+	}
+	for i := range this.Msgs {
+		if !this.Msgs[i].Equal(that1.Msgs[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Symbol) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Symbol)
+	if !ok {
+		that2, ok := that.(Symbol)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	if !bytes.Equal(this.Value, that1.Value) {
 		return false
 	}
 	return true
@@ -2397,6 +2946,9 @@ func (this *PinReq) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.PlanetID != that1.PlanetID {
+		return false
+	}
 	return true
 }
 func (this *AttrRange) Equal(that interface{}) bool {
@@ -2424,9 +2976,15 @@ func (this *AttrRange) Equal(that interface{}) bool {
 	if this.SI_StopAt != that1.SI_StopAt {
 		return false
 	}
+	if this.SI_StopAtSet != that1.SI_StopAtSet {
+		return false
+	}
 	if this.SI_BatchLimit != that1.SI_BatchLimit {
 		return false
 	}
+	if this.FromID_SeekAfter != that1.FromID_SeekAfter {
+		return false
+	}
 	return true
 }
 func (this *GeoFix) Equal(that interface{}) bool {
@@ -2711,6 +3269,66 @@ func (this *Err) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *MsgRecord) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MsgRecord)
+	if !ok {
+		that2, ok := that.(MsgRecord)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.TimeFS != that1.TimeFS {
+		return false
+	}
+	if this.Dir != that1.Dir {
+		return false
+	}
+	if !this.Msg.Equal(that1.Msg) {
+		return false
+	}
+	return true
+}
+func (this *PlanetGrant) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PlanetGrant)
+	if !ok {
+		that2, ok := that.(PlanetGrant)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.PlanetID != that1.PlanetID {
+		return false
+	}
+	if !bytes.Equal(this.UserUID, that1.UserUID) {
+		return false
+	}
+	if this.Role != that1.Role {
+		return false
+	}
+	return true
+}
 func (this *Msg) GoString() string {
 	if this == nil {
 		return "nil"
@@ -2751,10 +3369,13 @@ func (this *UserSeat) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 7)
 	s = append(s, "&arc.UserSeat{")
 	s = append(s, "UserID: "+fmt.Sprintf("%#v", this.UserID)+",\n")
 	s = append(s, "HomePlanetID: "+fmt.Sprintf("%#v", this.HomePlanetID)+",\n")
+	if this.PubKey != nil {
+		s = append(s, "PubKey: "+fmt.Sprintf("%#v", this.PubKey)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -2762,9 +3383,38 @@ func (this *LoginReq) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
+	s := make([]string, 0, 7)
 	s = append(s, "&arc.LoginReq{")
 	s = append(s, "UserUID: "+fmt.Sprintf("%#v", this.UserUID)+",\n")
+	if this.PubKey != nil {
+		s = append(s, "PubKey: "+fmt.Sprintf("%#v", this.PubKey)+",\n")
+	}
+	s = append(s, "ResumeToken: "+fmt.Sprintf("%#v", this.ResumeToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LoginChallenge) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&arc.LoginChallenge{")
+	s = append(s, "Nonce: "+fmt.Sprintf("%#v", this.Nonce)+",\n")
+	s = append(s, "Signature: "+fmt.Sprintf("%#v", this.Signature)+",\n")
+	s = append(s, "ResumeToken: "+fmt.Sprintf("%#v", this.ResumeToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Txn) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&arc.Txn{")
+	s = append(s, "PlanetID: "+fmt.Sprintf("%#v", this.PlanetID)+",\n")
+	if this.Msgs != nil {
+		s = append(s, "Msgs: "+fmt.Sprintf("%#v", this.Msgs)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -2828,13 +3478,14 @@ func (this *PinReq) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 9)
+	s := make([]string, 0, 10)
 	s = append(s, "&arc.PinReq{")
 	s = append(s, "ParentReqID: "+fmt.Sprintf("%#v", this.ParentReqID)+",\n")
 	s = append(s, "PinURI: "+fmt.Sprintf("%#v", this.PinURI)+",\n")
 	s = append(s, "PinCell: "+fmt.Sprintf("%#v", this.PinCell)+",\n")
 	s = append(s, "ContentSchema: "+fmt.Sprintf("%#v", this.ContentSchema)+",\n")
 	s = append(s, "ChildSchemas: "+fmt.Sprintf("%#v", this.ChildSchemas)+",\n")
+	s = append(s, "PlanetID: "+fmt.Sprintf("%#v", this.PlanetID)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -2842,11 +3493,13 @@ func (this *AttrRange) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 9)
 	s = append(s, "&arc.AttrRange{")
 	s = append(s, "SI_SeekTo: "+fmt.Sprintf("%#v", this.SI_SeekTo)+",\n")
 	s = append(s, "SI_StopAt: "+fmt.Sprintf("%#v", this.SI_StopAt)+",\n")
+	s = append(s, "SI_StopAtSet: "+fmt.Sprintf("%#v", this.SI_StopAtSet)+",\n")
 	s = append(s, "SI_BatchLimit: "+fmt.Sprintf("%#v", this.SI_BatchLimit)+",\n")
+	s = append(s, "FromID_SeekAfter: "+fmt.Sprintf("%#v", this.FromID_SeekAfter)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -2962,6 +3615,32 @@ func (this *Err) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *MsgRecord) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&arc.MsgRecord{")
+	s = append(s, "TimeFS: "+fmt.Sprintf("%#v", this.TimeFS)+",\n")
+	s = append(s, "Dir: "+fmt.Sprintf("%#v", this.Dir)+",\n")
+	if this.Msg != nil {
+		s = append(s, "Msg: "+fmt.Sprintf("%#v", this.Msg)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PlanetGrant) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&arc.PlanetGrant{")
+	s = append(s, "PlanetID: "+fmt.Sprintf("%#v", this.PlanetID)+",\n")
+	s = append(s, "UserUID: "+fmt.Sprintf("%#v", this.UserUID)+",\n")
+	s = append(s, "Role: "+fmt.Sprintf("%#v", this.Role)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringArc(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -3257,6 +3936,18 @@ func (m *UserSeat) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.PubKey != nil {
+		{
+			size, err := m.PubKey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintArc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.HomePlanetID != 0 {
 		i = encodeVarintArc(dAtA, i, uint64(m.HomePlanetID))
 		i--
@@ -3290,6 +3981,25 @@ func (m *LoginReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ResumeToken) > 0 {
+		i -= len(m.ResumeToken)
+		copy(dAtA[i:], m.ResumeToken)
+		i = encodeVarintArc(dAtA, i, uint64(len(m.ResumeToken)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.PubKey != nil {
+		{
+			size, err := m.PubKey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintArc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.UserUID) > 0 {
 		i -= len(m.UserUID)
 		copy(dAtA[i:], m.UserUID)
@@ -3300,6 +4010,92 @@ func (m *LoginReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *LoginChallenge) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LoginChallenge) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LoginChallenge) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ResumeToken) > 0 {
+		i -= len(m.ResumeToken)
+		copy(dAtA[i:], m.ResumeToken)
+		i = encodeVarintArc(dAtA, i, uint64(len(m.ResumeToken)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintArc(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Nonce) > 0 {
+		i -= len(m.Nonce)
+		copy(dAtA[i:], m.Nonce)
+		i = encodeVarintArc(dAtA, i, uint64(len(m.Nonce)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Txn) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Txn) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Txn) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Msgs) > 0 {
+		for iNdEx := len(m.Msgs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Msgs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintArc(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.PlanetID != 0 {
+		i = encodeVarintArc(dAtA, i, uint64(m.PlanetID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Symbol) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3519,22 +4315,27 @@ func (m *PinReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.PlanetID != 0 {
+		i = encodeVarintArc(dAtA, i, uint64(m.PlanetID))
+		i--
+		dAtA[i] = 0x58
+	}
 	if len(m.ChildSchemas) > 0 {
-		dAtA3 := make([]byte, len(m.ChildSchemas)*10)
-		var j2 int
+		dAtA5 := make([]byte, len(m.ChildSchemas)*10)
+		var j4 int
 		for _, num1 := range m.ChildSchemas {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA5[j4] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j2++
+				j4++
 			}
-			dAtA3[j2] = uint8(num)
-			j2++
+			dAtA5[j4] = uint8(num)
+			j4++
 		}
-		i -= j2
-		copy(dAtA[i:], dAtA3[:j2])
-		i = encodeVarintArc(dAtA, i, uint64(j2))
+		i -= j4
+		copy(dAtA[i:], dAtA5[:j4])
+		i = encodeVarintArc(dAtA, i, uint64(j4))
 		i--
 		dAtA[i] = 0x4a
 	}
@@ -3583,6 +4384,13 @@ func (m *AttrRange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.FromID_SeekAfter != 0 {
+		i = encodeVarintArc(dAtA, i, uint64(m.FromID_SeekAfter))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe0
+	}
 	if m.SI_BatchLimit != 0 {
 		i = encodeVarintArc(dAtA, i, uint64(m.SI_BatchLimit))
 		i--
@@ -3590,6 +4398,18 @@ func (m *AttrRange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xd8
 	}
+	if m.SI_StopAtSet {
+		i--
+		if m.SI_StopAtSet {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd0
+	}
 	if m.SI_StopAt != 0 {
 		i = encodeVarintArc(dAtA, i, uint64(m.SI_StopAt))
 		i--
@@ -4048,16 +4868,101 @@ func (m *Err) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintArc(dAtA []byte, offset int, v uint64) int {
-	offset -= sovArc(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (m *MsgRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Msg != nil {
+		{
+			size, err := m.Msg.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintArc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Dir != 0 {
+		i = encodeVarintArc(dAtA, i, uint64(m.Dir))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.TimeFS != 0 {
+		i = encodeVarintArc(dAtA, i, uint64(m.TimeFS))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PlanetGrant) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlanetGrant) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PlanetGrant) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Role != 0 {
+		i = encodeVarintArc(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.UserUID) > 0 {
+		i -= len(m.UserUID)
+		copy(dAtA[i:], m.UserUID)
+		i = encodeVarintArc(dAtA, i, uint64(len(m.UserUID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PlanetID != 0 {
+		i = encodeVarintArc(dAtA, i, uint64(m.PlanetID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintArc(dAtA []byte, offset int, v uint64) int {
+	offset -= sovArc(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
 }
 func (m *Msg) Size() (n int) {
 	if m == nil {
@@ -4138,6 +5043,14 @@ func (m *UserSeat) Size() (n int) {
 	if m.HomePlanetID != 0 {
 		n += 1 + sovArc(uint64(m.HomePlanetID))
 	}
+	if m.PubKey != nil {
+		l = m.PubKey.Size()
+		n += 1 + l + sovArc(uint64(l))
+	}
+	l = len(m.ResumeToken)
+	if l > 0 {
+		n += 1 + l + sovArc(uint64(l))
+	}
 	return n
 }
 
@@ -4151,6 +5064,49 @@ func (m *LoginReq) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovArc(uint64(l))
 	}
+	if m.PubKey != nil {
+		l = m.PubKey.Size()
+		n += 1 + l + sovArc(uint64(l))
+	}
+	return n
+}
+
+func (m *LoginChallenge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Nonce)
+	if l > 0 {
+		n += 1 + l + sovArc(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovArc(uint64(l))
+	}
+	l = len(m.ResumeToken)
+	if l > 0 {
+		n += 1 + l + sovArc(uint64(l))
+	}
+	return n
+}
+
+func (m *Txn) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PlanetID != 0 {
+		n += 1 + sovArc(uint64(m.PlanetID))
+	}
+	if len(m.Msgs) > 0 {
+		for _, e := range m.Msgs {
+			l = e.Size()
+			n += 1 + l + sovArc(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -4272,6 +5228,9 @@ func (m *PinReq) Size() (n int) {
 		}
 		n += 1 + sovArc(uint64(l)) + l
 	}
+	if m.PlanetID != 0 {
+		n += 1 + sovArc(uint64(m.PlanetID))
+	}
 	return n
 }
 
@@ -4287,9 +5246,15 @@ func (m *AttrRange) Size() (n int) {
 	if m.SI_StopAt != 0 {
 		n += 2 + sovArc(uint64(m.SI_StopAt))
 	}
+	if m.SI_StopAtSet {
+		n += 3
+	}
 	if m.SI_BatchLimit != 0 {
 		n += 2 + sovArc(uint64(m.SI_BatchLimit))
 	}
+	if m.FromID_SeekAfter != 0 {
+		n += 2 + sovArc(uint64(m.FromID_SeekAfter))
+	}
 	return n
 }
 
@@ -4492,6 +5457,44 @@ func (m *Err) Size() (n int) {
 	return n
 }
 
+func (m *MsgRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TimeFS != 0 {
+		n += 1 + sovArc(uint64(m.TimeFS))
+	}
+	if m.Dir != 0 {
+		n += 1 + sovArc(uint64(m.Dir))
+	}
+	if m.Msg != nil {
+		l = m.Msg.Size()
+		n += 1 + l + sovArc(uint64(l))
+	}
+	return n
+}
+
+func (m *PlanetGrant) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PlanetID != 0 {
+		n += 1 + sovArc(uint64(m.PlanetID))
+	}
+	l = len(m.UserUID)
+	if l > 0 {
+		n += 1 + l + sovArc(uint64(l))
+	}
+	if m.Role != 0 {
+		n += 1 + sovArc(uint64(m.Role))
+	}
+	return n
+}
+
 func sovArc(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -4542,6 +5545,7 @@ func (this *UserSeat) String() string {
 	s := strings.Join([]string{`&UserSeat{`,
 		`UserID:` + fmt.Sprintf("%v", this.UserID) + `,`,
 		`HomePlanetID:` + fmt.Sprintf("%v", this.HomePlanetID) + `,`,
+		`PubKey:` + strings.Replace(this.PubKey.String(), "CryptoKey", "CryptoKey", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -4552,6 +5556,34 @@ func (this *LoginReq) String() string {
 	}
 	s := strings.Join([]string{`&LoginReq{`,
 		`UserUID:` + fmt.Sprintf("%v", this.UserUID) + `,`,
+		`PubKey:` + strings.Replace(this.PubKey.String(), "CryptoKey", "CryptoKey", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LoginChallenge) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LoginChallenge{`,
+		`Nonce:` + fmt.Sprintf("%v", this.Nonce) + `,`,
+		`Signature:` + fmt.Sprintf("%v", this.Signature) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Txn) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMsgs := "[]*Msg{"
+	for _, f := range this.Msgs {
+		repeatedStringForMsgs += strings.Replace(f.String(), "Msg", "Msg", 1) + ","
+	}
+	repeatedStringForMsgs += "}"
+	s := strings.Join([]string{`&Txn{`,
+		`PlanetID:` + fmt.Sprintf("%v", this.PlanetID) + `,`,
+		`Msgs:` + repeatedStringForMsgs + `,`,
 		`}`,
 	}, "")
 	return s
@@ -4631,6 +5663,7 @@ func (this *PinReq) String() string {
 		`PinCell:` + fmt.Sprintf("%v", this.PinCell) + `,`,
 		`ContentSchema:` + fmt.Sprintf("%v", this.ContentSchema) + `,`,
 		`ChildSchemas:` + fmt.Sprintf("%v", this.ChildSchemas) + `,`,
+		`PlanetID:` + fmt.Sprintf("%v", this.PlanetID) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -4642,7 +5675,9 @@ func (this *AttrRange) String() string {
 	s := strings.Join([]string{`&AttrRange{`,
 		`SI_SeekTo:` + fmt.Sprintf("%v", this.SI_SeekTo) + `,`,
 		`SI_StopAt:` + fmt.Sprintf("%v", this.SI_StopAt) + `,`,
+		`SI_StopAtSet:` + fmt.Sprintf("%v", this.SI_StopAtSet) + `,`,
 		`SI_BatchLimit:` + fmt.Sprintf("%v", this.SI_BatchLimit) + `,`,
+		`FromID_SeekAfter:` + fmt.Sprintf("%v", this.FromID_SeekAfter) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -4757,6 +5792,30 @@ func (this *Err) String() string {
 	}, "")
 	return s
 }
+func (this *MsgRecord) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MsgRecord{`,
+		`TimeFS:` + fmt.Sprintf("%v", this.TimeFS) + `,`,
+		`Dir:` + fmt.Sprintf("%v", this.Dir) + `,`,
+		`Msg:` + strings.Replace(this.Msg.String(), "Msg", "Msg", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PlanetGrant) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PlanetGrant{`,
+		`PlanetID:` + fmt.Sprintf("%v", this.PlanetID) + `,`,
+		`UserUID:` + fmt.Sprintf("%v", this.UserUID) + `,`,
+		`Role:` + fmt.Sprintf("%v", this.Role) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringArc(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -5273,6 +6332,42 @@ func (m *UserSeat) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthArc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthArc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PubKey == nil {
+				m.PubKey = &CryptoKey{}
+			}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipArc(dAtA[iNdEx:])
@@ -5357,38 +6452,363 @@ func (m *LoginReq) Unmarshal(dAtA []byte) error {
 				m.UserUID = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipArc(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthArc
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthArc
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Symbol) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowArc
+			if m.PubKey == nil {
+				m.PubKey = &CryptoKey{}
 			}
-			if iNdEx >= l {
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeToken", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthArc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResumeToken = append(m.ResumeToken[:0], dAtA[iNdEx:postIndex]...)
+			if m.ResumeToken == nil {
+				m.ResumeToken = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipArc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthArc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LoginChallenge) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowArc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LoginChallenge: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LoginChallenge: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthArc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nonce = append(m.Nonce[:0], dAtA[iNdEx:postIndex]...)
+			if m.Nonce == nil {
+				m.Nonce = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthArc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeToken", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthArc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResumeToken = append(m.ResumeToken[:0], dAtA[iNdEx:postIndex]...)
+			if m.ResumeToken == nil {
+				m.ResumeToken = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipArc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthArc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Txn) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowArc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Txn: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Txn: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlanetID", wireType)
+			}
+			m.PlanetID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PlanetID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msgs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthArc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthArc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msgs = append(m.Msgs, &Msg{})
+			if err := m.Msgs[len(m.Msgs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipArc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthArc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Symbol) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowArc
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
 			b := dAtA[iNdEx]
@@ -6150,6 +7570,25 @@ func (m *PinReq) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field ChildSchemas", wireType)
 			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlanetID", wireType)
+			}
+			m.PlanetID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PlanetID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipArc(dAtA[iNdEx:])
@@ -6238,6 +7677,26 @@ func (m *AttrRange) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SI_StopAtSet", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SI_StopAtSet = bool(v != 0)
 		case 27:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field SI_BatchLimit", wireType)
@@ -6257,6 +7716,25 @@ func (m *AttrRange) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 28:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromID_SeekAfter", wireType)
+			}
+			m.FromID_SeekAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromID_SeekAfter |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipArc(dAtA[iNdEx:])
@@ -7451,6 +8929,252 @@ func (m *Err) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MsgRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowArc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeFS", wireType)
+			}
+			m.TimeFS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeFS |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dir", wireType)
+			}
+			m.Dir = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Dir |= MsgDir(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthArc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthArc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Msg == nil {
+				m.Msg = &Msg{}
+			}
+			if err := m.Msg.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipArc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthArc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlanetGrant) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowArc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlanetGrant: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlanetGrant: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlanetID", wireType)
+			}
+			m.PlanetID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PlanetID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserUID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthArc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserUID = append(m.UserUID[:0], dAtA[iNdEx:postIndex]...)
+			if m.UserUID == nil {
+				m.UserUID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= PlanetRole(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipArc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthArc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipArc(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -5,19 +5,46 @@ import (
 
 	"github.com/arcspace/go-arcspace/arc"
 	"github.com/arcspace/go-arcspace/arc/apps/filesys"
+	"github.com/arcspace/go-arcspace/arc/apps/sys"
 	"github.com/arcspace/go-arcspace/arc/apps/vibe"
 	"github.com/arcspace/go-arcspace/arc/host"
 )
 
-// StartNewHost starts a new host with the given opts
+// availableApp is an App archost can start.
+type availableApp struct {
+	appURI string
+	newApp func() arc.App
+}
+
+// availableApps are the Apps archost can start, in the order they are started.
+var availableApps = []availableApp{
+	{vibe.AppURI, vibe.NewApp},
+	{filesys.AppURI, filesys.NewApp},
+	{sys.AppURI, sys.NewApp},
+}
+
+// StartNewHost starts a new host with the given opts and all available Apps
 func StartNewHost(opts host.HostOpts) arc.Host {
-	h, err := host.StartNewHost(opts)
+	h, err := startHost(opts, availableApps)
 	if err != nil {
 		log.Fatalf("failed to start new host: %v", err)
 	}
+	return h
+}
 
-	h.RegisterApp(vibe.NewApp())
-	h.RegisterApp(filesys.NewApp())
+func startHost(opts host.HostOpts, apps []availableApp) (arc.Host, error) {
+	h, err := host.StartNewHost(opts)
+	if err != nil {
+		return nil, err
+	}
 
-	return h
+	for _, app := range apps {
+		if err = h.RegisterApp(app.newApp()); err != nil {
+			h.Close()
+			<-h.Done()
+			return nil, arc.ErrCode_InternalErr.Errorf("failed to register app %q: %v", app.appURI, err)
+		}
+	}
+
+	return h, nil
 }
@@ -0,0 +1,332 @@
+package archost
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/grpc_service"
+	"github.com/arcspace/go-arcspace/arc/host"
+	"github.com/arcspace/go-arcspace/arc/sock_service"
+	"github.com/arcspace/go-arcspace/arc/ws_service"
+)
+
+// Config selects the Apps and HostServices archost starts and the opts of its host.
+// It's typically read from a JSON file via ReadFile, e.g.
+//
+//	{
+//	    "host": {
+//	        "statePath":        "~/_.archost",
+//	        "cellIdleClose":    "3m",
+//	        "planetIdleClose":  "2m",
+//	        "valueLogFileSize": 134217728,
+//	        "admins":           [ "alice" ]
+//	    },
+//	    "apps": [
+//	        { "app": "vibe.app" },
+//	        { "app": "sys.app", "settings": { "key": "value" } }
+//	    ],
+//	    "services": [
+//	        { "service": "grpc", "listenAddr": "0.0.0.0:5192", "tls": { "clientCAFile": "/etc/archost/ca.pem", "requireClientCert": true },
+//	          "logRequests": true, "bearerTokens": [ "s3cret" ], "maxSessionsPerPeer": 8, "maxMsgsPerSec": 1000, "reflection": true },
+//	        { "service": "ws",   "listenAddr": "0.0.0.0:5193", "allowedOrigins": [ "https://dash.example.com" ] },
+//	        { "service": "sock", "listenAddr": "~/_.archost/archost.sock" }
+//	    ]
+//	}
+//
+// Only the host's limits and idle timeouts can change while it runs (see Reload); other changes apply once restarted.
+type Config struct {
+	Host     HostConfig      `json:"host"`
+	Apps     []AppConfig     `json:"apps,omitempty"`     // Apps to start, in order (nil starts all available Apps)
+	Services []ServiceConfig `json:"services,omitempty"` // HostServices to start
+}
+
+// HostConfig specifies host.HostOpts, where an omitted field keeps its default.
+type HostConfig struct {
+	Label             string   `json:"label,omitempty"`
+	StatePath         string   `json:"statePath,omitempty"`
+	CachePath         string   `json:"cachePath,omitempty"`
+	MaxTxnMsgs        int      `json:"maxTxnMsgs,omitempty"`
+	MaxOpenTxns       int      `json:"maxOpenTxns,omitempty"`
+	MaxPinResolves    int      `json:"maxPinResolves,omitempty"`
+	MaxQueuedPins     int      `json:"maxQueuedPins,omitempty"`
+	MaxUserPlanets    int      `json:"maxUserPlanets,omitempty"`
+	SubQueueSize      int      `json:"subQueueSize,omitempty"`
+	SnapshotQueueSize int      `json:"snapshotQueueSize,omitempty"`
+	SubOverflow       string   `json:"subOverflow,omitempty"` // "coalesce", "dropSub", or "disconnect"
+	ResumeGrace       Duration `json:"resumeGrace,omitempty"`
+	CellIdleClose     Duration `json:"cellIdleClose,omitempty"`
+	PlanetIdleClose   Duration `json:"planetIdleClose,omitempty"`
+	ValueLogFileSize  int64    `json:"valueLogFileSize,omitempty"`
+	Admins            []string `json:"admins,omitempty"` // UserUIDs granted PlanetRole_Owner on the host's planet (e.g. to view sys/host)
+}
+
+// AppConfig selects an available App to start and the settings given to it (see arc.AppContext).
+type AppConfig struct {
+	App      string          `json:"app"` // AppURI or just its name (e.g. "vibe.app")
+	Settings arc.AppSettings `json:"settings,omitempty"`
+}
+
+// ServiceConfig selects a HostService to start.
+type ServiceConfig struct {
+	Service        string     `json:"service"`                 // "grpc", "ws", or "sock"
+	ListenNetwork  string     `json:"listenNetwork,omitempty"` // "sock" only: "unix" (default) or "tcp"
+	ListenAddr     string     `json:"listenAddr,omitempty"`
+	RecordPath     string     `json:"recordPath,omitempty"`     // if set, each session's msgs are recorded to a new file in this dir
+	AllowedOrigins []string   `json:"allowedOrigins,omitempty"` // "ws" only (see ws_service.WsServerOpts)
+	TLS            *TLSConfig `json:"tls,omitempty"`            // "grpc" only: if set, connections are secured via TLS
+
+	// "grpc" only (see grpc_service.Interceptor)
+	LogRequests        bool     `json:"logRequests,omitempty"`        // if set, each stream is logged as it opens and closes
+	BearerTokens       []string `json:"bearerTokens,omitempty"`       // if set, a session must present one of these tokens
+	MaxSessionsPerPeer int      `json:"maxSessionsPerPeer,omitempty"` // if set, max sessions each peer IP can have open at once
+	MaxMsgsPerSec      float64  `json:"maxMsgsPerSec,omitempty"`      // if set, max msgs per second each peer IP can send
+	Reflection         bool     `json:"reflection,omitempty"`         // if set, gRPC server reflection is enabled (e.g. for grpcurl)
+}
+
+// TLSConfig specifies grpc_service.TLSOpts, where if no certFile is given, a self-signed cert is generated in the "tls" dir of the host's StatePath.
+type TLSConfig struct {
+	CertFile          string `json:"certFile,omitempty"`
+	KeyFile           string `json:"keyFile,omitempty"`
+	ClientCAFile      string `json:"clientCAFile,omitempty"`      // if set, client certs are verified against these CAs
+	RequireClientCert bool   `json:"requireClientCert,omitempty"` // if set, clients must present a cert verified by clientCAFile
+}
+
+// Duration is a time.Duration that appears in a config file as a string such as "90s" or "2m".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(buf []byte) error {
+	var str string
+	if err := json.Unmarshal(buf, &str); err != nil {
+		return err
+	}
+	dur, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*d = Duration(dur)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+var subOverflowByName = map[string]host.SubOverflow{
+	"coalesce":   host.SubOverflow_Coalesce,
+	"dropSub":    host.SubOverflow_DropSub,
+	"disconnect": host.SubOverflow_Disconnect,
+}
+
+// DefaultConfig returns a config that starts all available Apps and no HostServices using the default host opts.
+func DefaultConfig() Config {
+	opts := host.DefaultHostOpts()
+	return Config{
+		Host: HostConfig{
+			Label:             opts.Label,
+			StatePath:         opts.StatePath,
+			CachePath:         opts.CachePath,
+			MaxTxnMsgs:        opts.MaxTxnMsgs,
+			MaxOpenTxns:       opts.MaxOpenTxns,
+			MaxPinResolves:    opts.MaxPinResolves,
+			MaxQueuedPins:     opts.MaxQueuedPins,
+			MaxUserPlanets:    opts.MaxUserPlanets,
+			SubQueueSize:      opts.SubQueueSize,
+			SnapshotQueueSize: opts.SnapshotQueueSize,
+			SubOverflow:       "coalesce",
+			ResumeGrace:       Duration(opts.ResumeGrace),
+			CellIdleClose:     Duration(opts.CellIdleClose),
+			PlanetIdleClose:   Duration(opts.PlanetIdleClose),
+			ValueLogFileSize:  opts.ValueLogFileSize,
+		},
+	}
+}
+
+// ReadFile reads the given JSON config file into this config, where fields it omits are left as they are.
+func (cfg *Config) ReadFile(pathname string) error {
+	buf, err := os.ReadFile(pathname)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(buf, cfg); err != nil {
+		return arc.ErrCode_BadValue.Errorf("invalid config file %q: %v", pathname, err)
+	}
+	return nil
+}
+
+// HostOpts returns the host opts this config specifies.
+func (cfg *Config) HostOpts() (host.HostOpts, error) {
+	hc := &cfg.Host
+	opts := host.HostOpts{
+		Label:             hc.Label,
+		StatePath:         hc.StatePath,
+		CachePath:         hc.CachePath,
+		MaxTxnMsgs:        hc.MaxTxnMsgs,
+		MaxOpenTxns:       hc.MaxOpenTxns,
+		MaxPinResolves:    hc.MaxPinResolves,
+		MaxQueuedPins:     hc.MaxQueuedPins,
+		MaxUserPlanets:    hc.MaxUserPlanets,
+		SubQueueSize:      hc.SubQueueSize,
+		SnapshotQueueSize: hc.SnapshotQueueSize,
+		ResumeGrace:       time.Duration(hc.ResumeGrace),
+		CellIdleClose:     time.Duration(hc.CellIdleClose),
+		PlanetIdleClose:   time.Duration(hc.PlanetIdleClose),
+		ValueLogFileSize:  hc.ValueLogFileSize,
+	}
+	if hc.SubOverflow != "" {
+		overflow, ok := subOverflowByName[hc.SubOverflow]
+		if !ok {
+			return opts, arc.ErrCode_BadValue.Errorf("unknown subOverflow %q", hc.SubOverflow)
+		}
+		opts.SubOverflow = overflow
+	}
+
+	apps, err := cfg.selectApps()
+	if err != nil {
+		return opts, err
+	}
+	for i, ac := range cfg.Apps {
+		if ac.Settings == nil {
+			continue
+		}
+		if opts.AppSettings == nil {
+			opts.AppSettings = make(map[string]arc.AppSettings)
+		}
+		opts.AppSettings[apps[i].appURI] = ac.Settings
+	}
+	return opts, nil
+}
+
+// selectApps returns the available Apps this config selects, in the order given.
+func (cfg *Config) selectApps() ([]availableApp, error) {
+	if cfg.Apps == nil {
+		return availableApps, nil
+	}
+
+	apps := make([]availableApp, 0, len(cfg.Apps))
+	for _, ac := range cfg.Apps {
+		app, found := findAvailableApp(ac.App)
+		if !found {
+			return nil, arc.ErrCode_AppNotFound.Errorf("app %q is not available", ac.App)
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+// findAvailableApp returns the available App with the given AppURI or name, where an App's name is the path component
+// before its version, e.g. "vibe.arc.tools/vibe.app/v1.2022.1" => "vibe.app"
+func findAvailableApp(appURI string) (availableApp, bool) {
+	for _, app := range availableApps {
+		if app.appURI == appURI || path.Base(path.Dir(app.appURI)) == strings.Trim(appURI, "/") {
+			return app, true
+		}
+	}
+	return availableApp{}, false
+}
+
+// StartHost starts a new host and the Apps this config selects.
+func (cfg *Config) StartHost() (arc.Host, error) {
+	opts, err := cfg.HostOpts()
+	if err != nil {
+		return nil, err
+	}
+	apps, err := cfg.selectApps()
+	if err != nil {
+		return nil, err
+	}
+	h, err := startHost(opts, apps)
+	if err != nil {
+		return nil, err
+	}
+
+	hostPlanetID := h.HostPlanet().PlanetID()
+	for _, userUID := range cfg.Host.Admins {
+		err = h.GrantPlanetRole(&arc.PlanetGrant{
+			PlanetID: hostPlanetID,
+			UserUID:  []byte(userUID),
+			Role:     arc.PlanetRole_Owner,
+		})
+		if err != nil {
+			h.Close()
+			<-h.Done()
+			return nil, arc.ErrCode_InternalErr.Errorf("failed to grant admin %q: %v", userUID, err)
+		}
+	}
+	return h, nil
+}
+
+// NewServices returns a new instance of each HostService this config selects, ready to be started.
+func (cfg *Config) NewServices() ([]arc.HostService, error) {
+	services := make([]arc.HostService, 0, len(cfg.Services))
+	for _, sc := range cfg.Services {
+		switch sc.Service {
+		case "grpc":
+			opts := grpc_service.DefaultGrpcServerOpts(int(arc.Const_DefaultGrpcServicePort))
+			if sc.ListenAddr != "" {
+				opts.ListenAddr = sc.ListenAddr
+			}
+			opts.RecordPath = sc.RecordPath
+			opts.EnableReflection = sc.Reflection
+			if tc := sc.TLS; tc != nil {
+				opts.TLS = &grpc_service.TLSOpts{
+					CertFile:          tc.CertFile,
+					KeyFile:           tc.KeyFile,
+					ClientCAFile:      tc.ClientCAFile,
+					RequireClientCert: tc.RequireClientCert,
+				}
+				if tc.CertFile == "" {
+					opts.TLS.SelfSignedPath = path.Join(cfg.Host.StatePath, "tls")
+				}
+			}
+			if sc.LogRequests {
+				opts.Interceptors = append(opts.Interceptors, grpc_service.LogRequests())
+			}
+			if len(sc.BearerTokens) > 0 {
+				opts.Interceptors = append(opts.Interceptors, grpc_service.RequireBearerToken(grpc_service.BearerTokens(sc.BearerTokens...)))
+			}
+			if sc.MaxSessionsPerPeer > 0 || sc.MaxMsgsPerSec > 0 {
+				opts.Interceptors = append(opts.Interceptors, grpc_service.LimitPeers(grpc_service.PeerLimits{
+					MaxSessions:   sc.MaxSessionsPerPeer,
+					MaxMsgsPerSec: sc.MaxMsgsPerSec,
+				}))
+			}
+			services = append(services, opts.NewGrpcServer())
+		case "ws":
+			opts := ws_service.DefaultWsServerOpts(int(arc.Const_DefaultWsServicePort))
+			if sc.ListenAddr != "" {
+				opts.ListenAddr = sc.ListenAddr
+			}
+			opts.RecordPath = sc.RecordPath
+			opts.AllowedOrigins = sc.AllowedOrigins
+			services = append(services, opts.NewWsServer())
+		case "sock":
+			opts := sock_service.DefaultSockServerOpts(path.Join(cfg.Host.StatePath, "archost.sock"))
+			if sc.ListenNetwork != "" {
+				opts.ListenNetwork = sc.ListenNetwork
+			}
+			if sc.ListenAddr != "" {
+				opts.ListenAddr = sc.ListenAddr
+			} else if opts.ListenNetwork != "unix" {
+				return nil, arc.ErrCode_BadValue.Errorf("sock service on %q requires listenAddr", opts.ListenNetwork)
+			}
+			opts.RecordPath = sc.RecordPath
+			services = append(services, opts.NewSockServer())
+		default:
+			return nil, arc.ErrCode_BadValue.Errorf("unknown service %q", sc.Service)
+		}
+	}
+	return services, nil
+}
+
+// Reload applies the host opts this config specifies that can change while the given host runs (see host.ReloadOpts).
+func (cfg *Config) Reload(h arc.Host) error {
+	opts, err := cfg.HostOpts()
+	if err != nil {
+		return err
+	}
+	return host.ReloadOpts(h, opts)
+}
@@ -0,0 +1,75 @@
+// Package client is a Go client of an arc.Host session, carried over any Transport (e.g. gRPC, lib_service, or a sock_service.Conn).
+//
+// A Client allocates ReqIDs, registers AttrSchemas built from Go structs (see Model) or given as is (see Attrs), and rebuilds the cell trees the host
+// pushes to each Pin, delivering each insert, change, checkpoint, and close to a PinHandler.
+package client
+
+import (
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// Transport carries Msgs between a Client and a host session.
+type Transport interface {
+
+	// SendMsg sends the given msg to the host, which the caller can reuse once this returns.
+	// Only one goroutine sends at a time.
+	SendMsg(msg *arc.Msg) error
+
+	// RecvMsg blocks until the next msg from the host arrives, returning arc.ErrStreamClosed once the stream closes.
+	// Only one goroutine receives at a time.
+	RecvMsg() (*arc.Msg, error)
+
+	// Close closes this transport, ending its host session.
+	Close() error
+}
+
+// Model is a Go struct bound to an arc.AttrSchema when registered via Client.Register.
+//
+// Each exported field tagged `arc:"{AttrURI}"` is bound to an attr of the schema, where AttrIDs are assigned in field order.
+// A field can be a string, []byte, bool, int or uint kind, or time.Time.
+//
+//	type Note struct {
+//		Title    string    `arc:"title.string"`
+//		Modified time.Time `arc:"modified.DateTime"`
+//	}
+//
+//	func (note *Note) AttrModelURI() string { return "notes/note" }
+//
+// If a Model also has an AppURI() method, it selects the App that handles the schema (see arc.AttrSchema.AppURI).
+type Model interface {
+	AttrModelURI() string
+}
+
+// PinReq specifies a cell to pin via Client.Pin.
+type PinReq struct {
+	PinURI   string  // see arc.PinReq.PinURI
+	PinCell  uint64  // see arc.PinReq.PinCell
+	PlanetID uint64  // see arc.PinReq.PlanetID
+	Parent   *Pin    // if set, the pin that pushed PinCell
+	Content  Model   // registered Model the pinned cell is pushed as
+	Children []Model // registered Models of the child cells to push (if any)
+}
+
+// PinHandler receives a Pin's changes, called on the Client's receiving goroutine in the order pushed by the host.
+// Since no other msgs are received meanwhile, a callback should not block on a Client call awaiting the host (e.g. Commit).
+// Any callback can be nil.
+type PinHandler struct {
+
+	// OnInsert is called for each cell first pushed since the previous checkpoint, with its attrs set.
+	OnInsert func(cell *Cell)
+
+	// OnChange is called for each previously pushed cell that has attrs pushed since the previous checkpoint.
+	OnChange func(cell *Cell)
+
+	// OnCheckpoint is called once the host has pushed a consistent state, including any error the host pushed it with.
+	OnCheckpoint func(pin *Pin, err error)
+
+	// OnClose is called once the pin is closed, including the error it was closed with (or nil if closed via Pin.Close).
+	OnClose func(pin *Pin, err error)
+}
+
+// CellValue is a cell to commit via Client.Commit.
+type CellValue struct {
+	CellID uint64
+	Value  Model // registered Model whose attrs are committed
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// Attrs is a Model whose attrs are given by an AttrSchema registered via Client.RegisterSchemas (rather than by a Go struct).
+//
+// Vals holds the value of each attr by AttrURI, which is a string, []byte, int64, or time.Time (as given by the ValType pushed).
+type Attrs struct {
+	Schema *arc.AttrSchema
+	Vals   map[string]interface{}
+}
+
+func (attrs *Attrs) AttrModelURI() string {
+	return attrs.Schema.AttrModelURI
+}
+
+// setVal sets the value of the given PushAttr msg from an Attrs value.
+func setVal(msg *arc.Msg, val interface{}) {
+	switch v := val.(type) {
+	case []byte:
+		msg.SetValBuf(arc.ValType_bytes, len(v))
+		copy(msg.ValBuf, v)
+	case int:
+		msg.SetValInt(arc.ValType_int, int64(v))
+	case bool:
+		var i int64
+		if v {
+			i = 1
+		}
+		msg.SetValInt(arc.ValType_int, i)
+	default:
+		msg.SetVal(val)
+	}
+}
+
+// loadVal returns the value of the given PushAttr msg as an Attrs value.
+func loadVal(msg *arc.Msg) interface{} {
+	switch arc.ValType(msg.ValType) {
+	case arc.ValType_string, arc.ValType_AssetURI, arc.ValType_URL:
+		return string(msg.ValBuf)
+	case arc.ValType_DateTime:
+		return arc.TimeFS(msg.ValInt).Time()
+	case arc.ValType_bytes:
+		return append([]byte(nil), msg.ValBuf...)
+	}
+	if len(msg.ValBuf) > 0 {
+		return append([]byte(nil), msg.ValBuf...)
+	}
+	return msg.ValInt
+}
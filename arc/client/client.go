@@ -0,0 +1,465 @@
+package client
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/ski"
+	_ "github.com/arcspace/go-arcspace/ski/ed25519" // login signing kits
+	_ "github.com/arcspace/go-arcspace/ski/nacl"
+)
+
+// Client is a session with an arc.Host over a Transport.
+// Its methods can be called from any goroutine.
+type Client struct {
+	lastReqID uint64 // atomic
+	tr        Transport
+	sendMu    sync.Mutex // serializes sends so a request's msgs aren't interleaved with others
+
+	reqsMu sync.Mutex
+	reqs   map[uint64]openReq // open requests by ReqID
+
+	schemasMu    sync.RWMutex
+	schemas      map[reflect.Type]*modelSchema // registered schemas by Model type
+	schemasByID  map[int32]*modelSchema        // registered schemas by SchemaID
+	lastSchemaID int32
+
+	resumeToken []byte // issued by the host at login
+	done        chan struct{}
+	err         error // why the stream closed (set before done is closed)
+}
+
+// openReq receives the msgs the host sends for a ReqID.
+// Both methods are called from the Client's receiving goroutine.
+type openReq interface {
+
+	// handleMsg handles the given msg from the host (which isn't accessed once this returns).
+	handleMsg(msg *arc.Msg)
+
+	// closed is called once the request is closed by the host or the stream closes.
+	closed(err error)
+}
+
+// New starts a Client over the given Transport, which the Client closes when it's closed.
+func New(tr Transport) *Client {
+	c := &Client{
+		tr:          tr,
+		reqs:        make(map[uint64]openReq),
+		schemas:     make(map[reflect.Type]*modelSchema),
+		schemasByID: make(map[int32]*modelSchema),
+		done:        make(chan struct{}),
+	}
+	go c.recvMsgs()
+	return c
+}
+
+// Close closes this Client's Transport, closing each open request and Pin.
+func (c *Client) Close() error {
+	err := c.tr.Close()
+	<-c.done
+	return err
+}
+
+// Done returns a channel that is closed once this Client's stream has closed.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why this Client's stream closed (valid once Done is closed).
+func (c *Client) Err() error {
+	return c.err
+}
+
+// ResumeToken returns the token issued by the host at login, allowing the session to be resumed over a new Transport (see Resume).
+func (c *Client) ResumeToken() []byte {
+	c.reqsMu.Lock()
+	defer c.reqsMu.Unlock()
+	return c.resumeToken
+}
+
+// recvMsgs dispatches each msg from the host to its open request until the stream closes.
+func (c *Client) recvMsgs() {
+	var err error
+	for {
+		var msg *arc.Msg
+		msg, err = c.tr.RecvMsg()
+		if err != nil {
+			break
+		}
+
+		c.reqsMu.Lock()
+		req := c.reqs[msg.ReqID]
+		if msg.Op == arc.MsgOp_CloseReq {
+			delete(c.reqs, msg.ReqID)
+		}
+		c.reqsMu.Unlock()
+
+		if req != nil {
+			if msg.Op == arc.MsgOp_CloseReq {
+				req.closed(closeErr(msg))
+			} else {
+				req.handleMsg(msg)
+			}
+		}
+		msg.Reclaim()
+	}
+
+	c.reqsMu.Lock()
+	reqs := c.reqs
+	c.reqs = nil
+	c.reqsMu.Unlock()
+
+	for _, req := range reqs {
+		req.closed(err)
+	}
+	c.err = err
+	close(c.done)
+}
+
+// closeErr returns the error carried by the given CloseReq or checkpoint msg (or nil if none).
+func closeErr(msg *arc.Msg) error {
+	if msg.ValType != int32(arc.ValType_Err) {
+		return nil
+	}
+	err := &arc.Err{}
+	if loadErr := err.Unmarshal(msg.ValBuf); loadErr != nil {
+		return arc.ErrCode_BadValue.Wrap(loadErr)
+	}
+	return err
+}
+
+// addReq allocates a new ReqID and adds the given request under it.
+func (c *Client) addReq(req openReq) (uint64, error) {
+	reqID := atomic.AddUint64(&c.lastReqID, 1)
+
+	c.reqsMu.Lock()
+	defer c.reqsMu.Unlock()
+	if c.reqs == nil {
+		return 0, c.closedErr()
+	}
+	c.reqs[reqID] = req
+	return reqID, nil
+}
+
+// removeReq removes the given request without it being closed.
+func (c *Client) removeReq(reqID uint64) {
+	c.reqsMu.Lock()
+	delete(c.reqs, reqID)
+	c.reqsMu.Unlock()
+}
+
+func (c *Client) closedErr() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+	}
+	return arc.ErrStreamClosed
+}
+
+// send sends the given msgs under the given ReqID, reclaiming them once sent.
+func (c *Client) send(reqID uint64, msgs ...*arc.Msg) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	var err error
+	for _, msg := range msgs {
+		msg.ReqID = reqID
+		if err == nil {
+			err = c.tr.SendMsg(msg)
+		}
+		msg.Reclaim()
+	}
+	return err
+}
+
+// newMsg returns a new msg with the given op and value.
+func newMsg(op arc.MsgOp, val interface{}) *arc.Msg {
+	msg := arc.NewMsg()
+	msg.Op = op
+	switch v := val.(type) {
+	case *arc.PinReq:
+		msg.SetValBuf(arc.ValType_PinReq, v.Size())
+		if _, err := v.MarshalToSizedBuffer(msg.ValBuf); err != nil {
+			panic(err)
+		}
+	case nil:
+	default:
+		msg.SetVal(val)
+	}
+	return msg
+}
+
+// call is a request whose replies are received by the caller that opened it.
+type call struct {
+	replies chan *arc.Msg // closed once the request is closed
+	err     error         // the error the request was closed with (set before replies is closed)
+}
+
+func newCall() *call {
+	return &call{
+		replies: make(chan *arc.Msg, 8),
+	}
+}
+
+func (req *call) handleMsg(msg *arc.Msg) {
+	req.replies <- arc.CopyMsg(msg)
+}
+
+func (req *call) closed(err error) {
+	req.err = err
+	close(req.replies)
+}
+
+// do sends a new request having the given op and value, returning the ReqID and call receiving its replies.
+func (c *Client) do(op arc.MsgOp, val interface{}) (uint64, *call, error) {
+	req := newCall()
+	reqID, err := c.addReq(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err = c.send(reqID, newMsg(op, val)); err != nil {
+		c.removeReq(reqID)
+		return 0, nil, err
+	}
+	return reqID, req, nil
+}
+
+// await discards the given call's replies until it's closed, returning the error it was closed with.
+func (req *call) await() error {
+	for msg := range req.replies {
+		msg.Reclaim()
+	}
+	return req.err
+}
+
+// Login logs in as the given user, signing the host's login challenge with the given signing key.
+// The user's first login registers key's public key, after which each login must be signed by the same key.
+func (c *Client) Login(userUID []byte, key *ski.KeyEntry) error {
+	if key == nil || key.KeyInfo == nil {
+		return arc.ErrCode_InvalidLogin.Error("missing signing key")
+	}
+	var kitID arc.CryptoKitID
+	switch key.KeyInfo.CryptoKitID {
+	case ski.CryptoKitID_NaCl:
+		kitID = arc.CryptoKit_Signing_NaCl
+	case ski.CryptoKitID_ED25519:
+		kitID = arc.CryptoKit_Signing_ED25519
+	default:
+		return arc.ErrCode_InvalidLogin.Errorf("unsupported CryptoKitID %v", key.KeyInfo.CryptoKitID)
+	}
+	kit, err := ski.GetCryptoKit(key.KeyInfo.CryptoKitID)
+	if err != nil {
+		return arc.ErrCode_InvalidLogin.Wrap(err)
+	}
+
+	reqID, req, err := c.do(arc.MsgOp_Login, &arc.LoginReq{
+		UserUID: userUID,
+		PubKey: &arc.CryptoKey{
+			CryptoKitID: kitID,
+			KeyBytes:    key.KeyInfo.PubKey,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	signed := false
+	for msg := range req.replies {
+		var reply arc.LoginChallenge
+		if msg.Op == arc.MsgOp_Login && msg.LoadVal(&reply) == nil {
+			switch {
+			case len(reply.ResumeToken) > 0:
+				c.reqsMu.Lock()
+				c.resumeToken = reply.ResumeToken
+				c.reqsMu.Unlock()
+			case !signed && len(reply.Nonce) > 0:
+				signed = true
+				var sig []byte
+				sig, err = kit.Sign(reply.Nonce, key.PrivKey)
+				if err == nil {
+					err = c.send(reqID, newMsg(arc.MsgOp_Login, &arc.LoginChallenge{
+						Nonce:     reply.Nonce,
+						Signature: sig,
+					}))
+				}
+			}
+		}
+		msg.Reclaim()
+		if err != nil {
+			c.removeReq(reqID)
+			return err
+		}
+	}
+	return req.err
+}
+
+// Resume resumes the logged in session that was issued the given token (see ResumeToken).
+// Once resumed, the session's open requests and registered schemas are those of the resumed session.
+func (c *Client) Resume(token []byte) error {
+	_, req, err := c.do(arc.MsgOp_Login, &arc.LoginReq{
+		ResumeToken: token,
+	})
+	if err != nil {
+		return err
+	}
+	if err = req.await(); err != nil {
+		return err
+	}
+	c.reqsMu.Lock()
+	c.resumeToken = token
+	c.reqsMu.Unlock()
+	return nil
+}
+
+// Register registers an AttrSchema for each given Model that isn't already registered (see Model).
+func (c *Client) Register(models ...Model) error {
+	c.schemasMu.Lock()
+	var added []*modelSchema
+	for _, model := range models {
+		typ, err := modelType(model)
+		if err != nil {
+			c.schemasMu.Unlock()
+			return err
+		}
+		if c.schemas[typ] != nil {
+			continue
+		}
+		ms, err := newModelSchema(model, c.lastSchemaID+int32(len(added))+1)
+		if err != nil {
+			c.schemasMu.Unlock()
+			return err
+		}
+		added = append(added, ms)
+	}
+	c.lastSchemaID += int32(len(added))
+	c.schemasMu.Unlock()
+
+	return c.register(added)
+}
+
+// RegisterSchemas registers the given AttrSchemas, whose cells are pushed and committed as Attrs.
+// Each schema is assigned a SchemaID (replacing any it has), and each attr not having an AttrID is assigned its position (from 1).
+func (c *Client) RegisterSchemas(schemas ...*arc.AttrSchema) error {
+	c.schemasMu.Lock()
+	added := make([]*modelSchema, len(schemas))
+	for i, schema := range schemas {
+		c.lastSchemaID++
+		schema.SchemaID = c.lastSchemaID
+		for j, attr := range schema.Attrs {
+			if attr.AttrID == 0 {
+				attr.AttrID = int32(j + 1)
+			}
+		}
+		added[i] = &modelSchema{
+			AttrSchema: schema,
+		}
+	}
+	c.schemasMu.Unlock()
+
+	return c.register(added)
+}
+
+// register registers the given schemas with the host, after which they can be used.
+func (c *Client) register(added []*modelSchema) error {
+	if len(added) == 0 {
+		return nil
+	}
+	defs := &arc.Defs{}
+	for _, ms := range added {
+		defs.Schemas = append(defs.Schemas, ms.AttrSchema)
+	}
+	_, req, err := c.do(arc.MsgOp_ResolveAndRegister, defs)
+	if err == nil {
+		err = req.await()
+	}
+	if err != nil {
+		return err
+	}
+
+	c.schemasMu.Lock()
+	for _, ms := range added {
+		if ms.typ != nil {
+			c.schemas[ms.typ] = ms
+		}
+		c.schemasByID[ms.SchemaID] = ms
+	}
+	c.schemasMu.Unlock()
+	return nil
+}
+
+// schemaFor returns the schema registered for the given Model.
+func (c *Client) schemaFor(model Model) (*modelSchema, error) {
+	if attrs, ok := model.(*Attrs); ok {
+		if attrs.Schema != nil {
+			if ms := c.schemaByID(attrs.Schema.SchemaID); ms != nil && ms.AttrSchema == attrs.Schema {
+				return ms, nil
+			}
+		}
+		return nil, arc.ErrCode_TypeNotRegistered.Error("Attrs schema not registered")
+	}
+	typ, err := modelType(model)
+	if err != nil {
+		return nil, err
+	}
+	c.schemasMu.RLock()
+	ms := c.schemas[typ]
+	c.schemasMu.RUnlock()
+	if ms == nil {
+		return nil, arc.ErrCode_TypeNotRegistered.Errorf("Model %v not registered", typ)
+	}
+	return ms, nil
+}
+
+// schemaByID returns the schema registered with the given SchemaID (or nil if none).
+func (c *Client) schemaByID(schemaID int32) *modelSchema {
+	c.schemasMu.RLock()
+	defer c.schemasMu.RUnlock()
+	return c.schemasByID[schemaID]
+}
+
+// Commit commits the given cells to the given planet (or 0 for the logged in user's home planet) as a single txn.
+func (c *Client) Commit(planetID uint64, cells ...CellValue) error {
+	var msgs []*arc.Msg
+	for _, cell := range cells {
+		ms, err := c.schemaFor(cell.Value)
+		if err != nil {
+			for _, msg := range msgs {
+				msg.Reclaim()
+			}
+			return err
+		}
+		msgs = ms.appendMsgs(msgs, cell.CellID, cell.Value)
+	}
+
+	var txn interface{}
+	if planetID != 0 {
+		txn = &arc.Txn{PlanetID: planetID}
+	}
+	msgs = append(msgs, newMsg(arc.MsgOp_Commit, txn))
+
+	req := newCall()
+	reqID, err := c.addReq(req)
+	if err != nil {
+		for _, msg := range msgs {
+			msg.Reclaim()
+		}
+		return err
+	}
+	defer c.removeReq(reqID)
+	if err = c.send(reqID, msgs...); err != nil {
+		return err
+	}
+
+	// The host replies with a Commit once committed (or closes the request if the txn fails)
+	for msg := range req.replies {
+		committed := msg.Op == arc.MsgOp_Commit
+		msg.Reclaim()
+		if committed {
+			return nil
+		}
+	}
+	return req.err
+}
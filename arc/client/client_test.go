@@ -0,0 +1,319 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/host/hosttest"
+	"github.com/arcspace/go-arcspace/ski"
+)
+
+type testDir struct {
+	Name string `arc:"name.string"`
+}
+
+func (dir *testDir) AttrModelURI() string { return "test/dir" }
+
+type testItem struct {
+	Name     string    `arc:"name.string"`
+	Size     int64     `arc:"size.int"`
+	Hidden   bool      `arc:"hidden.int"`
+	Modified time.Time `arc:"modified.DateTime"`
+}
+
+func (item *testItem) AttrModelURI() string { return "test/item" }
+
+type testNote struct {
+	Title string `arc:"title.string"`
+	Body  []byte `arc:"body.bytes"`
+}
+
+func (note *testNote) AttrModelURI() string { return "test/note" }
+
+const dirCellID = 1<<40 + 100
+
+var modified = time.Unix(1700000000, 0)
+
+// dirApp pushes a dir cell with two items, and then publishes a change to the first item and a third item.
+type dirApp struct{}
+
+func (app dirApp) AppURI() string {
+	return "test.arc.tools/dir.app/v1.0.0"
+}
+
+func (app dirApp) AttrModelURIs() []string {
+	return []string{"test/dir"}
+}
+
+func (app dirApp) StartApp(ctx arc.AppContext) error {
+	return nil
+}
+
+func (app dirApp) StopApp() {
+}
+
+func (app dirApp) ResolveRequest(req *arc.CellReq) error {
+	req.PinCell = dirCellID
+	req.PinnedCell = app
+	return nil
+}
+
+func (app dirApp) PushCellState(req *arc.CellReq) error {
+	req.PushInsertCell(req.PinCell, req.ContentSchema)
+	req.PushAttr(req.PinCell, req.ContentSchema, "name.string", req.PinURI)
+
+	item := req.GetChildSchema("test/item")
+	if item == nil {
+		return nil
+	}
+	for i, name := range []string{"a", "b"} {
+		cellID := req.PinCell + arc.CellID(i+1)
+		req.PushInsertCell(cellID, item)
+		req.PushAttr(cellID, item, "name.string", name)
+		req.PushAttr(cellID, item, "size.int", int64(i+1))
+		req.PushAttr(cellID, item, "hidden.int", int64(i))
+		req.PushAttr(cellID, item, "modified.DateTime", modified)
+	}
+
+	batch := arc.NewMsgBatch()
+	appendAttr(batch, req.PinCell+1, item, "size.int", int64(10))
+	appendAttr(batch, req.PinCell+3, item, "name.string", "c")
+	return req.PublishUpdate(batch)
+}
+
+// appendAttr appends an InsertCell msg for the given cell followed by a PushAttr msg setting the given attr.
+func appendAttr(batch *arc.MsgBatch, cellID arc.CellID, schema *arc.AttrSchema, attrURI string, val interface{}) {
+	insert := batch.AddMsg()
+	insert.Op = arc.MsgOp_InsertCell
+	insert.CellID = cellID.U64()
+	insert.SetValInt(arc.ValType_SchemaID, int64(schema.SchemaID))
+
+	attr := batch.AddMsg()
+	attr.Op = arc.MsgOp_PushAttr
+	attr.CellID = cellID.U64()
+	attr.AttrID = schema.LookupAttr(attrURI).AttrID
+	attr.SetVal(val)
+}
+
+func startTestClient(t *testing.T) *Client {
+	h := hosttest.StartTestHost(t)
+	if err := h.RegisterApp(dirApp{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tr, err := Loopback(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(tr)
+	t.Cleanup(func() {
+		c.Close()
+	})
+
+	kit, err := ski.GetCryptoKit(ski.CryptoKitID_ED25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed := sha256.Sum256([]byte("alice"))
+	key := &ski.KeyEntry{
+		KeyInfo: &ski.KeyInfo{
+			KeyType:     ski.KeyType_SigningKey,
+			CryptoKitID: ski.CryptoKitID_ED25519,
+		},
+	}
+	if err = kit.GenerateNewKey(32, bytes.NewReader(seed[:]), key); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Login([]byte("alice"), key); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.ResumeToken()) == 0 {
+		t.Fatal("expected a resume token")
+	}
+	return c
+}
+
+func TestPinTree(t *testing.T) {
+	c := startTestClient(t)
+	if err := c.Register(&testDir{}, &testItem{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var inserted, changed []*Cell
+	checkpoints := make(chan error, 4)
+	closed := make(chan error, 1)
+	pin, err := c.Pin(PinReq{
+		PinURI:   "home",
+		Content:  &testDir{},
+		Children: []Model{&testItem{}},
+	}, PinHandler{
+		OnInsert: func(cell *Cell) {
+			inserted = append(inserted, cell)
+		},
+		OnChange: func(cell *Cell) {
+			changed = append(changed, cell)
+		},
+		OnCheckpoint: func(pin *Pin, err error) {
+			checkpoints <- err
+		},
+		OnClose: func(pin *Pin, err error) {
+			closed <- err
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The published update follows the pinned cell's initial state
+	for i := 0; i < 2; i++ {
+		select {
+		case err = <-checkpoints:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for checkpoint")
+		}
+	}
+	if err = pin.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err = <-closed:
+		if err != nil {
+			t.Fatalf("expected pin to close without error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for pin to close")
+	}
+
+	// The pinned cell's tree is rebuilt as pushed
+	root := pin.Cell
+	if root == nil || root.CellID != dirCellID || root.Value.(*testDir).Name != "home" {
+		t.Fatalf("unexpected pinned cell %+v", root)
+	}
+	if len(root.Children) != 3 {
+		t.Fatalf("expected 3 children, got %d", len(root.Children))
+	}
+	for i, name := range []string{"a", "b", "c"} {
+		child := root.Children[i]
+		item := child.Value.(*testItem)
+		if child.Parent != root || item.Name != name {
+			t.Fatalf("unexpected child %d: %+v", i, item)
+		}
+	}
+	a, b := root.Children[0].Value.(*testItem), root.Children[1].Value.(*testItem)
+	if a.Size != 10 || a.Hidden || !a.Modified.Equal(modified) {
+		t.Fatalf("unexpected item a: %+v", a)
+	}
+	if b.Size != 2 || !b.Hidden || !b.Modified.Equal(modified) {
+		t.Fatalf("unexpected item b: %+v", b)
+	}
+	if len(inserted) != 4 || inserted[0] != root || inserted[3] != root.Children[2] {
+		t.Fatalf("unexpected inserts %v", inserted)
+	}
+	if len(changed) != 1 || changed[0] != root.Children[0] {
+		t.Fatalf("unexpected changes %v", changed)
+	}
+}
+
+func TestCommitAndPin(t *testing.T) {
+	c := startTestClient(t)
+
+	// Models must be registered before they're used
+	const cellID = 1<<40 + 1
+	note := &testNote{Title: "hello", Body: []byte("world")}
+	if err := c.Commit(0, CellValue{CellID: cellID, Value: note}); err == nil {
+		t.Fatal("expected unregistered Model to fail")
+	}
+	if err := c.Register(note); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Commit(0, CellValue{CellID: cellID, Value: note}); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := make(chan *testNote, 1)
+	pin, err := c.Pin(PinReq{
+		PinCell: cellID,
+		Content: &testNote{},
+	}, PinHandler{
+		OnChange: func(cell *Cell) {
+			changes <- cell.Value.(*testNote)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pin.Cell.Value.(*testNote); got.Title != "hello" || string(got.Body) != "world" {
+		t.Fatalf("unexpected note %+v", got)
+	}
+
+	// Commits to a pinned cell are pushed to it
+	if err = c.Commit(0, CellValue{CellID: cellID, Value: &testNote{Title: "bye"}}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-changes:
+		if got.Title != "bye" {
+			t.Fatalf("unexpected note %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for change")
+	}
+
+	// Closing the client closes its pins
+	c.Close()
+	select {
+	case <-pin.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected pin to close")
+	}
+	if pin.Err() == nil {
+		t.Fatal("expected pin to close with an error")
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	c := startTestClient(t)
+
+	// A schema given as is (e.g. read from a file) is pushed and committed as Attrs
+	schema := &arc.AttrSchema{
+		AttrModelURI: "test/note",
+		SchemaName:   "note",
+		Attrs: []*arc.AttrSpec{
+			{AttrURI: "title.string"},
+			{AttrURI: "size.int"},
+		},
+	}
+	if err := c.RegisterSchemas(schema); err != nil {
+		t.Fatal(err)
+	}
+	if schema.SchemaID == 0 || schema.Attrs[1].AttrID != 2 {
+		t.Fatalf("expected IDs to be assigned, got %+v", schema)
+	}
+
+	const cellID = 1<<40 + 1
+	note := &Attrs{
+		Schema: schema,
+		Vals:   map[string]interface{}{"title.string": "hello", "size.int": int64(5)},
+	}
+	if err := c.Commit(0, CellValue{CellID: cellID, Value: note}); err != nil {
+		t.Fatal(err)
+	}
+	pin, err := c.Pin(PinReq{
+		PinCell: cellID,
+		Content: &Attrs{Schema: schema},
+	}, PinHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pin.Close()
+	got := pin.Cell.Value.(*Attrs).Vals
+	if got["title.string"] != "hello" || got["size.int"] != int64(5) {
+		t.Fatalf("unexpected attrs %v", got)
+	}
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// Cell is a cell pushed to a Pin, holding the latest values of its attrs.
+type Cell struct {
+	CellID   uint64
+	Value    Model   // pointer to a struct of the Model the cell was pushed as
+	Parent   *Cell   // the pinned cell this cell is a child of (or the parent Pin's cell for a pinned cell, if known)
+	Children []*Cell // child cells in the order pushed (pinned cell only)
+
+	schema   *modelSchema // the schema the cell was pushed with
+	inserted bool         // set once this cell has been passed to OnInsert
+	changed  bool         // set when an attr is pushed to this cell since the last checkpoint
+}
+
+// Pin is an open PinCell request, rebuilding the cell tree the host pushes to it.
+//
+// A Pin's cells are only accessed from the Client's receiving goroutine, so they should only be accessed from within its
+// PinHandler callbacks or once the Pin is done.
+type Pin struct {
+	ReqID  uint64
+	Cell   *Cell // the pinned cell (nil until it's pushed)
+	Parent *Pin  // see PinReq.Parent
+
+	client   *Client
+	handler  PinHandler
+	cellID   uint64           // CellID of the pinned cell
+	cells    map[uint64]*Cell // cells pushed to this pin by CellID
+	cur      *Cell            // cell of the most recent InsertCell msg
+	pending  []*Cell          // cells inserted or changed since the last checkpoint
+	synced   chan struct{}    // closed at the first checkpoint (or when closed before then)
+	isSynced bool
+	syncErr  error // the error of the first checkpoint (set before synced is closed)
+	done     chan struct{}
+	err      error // the error this pin closed with (set before done is closed)
+}
+
+// Pin pins the cell specified by the given PinReq, blocking until the host has pushed its state (i.e. its first checkpoint).
+// The given handler receives the cells pushed initially and as they change until the returned Pin is closed.
+func (c *Client) Pin(req PinReq, handler PinHandler) (*Pin, error) {
+	content, err := c.schemaFor(req.Content)
+	if err != nil {
+		return nil, err
+	}
+	pinReq := &arc.PinReq{
+		PinURI:        req.PinURI,
+		PinCell:       req.PinCell,
+		PlanetID:      req.PlanetID,
+		ContentSchema: content.SchemaID,
+	}
+	for _, child := range req.Children {
+		ms, err := c.schemaFor(child)
+		if err != nil {
+			return nil, err
+		}
+		pinReq.ChildSchemas = append(pinReq.ChildSchemas, ms.SchemaID)
+	}
+	if req.Parent != nil {
+		pinReq.ParentReqID = req.Parent.ReqID
+	}
+
+	pin := &Pin{
+		Parent:  req.Parent,
+		client:  c,
+		handler: handler,
+		cellID:  req.PinCell,
+		cells:   make(map[uint64]*Cell),
+		synced:  make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	pin.ReqID, err = c.addReq(pin)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.send(pin.ReqID, newMsg(arc.MsgOp_PinCell, pinReq)); err != nil {
+		c.removeReq(pin.ReqID)
+		return nil, err
+	}
+
+	<-pin.synced
+	if pin.syncErr != nil {
+		pin.Close()
+		return nil, pin.syncErr
+	}
+	return pin, nil
+}
+
+// Close closes this pin, after which OnClose is called once the host has closed it.
+func (pin *Pin) Close() error {
+	select {
+	case <-pin.done:
+		return nil
+	default:
+	}
+	return pin.client.send(pin.ReqID, newMsg(arc.MsgOp_CloseReq, nil))
+}
+
+// Done returns a channel that is closed once this pin has closed.
+func (pin *Pin) Done() <-chan struct{} {
+	return pin.done
+}
+
+// Err returns the error this pin closed with (valid once Done is closed).
+func (pin *Pin) Err() error {
+	return pin.err
+}
+
+func (pin *Pin) handleMsg(msg *arc.Msg) {
+	switch msg.Op {
+
+	case arc.MsgOp_PinCell:
+		pin.cellID = msg.CellID
+
+	case arc.MsgOp_InsertCell:
+		cell := pin.cells[msg.CellID]
+		if cell == nil {
+			ms := pin.client.schemaByID(int32(msg.ValInt))
+			if ms == nil {
+				pin.cur = nil
+				return
+			}
+			cell = &Cell{
+				CellID: msg.CellID,
+				Value:  ms.newValue(),
+				schema: ms,
+			}
+			pin.cells[msg.CellID] = cell
+			pin.touch(cell)
+		}
+		pin.cur = cell
+
+	case arc.MsgOp_PushAttr:
+		cell := pin.cur
+		if msg.CellID != 0 {
+			cell = pin.cells[msg.CellID]
+		}
+		if cell == nil {
+			return
+		}
+		if cell.schema.loadAttr(cell.Value, msg) {
+			pin.touch(cell)
+		}
+
+	case arc.MsgOp_Commit:
+		pin.checkpoint(closeErr(msg))
+	}
+}
+
+// touch notes the given cell as having been pushed since the last checkpoint.
+func (pin *Pin) touch(cell *Cell) {
+	if !cell.changed {
+		cell.changed = true
+		pin.pending = append(pin.pending, cell)
+	}
+}
+
+// checkpoint links new cells into this pin's cell tree and then passes what has been pushed since the last checkpoint to the handler.
+func (pin *Pin) checkpoint(err error) {
+	if pin.Cell == nil {
+		if pin.Cell = pin.cells[pin.cellID]; pin.Cell != nil && pin.Parent != nil {
+			pin.Cell.Parent = pin.Parent.cells[pin.cellID]
+		}
+	}
+
+	pending := pin.pending
+	pin.pending = nil
+	for _, cell := range pending {
+		if !cell.inserted && cell != pin.Cell && pin.Cell != nil {
+			cell.Parent = pin.Cell
+			pin.Cell.Children = append(pin.Cell.Children, cell)
+		}
+	}
+	for _, cell := range pending {
+		cell.changed = false
+		if !cell.inserted {
+			cell.inserted = true
+			if pin.handler.OnInsert != nil {
+				pin.handler.OnInsert(cell)
+			}
+		} else if pin.handler.OnChange != nil {
+			pin.handler.OnChange(cell)
+		}
+	}
+	if pin.handler.OnCheckpoint != nil {
+		pin.handler.OnCheckpoint(pin, err)
+	}
+
+	if !pin.isSynced {
+		pin.isSynced = true
+		pin.syncErr = err
+		close(pin.synced)
+	}
+}
+
+func (pin *Pin) closed(err error) {
+	if !pin.isSynced {
+		pin.isSynced = true
+		pin.syncErr = err
+		if pin.syncErr == nil {
+			pin.syncErr = arc.ErrCode_ReqCanceled.Error("pin closed before its first checkpoint")
+		}
+		close(pin.synced)
+	}
+	pin.err = err
+	if pin.handler.OnClose != nil {
+		pin.handler.OnClose(pin, err)
+	}
+	close(pin.done)
+}
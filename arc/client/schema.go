@@ -0,0 +1,195 @@
+package client
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// modelSchema binds a Model's struct type to the AttrSchema registered for it.
+type modelSchema struct {
+	*arc.AttrSchema
+	typ    reflect.Type // the Model's struct type (or nil for a schema registered via RegisterSchemas)
+	fields []int        // field index bound to each attr (by AttrID-1)
+}
+
+// modelType returns the struct type of the given Model.
+func modelType(model Model) (reflect.Type, error) {
+	if model == nil {
+		return nil, arc.ErrCode_BadSchema.Error("nil Model")
+	}
+	typ := reflect.TypeOf(model)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, arc.ErrCode_BadSchema.Errorf("Model %v is not a struct", typ)
+	}
+	return typ, nil
+}
+
+// newModelSchema builds an AttrSchema from the tagged fields of the given Model.
+func newModelSchema(model Model, schemaID int32) (*modelSchema, error) {
+	typ, err := modelType(model)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &modelSchema{
+		AttrSchema: &arc.AttrSchema{
+			AttrModelURI: model.AttrModelURI(),
+			SchemaName:   typ.Name(),
+			SchemaID:     schemaID,
+		},
+		typ: typ,
+	}
+	if app, ok := model.(interface{ AppURI() string }); ok {
+		ms.AppURI = app.AppURI()
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		attrURI := field.Tag.Get("arc")
+		if attrURI == "" || attrURI == "-" {
+			continue
+		}
+		if !field.IsExported() || !isAttrType(field.Type) {
+			return nil, arc.ErrCode_BadSchema.Errorf("%v.%v: unsupported attr field", typ.Name(), field.Name)
+		}
+		ms.fields = append(ms.fields, i)
+		ms.Attrs = append(ms.Attrs, &arc.AttrSpec{
+			AttrURI:    attrURI,
+			AttrID:     int32(len(ms.fields)),
+			SeriesType: arc.SeriesType_Fixed,
+		})
+	}
+	if len(ms.Attrs) == 0 {
+		return nil, arc.ErrCode_BadSchema.Errorf("Model %v has no fields tagged `arc:\"{AttrURI}\"`", typ.Name())
+	}
+	return ms, nil
+}
+
+func isAttrType(typ reflect.Type) bool {
+	if typ == timeType {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Slice:
+		return typ.Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+// newValue returns a pointer to a new zero value of this schema's Model.
+func (ms *modelSchema) newValue() Model {
+	if ms.typ == nil {
+		return &Attrs{
+			Schema: ms.AttrSchema,
+			Vals:   make(map[string]interface{}),
+		}
+	}
+	return reflect.New(ms.typ).Interface().(Model)
+}
+
+// appendMsgs appends an InsertCell msg for the given cell followed by a PushAttr msg for each of its attrs.
+func (ms *modelSchema) appendMsgs(msgs []*arc.Msg, cellID uint64, value Model) []*arc.Msg {
+	msg := arc.NewMsg()
+	msg.Op = arc.MsgOp_InsertCell
+	msg.CellID = cellID
+	msg.SetValInt(arc.ValType_SchemaID, int64(ms.SchemaID))
+	msgs = append(msgs, msg)
+
+	if attrs, ok := value.(*Attrs); ok {
+		for _, attr := range ms.Attrs {
+			val, ok := attrs.Vals[attr.AttrURI]
+			if !ok {
+				continue
+			}
+			msg = arc.NewMsg()
+			msg.Op = arc.MsgOp_PushAttr
+			msg.CellID = cellID
+			msg.AttrID = attr.AttrID
+			msg.SI = attr.BoundSI
+			setVal(msg, val)
+			msgs = append(msgs, msg)
+		}
+		return msgs
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(value))
+	for i, fieldIdx := range ms.fields {
+		msg = arc.NewMsg()
+		msg.Op = arc.MsgOp_PushAttr
+		msg.CellID = cellID
+		msg.AttrID = ms.Attrs[i].AttrID
+		setAttrVal(msg, val.Field(fieldIdx))
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// loadAttr sets the attr of the given value bound to the given PushAttr msg, returning false if no attr is bound to it.
+func (ms *modelSchema) loadAttr(value Model, msg *arc.Msg) bool {
+	attr := ms.LookupAttrByID(msg.AttrID, msg.SI)
+	if attr == nil {
+		return false
+	}
+	if attrs, ok := value.(*Attrs); ok {
+		attrs.Vals[attr.AttrURI] = loadVal(msg)
+		return true
+	}
+	val := reflect.ValueOf(value).Elem()
+	loadAttrVal(val.Field(ms.fields[attr.AttrID-1]), msg)
+	return true
+}
+
+func setAttrVal(msg *arc.Msg, field reflect.Value) {
+	if field.Type() == timeType {
+		msg.SetVal(field.Interface().(time.Time))
+		return
+	}
+	switch field.Kind() {
+	case reflect.String:
+		msg.SetVal(field.String())
+	case reflect.Slice:
+		buf := field.Bytes()
+		msg.SetValBuf(arc.ValType_bytes, len(buf))
+		copy(msg.ValBuf, buf)
+	case reflect.Bool:
+		var v int64
+		if field.Bool() {
+			v = 1
+		}
+		msg.SetValInt(arc.ValType_int, v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		msg.SetValInt(arc.ValType_int, int64(field.Uint()))
+	default:
+		msg.SetValInt(arc.ValType_int, field.Int())
+	}
+}
+
+func loadAttrVal(field reflect.Value, msg *arc.Msg) {
+	if field.Type() == timeType {
+		field.Set(reflect.ValueOf(arc.TimeFS(msg.ValInt).Time()))
+		return
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(string(msg.ValBuf))
+	case reflect.Slice:
+		field.SetBytes(append([]byte(nil), msg.ValBuf...))
+	case reflect.Bool:
+		field.SetBool(msg.ValInt != 0)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(uint64(msg.ValInt))
+	default:
+		field.SetInt(msg.ValInt)
+	}
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/lib_service"
+)
+
+// grpcTransport carries a host session over a HostGrpc HostSession stream.
+type grpcTransport struct {
+	rpc    arc.HostGrpc_HostSessionClient
+	cancel context.CancelFunc
+	cc     *grpc.ClientConn // closed with this transport if dialed by DialGrpc
+}
+
+// DialGrpc dials the HostGrpc service at the given address (e.g. "127.0.0.1:5192") and opens a host session over it.
+// If no DialOptions are given, the connection is not secured; otherwise they must include transport credentials.
+func DialGrpc(addr string, opts ...grpc.DialOption) (Transport, error) {
+	if len(opts) == 0 {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, arc.ErrCode_Disconnected.Errorf("failed to dial %v: %v", addr, err)
+	}
+	tr, err := newGrpcTransport(cc)
+	if err != nil {
+		cc.Close()
+		return nil, err
+	}
+	tr.cc = cc
+	return tr, nil
+}
+
+// WithBearerToken returns a DialOption that sends the given token as each stream's "authorization" metadata
+// (see grpc_service.RequireBearerToken). Note the token is sent even if the connection is not secured.
+func WithBearerToken(token string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(bearerToken(token))
+}
+
+type bearerToken string
+
+func (token bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": "Bearer " + string(token),
+	}, nil
+}
+
+func (token bearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
+// NewGrpcTransport opens a host session over the given gRPC connection, which remains open once the session closes.
+func NewGrpcTransport(cc *grpc.ClientConn) (Transport, error) {
+	return newGrpcTransport(cc)
+}
+
+func newGrpcTransport(cc *grpc.ClientConn) (*grpcTransport, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rpc, err := arc.NewHostGrpcClient(cc).HostSession(ctx)
+	if err != nil {
+		cancel()
+		return nil, arc.ErrCode_Disconnected.Errorf("failed to open HostSession: %v", err)
+	}
+	return &grpcTransport{
+		rpc:    rpc,
+		cancel: cancel,
+	}, nil
+}
+
+func (tr *grpcTransport) SendMsg(msg *arc.Msg) error {
+	return grpcErr(tr.rpc.Send(msg))
+}
+
+func (tr *grpcTransport) RecvMsg() (*arc.Msg, error) {
+	msg := arc.NewMsg()
+	if err := tr.rpc.RecvMsg(msg); err != nil {
+		msg.Reclaim()
+		return nil, grpcErr(err)
+	}
+	return msg, nil
+}
+
+func (tr *grpcTransport) Close() error {
+	tr.rpc.CloseSend()
+	tr.cancel()
+	if tr.cc != nil {
+		return tr.cc.Close()
+	}
+	return nil
+}
+
+// grpcErr returns arc.ErrStreamClosed if the given error is due to the stream closing.
+func grpcErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if err == io.EOF || status.Code(err) == codes.Canceled {
+		return arc.ErrStreamClosed
+	}
+	return err
+}
+
+// libTransport carries a host session over a lib_service session.
+type libTransport struct {
+	sess lib_service.LibSession
+	srv  lib_service.LibService // stopped with this transport if started by Loopback
+	buf  []byte                 // recycled by DequeueOutgoing (only accessed by RecvMsg)
+}
+
+// NewLibTransport returns a Transport over the given lib_service session.
+func NewLibTransport(sess lib_service.LibSession) Transport {
+	return &libTransport{
+		sess: sess,
+	}
+}
+
+// Loopback starts a new session of the given in-process host (via a lib_service) and returns a Transport over it.
+func Loopback(h arc.Host) (Transport, error) {
+	srv := lib_service.DefaultLibServiceOpts().NewLibService()
+	if err := srv.StartService(h); err != nil {
+		return nil, err
+	}
+	sess, err := srv.NewLibSession()
+	if err != nil {
+		srv.GracefulStop()
+		return nil, err
+	}
+	return &libTransport{
+		sess: sess,
+		srv:  srv,
+	}, nil
+}
+
+func (tr *libTransport) SendMsg(msg *arc.Msg) error {
+	// The host takes ownership of each msg it's given
+	msg = arc.CopyMsg(msg)
+	err := tr.sess.EnqueueIncoming(msg)
+	if err != nil {
+		msg.Reclaim()
+	}
+	return err
+}
+
+func (tr *libTransport) RecvMsg() (*arc.Msg, error) {
+	if err := tr.sess.DequeueOutgoing(&tr.buf); err != nil {
+		return nil, err
+	}
+	msg := arc.NewMsg()
+	if err := msg.Unmarshal(tr.buf); err != nil {
+		msg.Reclaim()
+		return nil, arc.ErrCode_BadValue.Wrap(err)
+	}
+	return msg, nil
+}
+
+func (tr *libTransport) Close() error {
+	tr.sess.Close()
+	if tr.srv != nil {
+		tr.srv.GracefulStop()
+	}
+	return nil
+}
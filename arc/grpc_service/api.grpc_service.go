@@ -11,8 +11,36 @@ type GrpcServerOpts struct {
 	ServiceURI    string
 	ListenNetwork string
 	ListenAddr    string
+	RecordPath    string   // if set, each session's msgs are recorded to a new file in this dir (see recorder.ReadFile)
+	TLS           *TLSOpts // if set, connections are secured via TLS (otherwise they are plaintext)
+
+	// Applied to each stream in order (see LogRequests, RequireBearerToken, LimitPeers)
+	Interceptors []Interceptor
+
+	// If set, the grpc.reflection.v1alpha service is registered (e.g. for grpcurl).
+	// Note arc.proto is gogo generated, so its services are listed but can't be described.
+	EnableReflection bool
+}
+
+// TLSOpts specifies the certificate a GrpcServer presents and whether clients must present one (i.e. mutual TLS).
+type TLSOpts struct {
+	CertFile string // PEM certificate (or chain) the server presents
+	KeyFile  string // PEM private key of CertFile
+
+	// If CertFile is not set, a self-signed certificate is generated in this dir on first use and reused after (see SelfSignedCertFile).
+	// A client can verify the server by trusting this certificate as a CA.
+	SelfSignedPath string
+
+	ClientCAFile      string // if set, PEM certificates of the CAs that client certificates are verified against
+	RequireClientCert bool   // if set, clients must present a certificate verified by ClientCAFile
 }
 
+// Names of the files in TLSOpts.SelfSignedPath holding the self-signed certificate and its private key.
+const (
+	SelfSignedCertFile = "cert.pem"
+	SelfSignedKeyFile  = "key.pem"
+)
+
 // DefaultGrpcServerOpts returns the default options for a GrpcServer
 // Fun fact: using "127.0.0.1" specifically binds to localhost, so incoming outside connections will be refused.
 // Until then, we want to need to accept incoming outside connections, go by default 0.0.0.0 will accept all incoming connections.
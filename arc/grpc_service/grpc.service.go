@@ -1,24 +1,33 @@
 package grpc_service
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
+	"path"
 	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
 	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/recorder"
 	"github.com/arcspace/go-cedar/process"
 )
 
 // grpcServer is the GRPC implementation of repo.proto
 type grpcServer struct {
+	numSess uint64 // number of sessions started (used to name recordings)
 	process.Context
 	server *grpc.Server
+	health *healthServer
+	lis    net.Listener
 	host   arc.Host
 	opts   GrpcServerOpts
 }
@@ -37,27 +46,55 @@ func (srv *grpcServer) StartService(on arc.Host) error {
 	}
 	srv.host = on
 
-	lis, err := net.Listen(srv.opts.ListenNetwork, srv.opts.ListenAddr)
+	var serverOpts []grpc.ServerOption
+	security := "plaintext"
+	if srv.opts.TLS != nil {
+		cfg, err := srv.opts.TLS.TLSConfig()
+		if err != nil {
+			return err
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(cfg)))
+		security = "TLS"
+		if cfg.ClientAuth == tls.RequireAndVerifyClientCert {
+			security = "mutual TLS"
+		}
+	}
+
+	var err error
+	srv.lis, err = net.Listen(srv.opts.ListenNetwork, srv.opts.ListenAddr)
 	if err != nil {
 		return errors.Errorf("failed to listen: %v", err)
 	}
 
-	srv.server = grpc.NewServer(
-	// 		grpc.StreamInterceptor(srv.StreamServerInterceptor()),
-	// 		grpc.UnaryInterceptor(srv.UnaryServerInterceptor()),
+	streamInterceptors := []grpc.StreamServerInterceptor{srv.recoverStream}
+	for _, interceptor := range srv.opts.Interceptors {
+		streamInterceptors = append(streamInterceptors, interceptor(srv))
+	}
+	serverOpts = append(serverOpts,
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+		grpc.ChainUnaryInterceptor(srv.recoverUnary),
 	)
+	srv.server = grpc.NewServer(serverOpts...)
 	arc.RegisterHostGrpcServer(srv.server, srv)
+	srv.health = newHealthServer()
+	healthpb.RegisterHealthServer(srv.server, srv.health)
+	if srv.opts.EnableReflection {
+		reflection.Register(srv.server)
+	}
 
 	srv.Context, err = srv.host.StartChild(&process.Task{
 		Label:     fmt.Sprint(srv.ServiceURI(), ".HostService"),
 		IdleClose: time.Nanosecond,
 		OnRun: func(ctx process.Context) {
-			srv.Infof(0, "Serving on \x1b[1;32m%v %v\x1b[0m", srv.opts.ListenNetwork, srv.opts.ListenAddr)
-			srv.server.Serve(lis)
+			go srv.watchHost(ctx)
+			srv.health.serve()
+			srv.Infof(0, "Serving on \x1b[1;32m%v %v\x1b[0m (%s)", srv.opts.ListenNetwork, srv.opts.ListenAddr, security)
+			srv.server.Serve(srv.lis)
 			srv.Info(2, "Serve COMPLETE")
 		},
 		OnClosing: func() {
 			if srv.server != nil {
+				srv.health.Shutdown()
 				srv.Info(1, "Stop")
 				srv.server.Stop()
 				srv.Info(2, "Stop COMPLETE")
@@ -71,9 +108,26 @@ func (srv *grpcServer) StartService(on arc.Host) error {
 	return nil
 }
 
+// watchHost reports NOT_SERVING once the host begins closing (ending each Watch stream as GracefulStop does),
+// so that clients stop opening sessions on a host that's shutting down, and stays NOT_SERVING once the host has closed.
+func (srv *grpcServer) watchHost(ctx process.Context) {
+	select {
+	case <-srv.host.Closing():
+		srv.health.drain()
+	case <-ctx.Done():
+		return
+	}
+	select {
+	case <-srv.host.Done():
+		srv.health.Shutdown()
+	case <-ctx.Done():
+	}
+}
+
 func (srv *grpcServer) GracefulStop() {
 	if srv.server != nil {
 		srv.Info(0, "GracefulStop")
+		srv.health.drain()
 		srv.server.GracefulStop()
 	}
 }
@@ -87,9 +141,19 @@ func (srv *grpcServer) HostSession(rpc arc.HostGrpc_HostSessionServer) error {
 		closing: make(chan struct{}),
 	}
 
+	var via arc.ServerStream = sess
+	if srv.opts.RecordPath != "" {
+		w, err := srv.newRecording()
+		if err != nil {
+			return err
+		}
+		via = recorder.RecordStream(sess, w)
+	}
+
 	var err error
-	sess.hostSess, err = srv.host.StartNewSession(srv, sess)
+	sess.hostSess, err = srv.host.StartNewSession(srv, via)
 	if err != nil {
+		via.Close()
 		return err
 	}
 
@@ -98,14 +162,23 @@ func (srv *grpcServer) HostSession(rpc arc.HostGrpc_HostSessionServer) error {
 	// 	return err
 	// }
 
-	// Block until the associated host session enters a closing state
-	select {
-	case <-sess.hostSess.Closing():
-	case <-sess.closing:
-	}
+	// Block until the host closes this stream.
+	// Note the host session may close before then since another session can be resumed over this stream.
+	<-sess.closing
 	return nil
 }
 
+// newRecording creates a file in opts.RecordPath to record a new session to.
+func (srv *grpcServer) newRecording() (*recorder.Writer, error) {
+	sessNum := atomic.AddUint64(&srv.numSess, 1)
+	name := fmt.Sprintf("%s-%d%s", time.Now().Format("20060102-150405"), sessNum, recorder.FileExt)
+	w, err := recorder.Create(path.Join(srv.opts.RecordPath, name))
+	if err != nil {
+		return nil, errors.Errorf("failed to create recording: %v", err)
+	}
+	return w, nil
+}
+
 type grpcSess struct {
 	closed   int32
 	closing  chan struct{}
@@ -146,38 +219,3 @@ func (sess *grpcSess) RecvMsg() (*arc.Msg, error) {
 	}
 	return msg, err
 }
-
-/*
-func (srv *grpcServer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		srv.Debugf(trimStr(fmt.Sprintf("[rpc server] %v %+v", info.FullMethod, req), 500))
-
-		x, err := handler(ctx, req)
-		if err != nil {
-			srv.Errorf(trimStr(fmt.Sprintf("[rpc server] %v %+v %+v", info.FullMethod, req, err), 500))
-		}
-
-		srv.Debugf(trimStr(fmt.Sprintf("[rpc server] %v %+v, %+v", info.FullMethod, req, x), 500))
-		return x, err
-	}
-}
-
-// StreamServerInterceptor is a debugging helper
-func (srv *grpcServer) StreamServerInterceptor() grpc.StreamServerInterceptor {
-	return func(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		srv.Infof(2, "[rpc server] %v", info.FullMethod)
-		err := handler(server, stream)
-		if err != nil {
-			srv.Errorf("[rpc server] %+v", err)
-		}
-		return err
-	}
-}
-
-func trimStr(s string, n int) string {
-	if len(s) > n {
-		return s[:n]
-	}
-	return s
-}
-*/
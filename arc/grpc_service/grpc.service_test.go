@@ -0,0 +1,315 @@
+package grpc_service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/client"
+	"github.com/arcspace/go-arcspace/arc/host/hosttest"
+)
+
+// startTestServer starts a GrpcServer with the given opts on a free loopback port and returns it and its address.
+func startTestServer(t *testing.T, h arc.Host, opts GrpcServerOpts) (*grpcServer, string) {
+	t.Helper()
+	opts.ListenAddr = "127.0.0.1:0"
+	srv := opts.NewGrpcServer()
+	if err := srv.StartService(h); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		srv.Close()
+		<-srv.Done()
+	})
+	grpcSrv := srv.(*grpcServer)
+	return grpcSrv, grpcSrv.lis.Addr().String()
+}
+
+// testSession is a HostSession opened via a raw HostGrpc stream.
+type testSession struct {
+	rpc    arc.HostGrpc_HostSessionClient
+	cc     *grpc.ClientConn
+	cancel context.CancelFunc
+}
+
+func openTestSession(addr string, opts ...grpc.DialOption) (*testSession, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	cc, err := grpc.DialContext(ctx, addr, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	rpc, err := arc.NewHostGrpcClient(cc).HostSession(ctx)
+	if err != nil {
+		cc.Close()
+		cancel()
+		return nil, err
+	}
+	return &testSession{
+		rpc:    rpc,
+		cc:     cc,
+		cancel: cancel,
+	}, nil
+}
+
+func (sess *testSession) Close() {
+	sess.cancel()
+	sess.cc.Close()
+}
+
+// pin returns an error unless the host replies to a pin (here refusing it since the session hasn't logged in).
+func (sess *testSession) pin() error {
+	msg := arc.NewMsg()
+	msg.ReqID = 7
+	msg.Op = arc.MsgOp_PinCell
+	if err := sess.rpc.Send(msg); err != nil {
+		return err
+	}
+	reply, err := sess.rpc.Recv()
+	if err != nil {
+		return err
+	}
+	if reply.ReqID != 7 || reply.Op != arc.MsgOp_CloseReq || reply.ValType != int32(arc.ValType_Err) {
+		return arc.ErrCode_BadValue.Errorf("expected req to be closed with an error, got %v", reply)
+	}
+	return nil
+}
+
+// pinViaGrpc opens a host session at the given address and returns an error unless the host replies to a pin.
+func pinViaGrpc(addr string, opts ...grpc.DialOption) error {
+	sess, err := openTestSession(addr, opts...)
+	if err != nil {
+		return err
+	}
+	defer sess.Close()
+	return sess.pin()
+}
+
+func TestInterceptors(t *testing.T) {
+	h := hosttest.StartTestHost(t)
+	plaintext := grpc.WithTransportCredentials(insecure.NewCredentials())
+
+	// A panic in an interceptor or handler is returned as an error
+	panics := func(srv arc.HostService) grpc.StreamServerInterceptor {
+		return func(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			md, _ := metadata.FromIncomingContext(stream.Context())
+			if len(md.Get("test-panic")) > 0 {
+				panic("test panic")
+			}
+			return handler(server, stream)
+		}
+	}
+	opts := DefaultGrpcServerOpts(0)
+	opts.Interceptors = []Interceptor{
+		LogRequests(),
+		RequireBearerToken(BearerTokens("s3cret", "other")),
+		panics,
+	}
+	_, addr := startTestServer(t, h, opts)
+
+	// A session must present a valid bearer token
+	if err := pinViaGrpc(addr, plaintext); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected missing token to fail, got %v", err)
+	}
+	if err := pinViaGrpc(addr, plaintext, client.WithBearerToken("wrong")); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected invalid token to fail, got %v", err)
+	}
+	if err := pinViaGrpc(addr, plaintext, client.WithBearerToken("s3cret")); err != nil {
+		t.Fatal(err)
+	}
+
+	panicking := grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(metadata.AppendToOutgoingContext(ctx, "test-panic", "1"), desc, cc, method, opts...)
+	})
+	err := pinViaGrpc(addr, plaintext, client.WithBearerToken("other"), panicking)
+	if err == nil || !strings.Contains(status.Convert(err).Message(), arc.ErrCode_InternalErr.String()) {
+		t.Fatalf("expected panic to be returned as ErrCode_InternalErr, got %v", err)
+	}
+	if err = pinViaGrpc(addr, plaintext, client.WithBearerToken("other")); err != nil {
+		t.Fatalf("expected server to survive a panic, got %v", err)
+	}
+
+	// Each peer is limited to its sessions and msg rate
+	opts = DefaultGrpcServerOpts(0)
+	opts.Interceptors = []Interceptor{
+		LimitPeers(PeerLimits{
+			MaxSessions:   1,
+			MaxMsgsPerSec: 20,
+			MsgBurst:      1,
+		}),
+	}
+	_, addr = startTestServer(t, h, opts)
+	sess, err := openTestSession(addr, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sess.Close()
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err = sess.pin(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected msgs to be throttled, took %v", elapsed)
+	}
+	if err = pinViaGrpc(addr, plaintext); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected second session to be refused, got %v", err)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	h := hosttest.StartTestHost(t)
+	opts := DefaultGrpcServerOpts(0)
+	opts.EnableReflection = true
+	srv, addr := startTestServer(t, h, opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cc, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	healthClient := healthpb.NewHealthClient(cc)
+
+	// The server reports SERVING once it's serving
+	watch, err := healthClient.Watch(ctx, &healthpb.HealthCheckRequest{Service: hostGrpcService})
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitStatus := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		for {
+			resp, err := watch.Recv()
+			if err != nil {
+				t.Fatalf("expected %v, got %v", want, err)
+			}
+			if resp.Status == want {
+				return
+			}
+		}
+	}
+	awaitStatus(healthpb.HealthCheckResponse_SERVING)
+	resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("expected server to be SERVING, got %v %v", resp, err)
+	}
+
+	// Reflection lists the registered services
+	refl, err := reflectionpb.NewServerReflectionClient(cc).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = refl.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{ListServices: "*"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := refl.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	services := make(map[string]bool)
+	for _, svc := range reply.GetListServicesResponse().GetService() {
+		services[svc.Name] = true
+	}
+	if !services[hostGrpcService] || !services["grpc.health.v1.Health"] {
+		t.Fatalf("unexpected services %v", services)
+	}
+	refl.CloseSend()
+	if _, err = refl.Recv(); err != io.EOF {
+		t.Fatalf("expected reflection stream to end, got %v", err)
+	}
+
+	// Draining reports NOT_SERVING and ends each Watch stream so GracefulStop isn't held up
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	awaitStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	if _, err = watch.Recv(); status.Code(err) != codes.Canceled {
+		t.Fatalf("expected Watch stream to end, got %v", err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for GracefulStop")
+	}
+
+	// Once closed, the server stays NOT_SERVING
+	srv.Close()
+	<-srv.Done()
+	srv.health.serve()
+	resp, err = srv.health.Check(ctx, &healthpb.HealthCheckRequest{Service: hostGrpcService})
+	if err != nil || resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected server to be NOT_SERVING, got %v %v", resp, err)
+	}
+}
+
+func TestHealthFollowsHost(t *testing.T) {
+	h := hosttest.StartTestHost(t)
+	srv, _ := startTestServer(t, h, DefaultGrpcServerOpts(0))
+
+	ctx := context.Background()
+	req := &healthpb.HealthCheckRequest{Service: hostGrpcService}
+	awaitStatus := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		for timeout := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+			resp, err := srv.health.Check(ctx, req)
+			if err == nil && resp.Status == want {
+				return
+			}
+			if time.Now().After(timeout) {
+				t.Fatalf("expected %v, got %v %v", want, resp, err)
+			}
+		}
+	}
+	awaitStatus(healthpb.HealthCheckResponse_SERVING)
+
+	// Once the host closes, so does the server's health
+	h.Close()
+	<-h.Done()
+	awaitStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	srv.health.serve()
+	awaitStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestPeerLimiter(t *testing.T) {
+	pl := newPeerLimiter(PeerLimits{
+		MaxMsgsPerSec: 10,
+		MsgBurst:      1,
+	})
+
+	// Reconnecting doesn't refill a peer's msg tokens
+	peer := pl.open("10.0.0.1")
+	if wait := pl.reserve(peer); wait > 0 {
+		t.Fatalf("expected first msg to be sent at once, waited %v", wait)
+	}
+	pl.close("10.0.0.1")
+	peer = pl.open("10.0.0.1")
+	if wait := pl.reserve(peer); wait <= 0 {
+		t.Fatal("expected msg after reconnecting to be throttled")
+	}
+	pl.close("10.0.0.1")
+
+	// A peer without sessions is evicted once its msg tokens have refilled
+	time.Sleep(300 * time.Millisecond)
+	pl.open("10.0.0.2")
+	if pl.peers["10.0.0.1"] != nil {
+		t.Fatal("expected idle peer to be evicted")
+	}
+}
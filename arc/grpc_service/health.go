@@ -0,0 +1,88 @@
+package grpc_service
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// hostGrpcService is the name of the HostGrpc service, whose health is reported alongside the server's overall health ("").
+const hostGrpcService = "arc.HostGrpc"
+
+// healthServer implements grpc.health.v1, where each service is NOT_SERVING until the server is serving,
+// NOT_SERVING again once it's draining (see GracefulStop) or its host is closing (see watchHost), and stays NOT_SERVING after it closes.
+//
+// Since grpc.health.v1 has no draining status, once draining each Watch stream ends after NOT_SERVING is sent so it can't hold up GracefulStop.
+type healthServer struct {
+	*health.Server
+	draining  chan struct{}
+	drainOnce sync.Once
+}
+
+func newHealthServer() *healthServer {
+	hs := &healthServer{
+		Server:   health.NewServer(),
+		draining: make(chan struct{}),
+	}
+	hs.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return hs
+}
+
+func (hs *healthServer) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	hs.SetServingStatus("", status)
+	hs.SetServingStatus(hostGrpcService, status)
+}
+
+// serve reports SERVING unless already draining.
+func (hs *healthServer) serve() {
+	select {
+	case <-hs.draining:
+	default:
+		hs.setStatus(healthpb.HealthCheckResponse_SERVING)
+	}
+}
+
+// drain reports NOT_SERVING and ends each Watch stream once it's been sent.
+func (hs *healthServer) drain() {
+	hs.drainOnce.Do(func() {
+		close(hs.draining)
+		hs.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	})
+}
+
+func (hs *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	return hs.Server.Watch(req, &drainingWatch{
+		Health_WatchServer: stream,
+		ctx:                ctx,
+		cancel:             cancel,
+		draining:           hs.draining,
+	})
+}
+
+// drainingWatch is a Watch stream that ends once it's sent a status other than SERVING while its server is draining.
+type drainingWatch struct {
+	healthpb.Health_WatchServer
+	ctx      context.Context
+	cancel   context.CancelFunc
+	draining <-chan struct{}
+}
+
+func (watch *drainingWatch) Context() context.Context {
+	return watch.ctx
+}
+
+func (watch *drainingWatch) Send(resp *healthpb.HealthCheckResponse) error {
+	err := watch.Health_WatchServer.Send(resp)
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		select {
+		case <-watch.draining:
+			watch.cancel()
+		default:
+		}
+	}
+	return err
+}
@@ -0,0 +1,322 @@
+package grpc_service
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// Interceptor returns a stream interceptor for the given GrpcServer, which it can use to log.
+//
+// A GrpcServer applies its GrpcServerOpts.Interceptors to each stream in order, where the first given is outermost.
+// Regardless, a panic in a handler or interceptor is recovered and returned as ErrCode_InternalErr.
+type Interceptor func(srv arc.HostService) grpc.StreamServerInterceptor
+
+// hostSessionMethod is the FullMethod of HostGrpc.HostSession
+const hostSessionMethod = "/" + hostGrpcService + "/HostSession"
+
+// recoverStream returns a panic in the given stream's handler as ErrCode_InternalErr.
+func (srv *grpcServer) recoverStream(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = srv.recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(server, stream)
+}
+
+// recoverUnary returns a panic in the given call's handler as ErrCode_InternalErr.
+func (srv *grpcServer) recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = srv.recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (srv *grpcServer) recovered(method string, r interface{}) error {
+	srv.Warnf("rpc=%s panic: %v\n%s", method, r, debug.Stack())
+	return arc.ErrCode_InternalErr.Errorf("panic in %s: %v", method, r)
+}
+
+// LogRequests logs each stream as it opens and closes, including its peer, duration and number of msgs sent and received.
+func LogRequests() Interceptor {
+	return func(srv arc.HostService) grpc.StreamServerInterceptor {
+		return func(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			addr := peerAddr(stream.Context())
+			srv.Infof(2, "rpc=%s peer=%s open", info.FullMethod, addr)
+
+			counted := &countingStream{ServerStream: stream}
+			start := time.Now()
+			err := handler(server, counted)
+			dur := time.Since(start).Round(time.Millisecond)
+			recvd, sent := atomic.LoadInt64(&counted.recvd), atomic.LoadInt64(&counted.sent)
+			if err != nil {
+				srv.Warnf("rpc=%s peer=%s dur=%v recvd=%d sent=%d err=%q", info.FullMethod, addr, dur, recvd, sent, err.Error())
+			} else {
+				srv.Infof(1, "rpc=%s peer=%s dur=%v recvd=%d sent=%d", info.FullMethod, addr, dur, recvd, sent)
+			}
+			return err
+		}
+	}
+}
+
+// countingStream counts the msgs sent and received over a stream.
+type countingStream struct {
+	grpc.ServerStream
+	recvd int64
+	sent  int64
+}
+
+func (stream *countingStream) RecvMsg(m interface{}) error {
+	err := stream.ServerStream.RecvMsg(m)
+	if err == nil {
+		atomic.AddInt64(&stream.recvd, 1)
+	}
+	return err
+}
+
+func (stream *countingStream) SendMsg(m interface{}) error {
+	err := stream.ServerStream.SendMsg(m)
+	if err == nil {
+		atomic.AddInt64(&stream.sent, 1)
+	}
+	return err
+}
+
+// RequireBearerToken refuses to open a HostSession unless its "authorization" metadata is "Bearer {token}" and the given check accepts token.
+// Other services (e.g. health) are not checked.
+func RequireBearerToken(check func(token string) error) Interceptor {
+	return func(srv arc.HostService) grpc.StreamServerInterceptor {
+		return func(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if info.FullMethod != hostSessionMethod {
+				return handler(server, stream)
+			}
+			token := ""
+			md, _ := metadata.FromIncomingContext(stream.Context())
+			if auth := md.Get("authorization"); len(auth) > 0 {
+				const prefix = "bearer "
+				if len(auth[0]) > len(prefix) && strings.EqualFold(auth[0][:len(prefix)], prefix) {
+					token = auth[0][len(prefix):]
+				}
+			}
+			if token == "" {
+				return status.Error(codes.Unauthenticated, "missing bearer token")
+			}
+			if err := check(token); err != nil {
+				srv.Warnf("rpc=%s peer=%s refused: %v", info.FullMethod, peerAddr(stream.Context()), err)
+				return status.Error(codes.Unauthenticated, "invalid bearer token")
+			}
+			return handler(server, stream)
+		}
+	}
+}
+
+// BearerTokens returns a check for RequireBearerToken that accepts only the given tokens.
+func BearerTokens(tokens ...string) func(token string) error {
+	return func(token string) error {
+		valid := 0
+		for _, t := range tokens {
+			valid |= subtle.ConstantTimeCompare([]byte(t), []byte(token))
+		}
+		if valid == 0 {
+			return arc.ErrCode_InsufficientPermissions.Error("unknown bearer token")
+		}
+		return nil
+	}
+}
+
+// PeerLimits limits the HostSessions of each peer, where a peer is identified by its IP address.
+type PeerLimits struct {
+	MaxSessions   int     // max HostSessions a peer can have open at once (or 0 for no limit)
+	MaxMsgsPerSec float64 // max msgs per second a peer can send across its HostSessions (or 0 for no limit)
+	MsgBurst      int     // msgs a peer can send at once before being throttled to MaxMsgsPerSec (defaults to MaxMsgsPerSec)
+}
+
+// LimitPeers refuses a HostSession that would exceed its peer's MaxSessions and throttles the msgs a peer sends to MaxMsgsPerSec.
+// A peer's msg rate carries over to its next session until it's been idle long enough to have been refilled to MsgBurst.
+func LimitPeers(limits PeerLimits) Interceptor {
+	return func(srv arc.HostService) grpc.StreamServerInterceptor {
+		pl := newPeerLimiter(limits)
+		return func(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if info.FullMethod != hostSessionMethod {
+				return handler(server, stream)
+			}
+			host := peerHost(stream.Context())
+			ps := pl.open(host)
+			if ps == nil {
+				srv.Warnf("rpc=%s peer=%s refused: exceeds %d sessions", info.FullMethod, host, limits.MaxSessions)
+				return status.Errorf(codes.ResourceExhausted, "exceeds %d sessions per peer", limits.MaxSessions)
+			}
+			defer pl.close(host)
+
+			if limits.MaxMsgsPerSec > 0 {
+				stream = &limitedStream{
+					ServerStream: stream,
+					limiter:      pl,
+					peer:         ps,
+				}
+			}
+			return handler(server, stream)
+		}
+	}
+}
+
+type peerLimiter struct {
+	PeerLimits
+	refillTime time.Duration // how long it takes a peer's msg tokens to refill from empty to MsgBurst
+	mu         sync.Mutex
+	peers      map[string]*peerState
+	swept      time.Time // when idle peers were last evicted
+}
+
+// peerState is the number of open sessions of a peer and the msg tokens available to it.
+type peerState struct {
+	sessions int
+	tokens   float64
+	refilled time.Time
+}
+
+func newPeerLimiter(limits PeerLimits) *peerLimiter {
+	if limits.MsgBurst <= 0 {
+		limits.MsgBurst = int(limits.MaxMsgsPerSec)
+		if limits.MsgBurst < 1 {
+			limits.MsgBurst = 1
+		}
+	}
+	pl := &peerLimiter{
+		PeerLimits: limits,
+		peers:      make(map[string]*peerState),
+	}
+	if limits.MaxMsgsPerSec > 0 {
+		pl.refillTime = time.Duration(float64(limits.MsgBurst) / limits.MaxMsgsPerSec * float64(time.Second))
+	}
+	return pl
+}
+
+// open returns the state of the given peer with a session added, or nil if that would exceed MaxSessions.
+func (pl *peerLimiter) open(host string) *peerState {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	now := time.Now()
+	if pl.refillTime > 0 && now.Sub(pl.swept) >= pl.refillTime {
+		pl.evictIdle(now)
+	}
+
+	peer := pl.peers[host]
+	if peer == nil {
+		peer = &peerState{
+			tokens:   float64(pl.MsgBurst),
+			refilled: now,
+		}
+		pl.peers[host] = peer
+	}
+	if pl.MaxSessions > 0 && peer.sessions >= pl.MaxSessions {
+		return nil
+	}
+	peer.sessions++
+	return peer
+}
+
+// close removes a session from the given peer.
+// Its state is kept until its msg tokens have refilled (see evictIdle) so that reconnecting doesn't refill them.
+func (pl *peerLimiter) close(host string) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	peer := pl.peers[host]
+	peer.sessions--
+	if peer.sessions == 0 && pl.refillTime == 0 {
+		delete(pl.peers, host)
+	}
+}
+
+// evictIdle removes each peer without sessions whose msg tokens have since refilled to MsgBurst, since a new state is then no different.
+// Since this runs at most once per refillTime, a peer is kept for up to refillTime after that.
+func (pl *peerLimiter) evictIdle(now time.Time) {
+	for host, peer := range pl.peers {
+		if peer.sessions > 0 {
+			continue
+		}
+		empty := float64(pl.MsgBurst) - peer.tokens
+		if now.Sub(peer.refilled) >= time.Duration(empty/pl.MaxMsgsPerSec*float64(time.Second)) {
+			delete(pl.peers, host)
+		}
+	}
+	pl.swept = now
+}
+
+// reserve takes a msg token from the given peer, returning how long to wait until it's available.
+func (pl *peerLimiter) reserve(peer *peerState) time.Duration {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	now := time.Now()
+	peer.tokens += now.Sub(peer.refilled).Seconds() * pl.MaxMsgsPerSec
+	if max := float64(pl.MsgBurst); peer.tokens > max {
+		peer.tokens = max
+	}
+	peer.refilled = now
+	peer.tokens--
+	if peer.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-peer.tokens / pl.MaxMsgsPerSec * float64(time.Second))
+}
+
+// limitedStream delays each msg received until its peer has a msg token available.
+type limitedStream struct {
+	grpc.ServerStream
+	limiter *peerLimiter
+	peer    *peerState
+}
+
+func (stream *limitedStream) RecvMsg(m interface{}) error {
+	if err := stream.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	wait := stream.limiter.reserve(stream.peer)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-stream.Context().Done():
+		return status.FromContextError(stream.Context().Err()).Err()
+	}
+}
+
+// peerAddr returns the address of the peer of the given stream context.
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+// peerHost returns the host (e.g. IP address) of the peer of the given stream context.
+func peerHost(ctx context.Context) string {
+	addr := peerAddr(ctx)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
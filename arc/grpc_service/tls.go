@@ -0,0 +1,147 @@
+package grpc_service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/arcspace/go-cedar/utils"
+)
+
+// TLSConfig returns the server-side tls.Config specified by these options.
+func (opts *TLSOpts) TLSConfig() (*tls.Config, error) {
+	certFile, keyFile := opts.CertFile, opts.KeyFile
+	if certFile == "" {
+		if opts.SelfSignedPath == "" {
+			return nil, errors.Errorf("TLS requires a CertFile or SelfSignedPath")
+		}
+		var err error
+		if certFile, keyFile, err = loadSelfSigned(opts.SelfSignedPath); err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Errorf("failed to load TLS cert: %v", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if opts.ClientCAFile != "" {
+		pool, err := LoadCertPool(opts.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		if opts.RequireClientCert {
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	} else if opts.RequireClientCert {
+		return nil, errors.Errorf("RequireClientCert requires a ClientCAFile")
+	}
+
+	return cfg, nil
+}
+
+// LoadCertPool returns a pool of the PEM certificates in the given file (e.g. to verify a peer's certificate against).
+func LoadCertPool(pathname string) (*x509.CertPool, error) {
+	buf, err := os.ReadFile(pathname)
+	if err != nil {
+		return nil, errors.Errorf("failed to read CA certs: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(buf) {
+		return nil, errors.Errorf("no PEM certs found in %q", pathname)
+	}
+	return pool, nil
+}
+
+// loadSelfSigned returns the pathnames of the self-signed cert and key in the given dir, generating them if they don't exist.
+func loadSelfSigned(dir string) (certFile, keyFile string, err error) {
+	dir, err = utils.ExpandAndCheckPath(dir, true)
+	if err != nil {
+		return
+	}
+	certFile = path.Join(dir, SelfSignedCertFile)
+	keyFile = path.Join(dir, SelfSignedKeyFile)
+
+	if _, err = os.Stat(certFile); err == nil {
+		return
+	}
+	if !os.IsNotExist(err) {
+		return
+	}
+	err = writeSelfSigned(certFile, keyFile)
+	return
+}
+
+// writeSelfSigned generates a self-signed cert valid for this host's name and loopback addrs.
+// Since it's also its own CA, a client can trust it as is.
+func writeSelfSigned(certFile, keyFile string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "archost"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	if hostname, _ := os.Hostname(); hostname != "" && hostname != "localhost" {
+		tmpl.DNSNames = append(tmpl.DNSNames, hostname)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return errors.Errorf("failed to create self-signed cert: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	// Write the key first so a cert is never present without it
+	if err = writePEM(keyFile, "EC PRIVATE KEY", keyDER, 0600); err != nil {
+		return err
+	}
+	return writePEM(certFile, "CERTIFICATE", der, 0644)
+}
+
+func writePEM(pathname, blockType string, der []byte, perm os.FileMode) error {
+	buf := pem.EncodeToMemory(&pem.Block{
+		Type:  blockType,
+		Bytes: der,
+	})
+	if err := os.WriteFile(pathname, buf, perm); err != nil {
+		return errors.Errorf("failed to write %q: %v", pathname, err)
+	}
+	return nil
+}
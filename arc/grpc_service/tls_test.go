@@ -0,0 +1,114 @@
+package grpc_service
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/arcspace/go-arcspace/arc/host/hosttest"
+)
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+	h := hosttest.StartTestHost(t)
+
+	// A self-signed cert is generated on first use and reused after
+	tlsPath := dir + "/tls"
+	startServer := func(tlsOpts *TLSOpts) string {
+		t.Helper()
+		opts := DefaultGrpcServerOpts(0)
+		opts.TLS = tlsOpts
+		_, addr := startTestServer(t, h, opts)
+		return addr
+	}
+	addr := startServer(&TLSOpts{SelfSignedPath: tlsPath})
+	certPEM, err := os.ReadFile(path.Join(tlsPath, SelfSignedCertFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(path.Join(tlsPath, SelfSignedKeyFile)); err != nil || fi.Mode().Perm() != 0600 {
+		t.Fatalf("expected key file with mode 0600, got %v", err)
+	}
+	startServer(&TLSOpts{SelfSignedPath: tlsPath})
+	if buf, _ := os.ReadFile(path.Join(tlsPath, SelfSignedCertFile)); !bytes.Equal(buf, certPEM) {
+		t.Fatal("expected self-signed cert to be reused")
+	}
+
+	// A client that trusts the self-signed cert reaches the host
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(certPEM)
+	if err = pinViaGrpc(addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: roots}))); err != nil {
+		t.Fatal(err)
+	}
+
+	// Clients that don't speak TLS or don't trust the server's cert are refused
+	if err = pinViaGrpc(addr, grpc.WithTransportCredentials(insecure.NewCredentials())); err == nil {
+		t.Fatal("expected plaintext client to fail")
+	}
+	if err = pinViaGrpc(addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))); err == nil {
+		t.Fatal("expected client that doesn't trust the self-signed cert to fail")
+	}
+
+	// Client certs are required only if set
+	if _, err = (&TLSOpts{SelfSignedPath: tlsPath, RequireClientCert: true}).TLSConfig(); err == nil {
+		t.Fatal("expected RequireClientCert without a ClientCAFile to fail")
+	}
+	clientCert := newClientCert(t, dir)
+	mtlsAddr := startServer(&TLSOpts{
+		SelfSignedPath:    tlsPath,
+		ClientCAFile:      dir + "/client.pem",
+		RequireClientCert: true,
+	})
+	if err = pinViaGrpc(mtlsAddr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: roots}))); err == nil {
+		t.Fatal("expected client without a cert to fail")
+	}
+	err = pinViaGrpc(mtlsAddr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{clientCert},
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newClientCert writes a self-signed client cert to {dir}/client.pem, which also serves as the CA it's verified against.
+func newClientCert(t *testing.T, dir string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = writePEM(dir+"/client.pem", "CERTIFICATE", der, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+}
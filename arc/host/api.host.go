@@ -1,22 +1,117 @@
 package host
 
-import "github.com/arcspace/go-arcspace/arc"
+import (
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
 
 type HostOpts struct {
-	Label     string // label of this host
-	StatePath string // local fs path where user and state data is stored
-	CachePath string // local fs path where purgeable data is stored
+	Label             string                     // label of this host
+	StatePath         string                     // local fs path where user and state data is stored
+	CachePath         string                     // local fs path where purgeable data is stored
+	MaxTxnMsgs        int                        // max number of msgs a client txn can contain (see MsgOp_Commit)
+	MaxOpenTxns       int                        // max number of client txns each session can have pending commit
+	MaxPinResolves    int                        // max number of PinCell requests each session resolves at once (see arc.App.ResolveRequest)
+	MaxQueuedPins     int                        // max number of PinCell requests each session can have awaiting resolve, beyond which a pin is refused
+	MaxUserPlanets    int                        // max number of planets each user can create (see MsgOp_CreatePlanet)
+	SubQueueSize      int                        // max number of msgs queued for each open request before SubOverflow applies
+	SnapshotQueueSize int                        // max number of msgs of a pinned cell's state queued for each open request before SubOverflow applies
+	SubOverflow       SubOverflow                // what is done when a live update doesn't fit in a request's queue
+	ResumeGrace       time.Duration              // how long a logged in session outlives its dropped stream, awaiting resumption (0 disables)
+	CellIdleClose     time.Duration              // how long a cell stays open once it has no subs (checked every CellIdleClose/2, at least each minute)
+	PlanetIdleClose   time.Duration              // how long a mounted planet (other than the home planet) stays open once it has no open cells
+	ValueLogFileSize  int64                      // size of each planet db's value log files (see badger.Options)
+	AppSettings       map[string]arc.AppSettings // settings given to each registered App, keyed by AppURI (see arc.AppContext)
 }
 
+// SubOverflow specifies what a host does when a live cell update doesn't fit in a sub's outbound queue (i.e. its client isn't keeping up).
+// Each action taken is counted in arc.SubQueueStats.
+type SubOverflow int32
+
+const (
+	// The update replaces the attr values it supersedes that are still queued.
+	// If the update can't be coalesced (e.g. it contains new series items), the sub is dropped.
+	SubOverflow_Coalesce SubOverflow = iota
+
+	// The sub's request is closed with an error.
+	SubOverflow_DropSub
+
+	// The client's session is closed.
+	SubOverflow_Disconnect
+)
+
 func DefaultHostOpts() HostOpts {
 	opts := HostOpts{
-		Label:     "Host",
-		StatePath: "~/_.archost",
+		Label:             "Host",
+		StatePath:         "~/_.archost",
+		MaxTxnMsgs:        4096,
+		MaxOpenTxns:       16,
+		MaxPinResolves:    8,
+		MaxQueuedPins:     256,
+		MaxUserPlanets:    16,
+		SubQueueSize:      256,
+		SnapshotQueueSize: 8192,
+		SubOverflow:       SubOverflow_Coalesce,
+		ResumeGrace:       30 * time.Second,
+		CellIdleClose:     3 * time.Minute,
+		PlanetIdleClose:   120 * time.Second,
+
+		// Limit ValueLogFileSize to ~134mb since badger does a mmap size test on init, causing iOS 13 to error out.
+		// Also, massive value file sizes aren't appropriate for mobile.
+		ValueLogFileSize: 1 << 27,
 	}
 	return opts
 }
 
+// applyDefaults sets each limit of these opts that is unset to its default.
+func (opts *HostOpts) applyDefaults() {
+	defaults := DefaultHostOpts()
+	if opts.MaxTxnMsgs <= 0 {
+		opts.MaxTxnMsgs = defaults.MaxTxnMsgs
+	}
+	if opts.MaxOpenTxns <= 0 {
+		opts.MaxOpenTxns = defaults.MaxOpenTxns
+	}
+	if opts.SubQueueSize <= 0 {
+		opts.SubQueueSize = defaults.SubQueueSize
+	}
+	if opts.SnapshotQueueSize <= 0 {
+		opts.SnapshotQueueSize = defaults.SnapshotQueueSize
+	}
+	if opts.MaxPinResolves <= 0 {
+		opts.MaxPinResolves = defaults.MaxPinResolves
+	}
+	if opts.MaxQueuedPins <= 0 {
+		opts.MaxQueuedPins = defaults.MaxQueuedPins
+	}
+	if opts.MaxUserPlanets <= 0 {
+		opts.MaxUserPlanets = defaults.MaxUserPlanets
+	}
+	if opts.CellIdleClose <= 0 {
+		opts.CellIdleClose = defaults.CellIdleClose
+	}
+	if opts.PlanetIdleClose <= 0 {
+		opts.PlanetIdleClose = defaults.PlanetIdleClose
+	}
+	if opts.ValueLogFileSize <= 0 {
+		opts.ValueLogFileSize = defaults.ValueLogFileSize
+	}
+}
+
 // StartNewHost starts a new host with the given opts
 func StartNewHost(opts HostOpts) (arc.Host, error) {
 	return startNewHost(opts)
 }
+
+// ReloadOpts applies the given opts to a host started via StartNewHost, leaving the opts that can't change while it runs as they are.
+// The limits, idle timeouts, SubOverflow and ResumeGrace are applied, taking effect for sessions, requests, and planets
+// opened afterwards (or, for CellIdleClose, at the next idle check).
+func ReloadOpts(h arc.Host, opts HostOpts) error {
+	host, ok := h.(*host)
+	if !ok {
+		return arc.ErrCode_Unimplemented.Error("host opts can't be reloaded")
+	}
+	host.reloadOpts(opts)
+	return nil
+}
@@ -0,0 +1,80 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+func TestReloadOpts(t *testing.T) {
+	h := startTestHost(t)
+	statePath := h.opts.StatePath
+	key := cellKey{CellID: 1<<40 + 1}
+	cell, err := h.home.getCell(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.home.closeIdleCells(time.Second)
+	select {
+	case <-cell.Closing():
+		t.Fatal("expected cell to stay open")
+	default:
+	}
+
+	opts := DefaultHostOpts()
+	opts.StatePath = t.TempDir()
+	opts.MaxTxnMsgs = 2
+	opts.CellIdleClose = time.Second
+	if err = ReloadOpts(h, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only opts that can change while running are reloaded
+	if got := h.getOpts(); got.StatePath != statePath || got.MaxTxnMsgs != 2 {
+		t.Fatalf("unexpected opts after reload: %+v", got)
+	}
+
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.register(noteSchema)
+	err = ts.commit(insertCell(key.CellID, noteSchema), pushAttr(key.CellID, 1, "1"), pushAttr(key.CellID, 1, "2"))
+	if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_CommitFailed {
+		t.Fatalf("expected txn exceeding reloaded MaxTxnMsgs to fail, got %v", err)
+	}
+
+	// A reloaded CellIdleClose applies at the next idle check
+	h.home.closeIdleCells(time.Second)
+	select {
+	case <-cell.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected idle cell to close")
+	}
+}
+
+func TestCellIdleClose(t *testing.T) {
+	dir := t.TempDir()
+	opts := DefaultHostOpts()
+	opts.StatePath = dir + "/state"
+	opts.CachePath = dir + "/cache"
+	opts.CellIdleClose = 100 * time.Millisecond
+	h, err := startNewHost(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		h.Close()
+		<-h.Done()
+	}()
+
+	// A CellIdleClose well under a minute is honored by the planet's idle checks
+	cell, err := h.(*host).home.getCell(cellKey{CellID: 1<<40 + 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-cell.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected idle cell to close")
+	}
+}
@@ -0,0 +1,311 @@
+package host
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/symbol"
+	"github.com/arcspace/go-cedar/process"
+	"github.com/dgraph-io/badger/v3"
+)
+
+// Each App's private storage is kept on the host's home planet, keyed by the planet symbol of the App's AppURI:
+//
+//	kAppStore+AppSym+key => value
+
+// appInst is a registered App and the child process it runs under (its arc.AppContext).
+type appInst struct {
+	process.Context
+
+	app      arc.App
+	appURI   string
+	appBase  string   // appURI without its version (see parseAppURI)
+	appVers  []uint64 // version components of appURI
+	appSym   symbol.ID
+	host     *host
+	settings arc.AppSettings
+}
+
+func (host *host) RegisterApp(app arc.App) error {
+	appURI := app.AppURI()
+	if appURI == "" {
+		return arc.ErrCode_InvalidURI.Error("invalid app URI")
+	}
+	if err := host.checkAppURI(appURI); err != nil {
+		return err
+	}
+
+	inst := &appInst{
+		app:      app,
+		appURI:   appURI,
+		appSym:   host.home.symTable.GetSymbolID([]byte("/App/"+appURI), true),
+		host:     host,
+		settings: host.getOpts().AppSettings[appURI],
+	}
+	inst.appBase, inst.appVers = parseAppURI(appURI)
+
+	// Apps run under the home planet so that their storage outlives them
+	var err error
+	inst.Context, err = host.home.StartChild(&process.Task{
+		Label:     fmt.Sprint("App ", appURI),
+		OnClosing: inst.onClosing,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = app.StartApp(inst); err == nil {
+		err = host.addApp(inst)
+		if err != nil {
+			app.StopApp()
+		}
+	}
+	if err != nil {
+		inst.Close()
+		<-inst.Done()
+		return err
+	}
+	return nil
+}
+
+// checkAppURI returns an error if an App with the given AppURI is already registered.
+func (host *host) checkAppURI(appURI string) error {
+	host.appsMu.RLock()
+	defer host.appsMu.RUnlock()
+
+	if host.appsByURI[appURI] != nil {
+		return arc.ErrCode_InvalidURI.Errorf("App %q already registered", appURI)
+	}
+	return nil
+}
+
+// addApp routes requests to the given started App, unless it has since begun closing or it conflicts with a registered App.
+func (host *host) addApp(inst *appInst) error {
+	host.appsMu.Lock()
+	defer host.appsMu.Unlock()
+
+	select {
+	case <-inst.Closing():
+		return arc.ErrCode_ShuttingDown.Errorf("App %q stopped while starting", inst.appURI)
+	default:
+	}
+	if host.appsByURI[inst.appURI] != nil {
+		return arc.ErrCode_InvalidURI.Errorf("App %q already registered", inst.appURI)
+	}
+
+	// Only versions of the same App can handle the same data model
+	modelURIs := inst.app.AttrModelURIs()
+	for _, modelURI := range modelURIs {
+		for _, other := range host.appsByModel[modelURI] {
+			if other.appBase != inst.appBase {
+				return arc.ErrCode_AppConflict.Errorf("App %q can't handle %q since App %q already does", inst.appURI, modelURI, other.appURI)
+			}
+		}
+	}
+
+	host.apps = append(host.apps, inst)
+	host.appsByURI[inst.appURI] = inst
+	for _, modelURI := range modelURIs {
+		if modelURI == "" {
+			continue
+		}
+		apps := append(host.appsByModel[modelURI], inst)
+		sort.SliceStable(apps, func(i, j int) bool {
+			return compareVers(apps[i].appVers, apps[j].appVers) > 0
+		})
+		host.appsByModel[modelURI] = apps
+	}
+	host.statusChanged()
+	return nil
+}
+
+// removeApp stops routing requests to the given App, returning false if it was not registered.
+func (host *host) removeApp(inst *appInst) bool {
+	host.appsMu.Lock()
+	defer host.appsMu.Unlock()
+
+	if host.appsByURI[inst.appURI] != inst {
+		return false
+	}
+	delete(host.appsByURI, inst.appURI)
+	for modelURI, apps := range host.appsByModel {
+		host.appsByModel[modelURI] = removeAppInst(apps, inst)
+		if len(host.appsByModel[modelURI]) == 0 {
+			delete(host.appsByModel, modelURI)
+		}
+	}
+	host.apps = removeAppInst(host.apps, inst)
+	host.statusChanged()
+	return true
+}
+
+func removeAppInst(apps []*appInst, inst *appInst) []*appInst {
+	for i, app := range apps {
+		if app == inst {
+			return append(apps[:i:i], apps[i+1:]...)
+		}
+	}
+	return apps
+}
+
+// stopApps closes each registered App in the reverse order they were registered, blocking until each has closed.
+func (host *host) stopApps() {
+	host.appsMu.RLock()
+	apps := append([]*appInst(nil), host.apps...)
+	host.appsMu.RUnlock()
+
+	for i := len(apps) - 1; i >= 0; i-- {
+		apps[i].Close()
+		<-apps[i].Done()
+	}
+}
+
+func (host *host) SelectAppForSchema(schema *arc.AttrSchema) (arc.App, error) {
+	if schema == nil {
+		return nil, arc.ErrCode_AppNotFound.Errorf("missing schema")
+	}
+
+	host.appsMu.RLock()
+	defer host.appsMu.RUnlock()
+
+	// An explicit AppURI must be honored rather than falling back to another (e.g. a different major version)
+	if schema.AppURI != arc.DefaultAppForDataModel && schema.AppURI != "" {
+		inst := host.selectAppByURI(schema.AppURI)
+		if inst == nil {
+			return nil, arc.ErrCode_AppNotFound.Errorf("App not found for AppURI %q", schema.AppURI)
+		}
+		return inst.app, nil
+	}
+
+	apps := host.appsByModel[schema.AttrModelURI]
+	if len(apps) == 0 {
+		return nil, arc.ErrCode_AppNotFound.Errorf("App not found for schema: %s", schema.SchemaDesc())
+	}
+
+	return apps[0].app, nil
+}
+
+// selectAppByURI returns the newest registered App that matches the given AppURI (or nil if none match).
+// The given AppURI can omit its domain and any trailing version components (e.g. "vibe.app/v1").
+func (host *host) selectAppByURI(appURI string) *appInst {
+	if inst := host.appsByURI[appURI]; inst != nil {
+		return inst
+	}
+
+	base, vers := parseAppURI(appURI)
+	var newest *appInst
+	for _, inst := range host.apps {
+		if !inst.matches(base, vers) {
+			continue
+		}
+		if newest == nil || compareVers(inst.appVers, newest.appVers) > 0 {
+			newest = inst
+		}
+	}
+	return newest
+}
+
+// matches reports if this App's AppURI has the given base (or ends with it) and starts with the given version components.
+func (inst *appInst) matches(base string, vers []uint64) bool {
+	if inst.appBase != base && !strings.HasSuffix(inst.appBase, "/"+base) {
+		return false
+	}
+	if len(vers) > len(inst.appVers) {
+		return false
+	}
+	for i, v := range vers {
+		if inst.appVers[i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAppURI splits the given AppURI into its base and version components, e.g.
+//
+//	"arcspace.systems/sys.app/v1.2023.1" => "arcspace.systems/sys.app", [1 2023 1]
+//
+// If the AppURI has no version, it is returned as is with no version components.
+func parseAppURI(appURI string) (base string, vers []uint64) {
+	i := strings.LastIndex(appURI, "/v")
+	if i < 0 {
+		return appURI, nil
+	}
+	for _, part := range strings.Split(appURI[i+2:], ".") {
+		v, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return appURI, nil
+		}
+		vers = append(vers, v)
+	}
+	return appURI[:i], vers
+}
+
+// compareVers orders the given version components, where missing components are less than present ones.
+func compareVers(a, b []uint64) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
+
+func (inst *appInst) onClosing() {
+	if inst.host.removeApp(inst) {
+		inst.app.StopApp()
+	}
+}
+
+func (inst *appInst) Host() arc.Host {
+	return inst.host
+}
+
+func (inst *appInst) Settings() arc.AppSettings {
+	return inst.settings
+}
+
+func (inst *appInst) appKey(key []byte) []byte {
+	appKey := make([]byte, 0, 1+symbol.IDSz+len(key))
+	appKey = append(appKey, kAppStore)
+	appKey = inst.appSym.WriteTo(appKey)
+	return append(appKey, key...)
+}
+
+func (inst *appInst) GetAppValue(key []byte) ([]byte, error) {
+	var value []byte
+	err := inst.host.home.db.View(func(dbTx *badger.Txn) error {
+		item, err := dbTx.Get(inst.appKey(key))
+		if err == nil {
+			value, err = item.ValueCopy(nil)
+		}
+		return err
+	})
+
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, arc.ErrCode_DataFailure.Wrap(err)
+	}
+	return value, nil
+}
+
+func (inst *appInst) PutAppValue(key, value []byte) error {
+	err := inst.host.home.db.Update(func(dbTx *badger.Txn) error {
+		if value == nil {
+			return dbTx.Delete(inst.appKey(key))
+		}
+		return dbTx.Set(inst.appKey(key), value)
+	})
+	if err != nil {
+		return arc.ErrCode_DataFailure.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package host
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// lifecycleApp notes when it's started and stopped to a log shared by the apps of a test.
+type lifecycleApp struct {
+	name     string
+	log      *appLog
+	startErr error
+	ctx      arc.AppContext
+}
+
+type appLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (log *appLog) add(event string) {
+	log.mu.Lock()
+	log.events = append(log.events, event)
+	log.mu.Unlock()
+}
+
+func (app *lifecycleApp) AppURI() string {
+	return "test.arc.tools/" + app.name + ".app/v1.0.0"
+}
+
+func (app *lifecycleApp) AttrModelURIs() []string {
+	return []string{"test/" + app.name}
+}
+
+func (app *lifecycleApp) StartApp(ctx arc.AppContext) error {
+	if app.startErr != nil {
+		return app.startErr
+	}
+	app.ctx = ctx
+	app.log.add("start " + app.name + " " + ctx.Settings()["greeting"])
+	return nil
+}
+
+func (app *lifecycleApp) StopApp() {
+	// An App's storage remains available while it stops
+	val, err := app.ctx.GetAppValue([]byte("key"))
+	if err != nil {
+		app.log.add("stop " + app.name + " " + err.Error())
+		return
+	}
+	app.log.add("stop " + app.name + " " + string(val))
+}
+
+func (app *lifecycleApp) ResolveRequest(req *arc.CellReq) error {
+	return arc.ErrCode_Unimplemented.Error("not pinnable")
+}
+
+func TestAppLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	opts := DefaultHostOpts()
+	opts.StatePath = dir + "/state"
+	opts.CachePath = dir + "/cache"
+	opts.AppSettings = map[string]arc.AppSettings{
+		"test.arc.tools/a.app/v1.0.0": {"greeting": "hello"},
+	}
+
+	log := &appLog{}
+	a := &lifecycleApp{name: "a", log: log}
+	b := &lifecycleApp{name: "b", log: log}
+
+	h, err := startNewHost(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, app := range []arc.App{a, b} {
+		if err = h.RegisterApp(app); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// An App can only be registered once, and one that fails to start isn't registered
+	if err = h.RegisterApp(&lifecycleApp{name: "a", log: log}); err == nil {
+		t.Fatal("expected duplicate AppURI to fail")
+	}
+	failing := &lifecycleApp{name: "c", log: log, startErr: errors.New("failed")}
+	if err = h.RegisterApp(failing); err == nil {
+		t.Fatal("expected failed start to fail registration")
+	}
+	if n := len(h.HostStatus().Apps); n != 2 {
+		t.Fatalf("expected 2 registered apps, got %d", n)
+	}
+	if _, err = h.SelectAppForSchema(&arc.AttrSchema{AttrModelURI: "test/c"}); err == nil {
+		t.Fatal("expected failed app to not be selected")
+	}
+
+	// Each App's storage is private to it
+	if err = a.ctx.PutAppValue([]byte("key"), []byte("a-value")); err != nil {
+		t.Fatal(err)
+	}
+	if val, err := b.ctx.GetAppValue([]byte("key")); err != nil || val != nil {
+		t.Fatalf("expected no value for b, got %q (%v)", val, err)
+	}
+
+	// Apps are stopped in the reverse order they were registered
+	h.Close()
+	<-h.Done()
+	want := []string{"start a hello", "start b ", "stop b ", "stop a a-value"}
+	if len(log.events) != len(want) {
+		t.Fatalf("expected %v, got %v", want, log.events)
+	}
+	for i := range want {
+		if log.events[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, log.events)
+		}
+	}
+
+	// ... and an App's storage persists
+	h, err = startNewHost(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		h.Close()
+		<-h.Done()
+	}()
+	a = &lifecycleApp{name: "a", log: log}
+	if err = h.RegisterApp(a); err != nil {
+		t.Fatal(err)
+	}
+	if val, err := a.ctx.GetAppValue([]byte("key")); err != nil || string(val) != "a-value" {
+		t.Fatalf("expected stored value, got %q (%v)", val, err)
+	}
+	if err = a.ctx.PutAppValue([]byte("key"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if val, _ := a.ctx.GetAppValue([]byte("key")); val != nil {
+		t.Fatalf("expected value to be removed, got %q", val)
+	}
+}
+
+// versionedApp is an App with the given AppURI that handles the given data models.
+type versionedApp struct {
+	appURI string
+	models []string
+}
+
+func (app *versionedApp) AppURI() string {
+	return app.appURI
+}
+
+func (app *versionedApp) AttrModelURIs() []string {
+	return app.models
+}
+
+func (app *versionedApp) StartApp(ctx arc.AppContext) error {
+	return nil
+}
+
+func (app *versionedApp) StopApp() {
+}
+
+func (app *versionedApp) ResolveRequest(req *arc.CellReq) error {
+	return arc.ErrCode_Unimplemented.Error("not pinnable")
+}
+
+func TestAppVersions(t *testing.T) {
+	v1_2 := &versionedApp{"test.arc.tools/vibe.app/v1.2.0", []string{"test/vibe"}}
+	v1_10 := &versionedApp{"test.arc.tools/vibe.app/v1.10.3", []string{"test/vibe"}}
+	v2 := &versionedApp{"test.arc.tools/vibe.app/v2.0.1", []string{"test/vibe"}}
+	h := startTestHost(t, v1_10, v2, v1_2)
+
+	for appURI, want := range map[string]arc.App{
+		".":                              v2,
+		"test.arc.tools/vibe.app":        v2,
+		"test.arc.tools/vibe.app/v1":     v1_10,
+		"vibe.app/v1":                    v1_10,
+		"vibe.app/v1.2":                  v1_2,
+		"test.arc.tools/vibe.app/v1.2.0": v1_2,
+	} {
+		app, err := h.SelectAppForSchema(&arc.AttrSchema{AppURI: appURI, AttrModelURI: "test/vibe"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if app != want {
+			t.Errorf("AppURI %q: expected %q, got %q", appURI, want.AppURI(), app.AppURI())
+		}
+	}
+
+	// An AppURI that matches no registered App doesn't fall back to the data model
+	for _, appURI := range []string{
+		"test.arc.tools/vibe.app/v3",
+		"test.arc.tools/other.app/v1.2.0",
+	} {
+		_, err := h.SelectAppForSchema(&arc.AttrSchema{AppURI: appURI, AttrModelURI: "test/vibe"})
+		if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_AppNotFound {
+			t.Errorf("AppURI %q: expected ErrCode_AppNotFound, got %v", appURI, err)
+		}
+	}
+
+	// A different App can't handle a data model already handled
+	err := h.RegisterApp(&versionedApp{"test.arc.tools/other.app/v1.0.0", []string{"test/other", "test/vibe"}})
+	if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_AppConflict {
+		t.Fatalf("expected ErrCode_AppConflict, got %v", err)
+	}
+	if _, err = h.SelectAppForSchema(&arc.AttrSchema{AttrModelURI: "test/other"}); err == nil {
+		t.Fatal("expected conflicting App to not be registered")
+	}
+
+	// Once the newest version stops, the next newest is selected
+	h.appsMu.RLock()
+	inst := h.appsByURI[v2.appURI]
+	h.appsMu.RUnlock()
+	inst.Close()
+	<-inst.Done()
+	app, err := h.SelectAppForSchema(&arc.AttrSchema{AttrModelURI: "test/vibe"})
+	if err != nil || app != v1_10 {
+		t.Fatalf("expected %q, got %v (%v)", v1_10.appURI, app, err)
+	}
+}
@@ -0,0 +1,332 @@
+package host
+
+import (
+	"encoding/binary"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/symbol"
+	"github.com/dgraph-io/badger/v3"
+)
+
+// Planet db key prefixes (symbol.Table reserves 0xFC and above)
+const (
+	kCellStore   = byte(0xC1) // cell attr store (see below)
+	kUserSeats   = byte(0xF1) // user record table
+	kPlanetRoles = byte(0xF2) // roles granted to users (see planetRoles.go)
+	kAppStore    = byte(0xF3) // private App storage (see apps.go)
+	kUserPlanets = byte(0xF4) // planets created by each user (see planets.go)
+)
+
+// A cell maps to its current state where each attr is stored under its own key:
+//
+//	kCellStore+CellID+AttrSym           => Msg  (SeriesType_Fixed)
+//	kCellStore+CellID+AttrSym+SI+FromID => Msg  (all other SeriesTypes)
+//
+// CellID, SI, and FromID are big endian uint64s (with the SI sign bit flipped) so that keys sort by SI.
+// AttrSym is the planet symbol for "{AttrModelURI}/{AttrURI}" since client AttrIDs are only valid within a session.
+// Stored Msgs have AttrID zeroed, which is reassigned (via the reader's AttrSchema) when read.
+
+// attrEntry is a resolved PushAttr Msg ready to be written to the cell store.
+type attrEntry struct {
+	attrSym symbol.ID
+	series  bool
+	msg     *arc.Msg
+}
+
+func appendCellKey(key []byte, cellID arc.CellID) []byte {
+	key = append(key, kCellStore)
+	return appendU64(key, uint64(cellID))
+}
+
+func appendU64(key []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(key, buf[:]...)
+}
+
+// siKey maps a SI to a uint64 that preserves signed ordering.
+func siKey(SI int64) uint64 {
+	return uint64(SI) ^ (1 << 63)
+}
+
+// attrSym returns the planet symbol ID for the given attr (or 0 if the attr has never been stored and autoIssue is false).
+func (pl *planetSess) attrSym(schema *arc.AttrSchema, attr *arc.AttrSpec, autoIssue bool) symbol.ID {
+	var buf [128]byte
+	uri := append(buf[:0], schema.AttrModelURI...)
+	uri = append(uri, '/')
+	uri = append(uri, attr.AttrURI...)
+	return pl.symTable.GetSymbolID(uri, autoIssue)
+}
+
+// ReadCell pushes the stored fixed attrs of the given cell, IAW the given schema.
+func (pl *planetSess) ReadCell(cellID arc.CellID, schema *arc.AttrSchema, push func(msg *arc.Msg) error) error {
+	if schema == nil {
+		return arc.ErrCode_BadSchema.Error("missing schema")
+	}
+
+	dbTx := pl.db.NewTransaction(false)
+	defer dbTx.Discard()
+
+	var keyBuf [64]byte
+	cellKey := appendCellKey(keyBuf[:0], cellID)
+
+	for _, attr := range schema.Attrs {
+		if attr.SeriesType != arc.SeriesType_Fixed {
+			continue
+		}
+		attrSym := pl.attrSym(schema, attr, false)
+		if attrSym == 0 {
+			continue
+		}
+
+		item, err := dbTx.Get(attrSym.WriteTo(cellKey))
+		if err == badger.ErrKeyNotFound {
+			continue
+		}
+		if err == nil {
+			err = unmarshalAndPush(item, attr, push)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteCell atomically stores the given PushAttr msgs for the given cell, IAW the given schema, and pushes them to the subs of the stored cell.
+// Msgs with an AttrID not in the schema are rejected.
+func (pl *planetSess) WriteCell(cellID arc.CellID, schema *arc.AttrSchema, msgs []*arc.Msg) error {
+	if schema == nil {
+		return arc.ErrCode_BadSchema.Error("missing schema")
+	}
+
+	var err error
+	entries := make([]attrEntry, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Op != arc.MsgOp_PushAttr {
+			continue
+		}
+		msg.CellID = cellID.U64()
+		if entries, err = pl.appendEntry(entries, schema, msg); err != nil {
+			return err
+		}
+	}
+
+	if err = pl.commitEntries(entries); err != nil {
+		return err
+	}
+
+	// Push the changes to the cell's subs (if open)
+	pl.cellsMu.Lock()
+	cell := pl.cells[cellKey{CellID: cellID}]
+	pl.cellsMu.Unlock()
+	if cell != nil {
+		batch := arc.NewMsgBatch()
+		for _, entry := range entries {
+			batch.Msgs = append(batch.Msgs, arc.CopyMsg(entry.msg))
+		}
+		cell.publish(&cellTxn{
+			batch:    batch,
+			content:  schema,
+			schemaOf: func(int32) *arc.AttrSchema { return nil },
+		})
+	}
+
+	return nil
+}
+
+// commitTxn atomically stores the given InsertCell and PushAttr msgs, where each InsertCell specifies the CellID and SchemaID of the PushAttr msgs that follow.
+func (pl *planetSess) commitTxn(msgs []*arc.Msg, reg arc.TypeRegistry) error {
+	var (
+		err    error
+		cellID uint64
+		schema *arc.AttrSchema
+	)
+
+	entries := make([]attrEntry, 0, len(msgs))
+	for _, msg := range msgs {
+		switch msg.Op {
+		case arc.MsgOp_InsertCell:
+			if msg.CellID == 0 || msg.ValType != int32(arc.ValType_SchemaID) {
+				return arc.ErrCode_MalformedTx.Error("InsertCell requires a CellID and SchemaID")
+			}
+			if schema, err = reg.GetSchemaByID(int32(msg.ValInt)); err != nil {
+				return err
+			}
+			cellID = msg.CellID
+		case arc.MsgOp_PushAttr:
+			if schema == nil {
+				return arc.ErrCode_MalformedTx.Error("PushAttr must follow InsertCell")
+			}
+			if msg.CellID == 0 {
+				msg.CellID = cellID
+			} else if msg.CellID != cellID {
+				return arc.ErrCode_MalformedTx.Errorf("PushAttr CellID %d does not match InsertCell CellID %d", msg.CellID, cellID)
+			}
+			if entries, err = pl.appendEntry(entries, schema, msg); err != nil {
+				return err
+			}
+		default:
+			return arc.ErrCode_MalformedTx.Errorf("unexpected txn MsgOp: %v", msg.Op)
+		}
+	}
+
+	if len(entries) == 0 {
+		return arc.ErrCode_NothingToCommit.Error("txn contains no attrs")
+	}
+	return pl.commitEntries(entries)
+}
+
+// appendEntry resolves the given PushAttr msg via the given schema and appends it to entries.
+func (pl *planetSess) appendEntry(entries []attrEntry, schema *arc.AttrSchema, msg *arc.Msg) ([]attrEntry, error) {
+	attr := schema.LookupAttrByID(msg.AttrID, msg.SI)
+	if attr == nil {
+		return entries, arc.ErrCode_MalformedTx.Errorf("AttrID %d not found in schema %q", msg.AttrID, schema.SchemaDesc())
+	}
+	entries = append(entries, attrEntry{
+		attrSym: pl.attrSym(schema, attr, true),
+		series:  attr.SeriesType != arc.SeriesType_Fixed,
+		msg:     msg,
+	})
+	return entries, nil
+}
+
+// commitEntries writes the given entries in a single db txn.
+func (pl *planetSess) commitEntries(entries []attrEntry) error {
+	dbTx := pl.db.NewTransaction(true)
+	defer dbTx.Discard()
+
+	for _, entry := range entries {
+		src := entry.msg
+		key := appendCellKey(make([]byte, 0, 32), arc.CellID(src.CellID))
+		key = entry.attrSym.WriteTo(key)
+		if entry.series {
+			key = appendU64(key, siKey(src.SI))
+			key = appendU64(key, src.FromID)
+		}
+
+		stored := arc.Msg{
+			Op:      arc.MsgOp_PushAttr,
+			CellID:  src.CellID,
+			FromID:  src.FromID,
+			SI:      src.SI,
+			ValType: src.ValType,
+			ValBuf:  src.ValBuf,
+			ValInt:  src.ValInt,
+		}
+		val, err := stored.Marshal()
+		if err == nil {
+			err = dbTx.Set(key, val)
+		}
+		if err != nil {
+			return arc.ErrCode_CommitFailed.Wrap(err)
+		}
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return arc.ErrCode_CommitFailed.Wrap(err)
+	}
+	return nil
+}
+
+// readSeries pushes the stored items of the given series attr within the given range, newest to oldest.
+// If limit > 0, at most limit items are pushed.
+// Returns the range actually read, which is narrower than the given range if the limit was reached.
+func (pl *planetSess) readSeries(
+	cellID arc.CellID,
+	schema *arc.AttrSchema,
+	attr *arc.AttrSpec,
+	r Range,
+	limit uint64,
+	push func(msg *arc.Msg) error,
+) (Range, error) {
+
+	attrSym := pl.attrSym(schema, attr, false)
+	if attrSym == 0 {
+		return r, nil
+	}
+
+	dbTx := pl.db.NewTransaction(false)
+	defer dbTx.Discard()
+
+	var keyBuf [64]byte
+	prefix := attrSym.WriteTo(appendCellKey(keyBuf[:0], cellID))
+	N := len(prefix)
+
+	// Seek to the last key having SI == r.R (and FromID < r.FromR if set)
+	seekFrom := ^uint64(0)
+	if r.FromR != 0 {
+		seekFrom = r.FromR - 1
+	}
+	seekTo := appendU64(prefix, siKey(r.R))
+	seekTo = appendU64(seekTo, seekFrom)
+
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = true
+	opts.Prefix = prefix[:N:N]
+	itr := dbTx.NewIterator(opts)
+	defer itr.Close()
+
+	var (
+		count    uint64
+		lastSI   int64
+		lastFrom uint64
+	)
+	for itr.Seek(seekTo); itr.Valid(); itr.Next() {
+		item := itr.Item()
+		key := item.Key()
+		if len(key) != N+16 {
+			continue // not a series entry
+		}
+		SI := int64(binary.BigEndian.Uint64(key[N:]) ^ (1 << 63))
+		if SI < r.L {
+			break
+		}
+
+		// Items sharing a SI may straddle the limit, so the range read ends at the last item pushed
+		if limit > 0 && count == limit {
+			r.L, r.FromL = lastSI, lastFrom
+			break
+		}
+		if err := unmarshalAndPush(item, attr, push); err != nil {
+			return r, err
+		}
+		lastSI, lastFrom = SI, binary.BigEndian.Uint64(key[N+8:])
+		count++
+	}
+
+	return r, nil
+}
+
+func unmarshalAndPush(item *badger.Item, attr *arc.AttrSpec, push func(msg *arc.Msg) error) error {
+	msg := arc.NewMsg()
+	err := item.Value(func(val []byte) error {
+		return msg.Unmarshal(val)
+	})
+	if err != nil {
+		msg.Reclaim()
+		return arc.ErrCode_DataFailure.Errorf("failed to read stored attr %q: %v", attr.AttrURI, err)
+	}
+
+	msg.AttrID = attr.AttrID
+	if attr.SeriesType == arc.SeriesType_Fixed {
+		msg.SI = attr.BoundSI
+	}
+	if attr.ValTypeID != 0 {
+		msg.ValType = int32(attr.ValTypeID)
+	}
+	return push(msg)
+}
+
+// storedCell serves cell state from the planet's cell store.
+// It is the AppCell of a pin whose App does not assign CellReq.PinnedCell, or of a pinned cell whose data model no App handles
+// (e.g. a cell a client committed via MsgOp_Commit).
+type storedCell struct {
+	pl *planetSess
+}
+
+func (cell *storedCell) PushCellState(req *arc.CellReq) error {
+	req.PushInsertCell(req.PinCell, req.ContentSchema)
+	return cell.pl.ReadCell(req.PinCell, req.ContentSchema, req.PushMsg)
+}
@@ -0,0 +1,77 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// insertCell returns an InsertCell msg for the given cell having the given schema.
+func insertCell(cellID uint64, schema *arc.AttrSchema) *arc.Msg {
+	msg := arc.NewMsg()
+	msg.CellID = cellID
+	msg.Op = arc.MsgOp_InsertCell
+	msg.ValType = int32(arc.ValType_SchemaID)
+	msg.ValInt = int64(schema.SchemaID)
+	return msg
+}
+
+// pushAttr returns a PushAttr msg setting the given attr of the given cell.
+func pushAttr(cellID uint64, attrID int32, val string) *arc.Msg {
+	msg := arc.NewMsg()
+	msg.CellID = cellID
+	msg.Op = arc.MsgOp_PushAttr
+	msg.AttrID = attrID
+	msg.SetVal(val)
+	return msg
+}
+
+func TestCommitThenPin(t *testing.T) {
+	h := startTestHost(t)
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.register(noteSchema)
+
+	const cellID = 1<<40 + 1
+	err := ts.commit(
+		insertCell(cellID, noteSchema),
+		pushAttr(cellID, 1, "hello"),
+		pushAttr(cellID, 2, "world"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No App handles "test/note", so the committed cell is served from the cell store
+	reqID, msgs, err := ts.pin(&arc.PinReq{
+		PinCell:       cellID,
+		ContentSchema: noteSchema.SchemaID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals := attrVals(msgs, cellID); vals[1] != "hello" || vals[2] != "world" {
+		t.Fatalf("got attrs %v", vals)
+	}
+
+	// Subsequent commits are pushed to the open pin
+	err = ts.commit(
+		insertCell(cellID, noteSchema),
+		pushAttr(cellID, 1, "hello again"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pushed []*arc.Msg
+	for msg := ts.recv(reqID); msg.Op != arc.MsgOp_Commit; msg = ts.recv(reqID) {
+		pushed = append(pushed, msg)
+	}
+	if vals := attrVals(pushed, cellID); vals[1] != "hello again" {
+		t.Fatalf("got pushed attrs %v", vals)
+	}
+
+	// A cell that no App handles can only be pinned by CellID
+	if _, _, err = ts.pin(&arc.PinReq{ContentSchema: noteSchema.SchemaID}); err == nil {
+		t.Fatal("expected pin without an App or CellID to fail")
+	}
+}
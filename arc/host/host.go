@@ -1,7 +1,10 @@
 package host
 
 import (
+	"bytes"
+	"crypto/rand"
 	"fmt"
+	"math"
 	"os"
 	"path"
 	"sync"
@@ -9,6 +12,9 @@ import (
 	"time"
 
 	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/ski"
+	_ "github.com/arcspace/go-arcspace/ski/ed25519" // login signing kits
+	_ "github.com/arcspace/go-arcspace/ski/nacl"
 	"github.com/arcspace/go-arcspace/symbol"
 	"github.com/arcspace/go-cedar/bufs"
 	"github.com/arcspace/go-cedar/process"
@@ -18,14 +24,25 @@ import (
 
 type host struct {
 	process.Context
-	opts HostOpts
+	opts   HostOpts     // fields changed by reloadOpts are read via getOpts
+	optsMu sync.RWMutex // protects opts once started
 
 	homePlanetID uint64
 	home         *planetSess // Home planet of this host
-	appsByURI    map[string]arc.App
-	appsByModel  map[string]arc.App
+	apps         []*appInst  // registered Apps in the order registered
+	appsByURI    map[string]*appInst
+	appsByModel  map[string][]*appInst // newest version first
+	appsMu       sync.RWMutex          // protects apps, appsByURI, and appsByModel
 	plSess       map[uint64]*planetSess
 	plMu         sync.RWMutex
+	usersMu      sync.Mutex             // serializes user login and creation
+	subStats     arc.SubQueueStats      // updated atomically
+	sessions     map[*hostSess]struct{} // open sessions
+	resumable    map[string]*hostSess   // logged in sessions by resume token
+	sessMu       sync.Mutex             // protects sessions and resumable
+	nextSessID   uint64                 // updated atomically
+	statusCh     chan struct{}          // closed once HostStatus changes (nil if not watched)
+	statusMu     sync.Mutex             // protects statusCh
 }
 
 const (
@@ -43,12 +60,15 @@ func startNewHost(opts HostOpts) (arc.Host, error) {
 	if opts.CachePath, err = utils.ExpandAndCheckPath(opts.CachePath, true); err != nil {
 		return nil, err
 	}
+	opts.applyDefaults()
 
 	host := &host{
 		opts:        opts,
-		appsByURI:   make(map[string]arc.App),
-		appsByModel: make(map[string]arc.App),
+		appsByURI:   make(map[string]*appInst),
+		appsByModel: make(map[string][]*appInst),
 		plSess:      make(map[uint64]*planetSess),
+		sessions:    make(map[*hostSess]struct{}),
+		resumable:   make(map[string]*hostSess),
 	}
 
 	// err = host.loadSeat()
@@ -65,6 +85,7 @@ func startNewHost(opts HostOpts) (arc.Host, error) {
 	host.Context, err = process.Start(&process.Task{
 		Label:     host.opts.Label,
 		IdleClose: time.Nanosecond,
+		OnClosing: host.stopApps,
 		OnClosed: func() {
 			host.Info(1, "arc.Host shutdown complete")
 		},
@@ -82,6 +103,30 @@ func startNewHost(opts HostOpts) (arc.Host, error) {
 	return host, nil
 }
 
+// getOpts returns the opts currently in effect.
+func (host *host) getOpts() HostOpts {
+	host.optsMu.RLock()
+	defer host.optsMu.RUnlock()
+	return host.opts
+}
+
+func (host *host) reloadOpts(opts HostOpts) {
+	opts.applyDefaults()
+
+	host.optsMu.Lock()
+	defer host.optsMu.Unlock()
+
+	host.opts.MaxTxnMsgs = opts.MaxTxnMsgs
+	host.opts.MaxOpenTxns = opts.MaxOpenTxns
+	host.opts.MaxPinResolves = opts.MaxPinResolves
+	host.opts.MaxQueuedPins = opts.MaxQueuedPins
+	host.opts.SubQueueSize = opts.SubQueueSize
+	host.opts.SubOverflow = opts.SubOverflow
+	host.opts.ResumeGrace = opts.ResumeGrace
+	host.opts.CellIdleClose = opts.CellIdleClose
+	host.opts.PlanetIdleClose = opts.PlanetIdleClose
+}
+
 func (host *host) mountHomePlanet() error {
 	var err error
 
@@ -200,12 +245,10 @@ func (host *host) mountPlanet(
 	}
 
 	var fsName string
-	if planetID == host.homePlanetID {
-		fsName = "HostHomePlanet"
-	} else if planetID != 0 {
-		fsName = string(host.home.LookupID(planetID))
+	if planetID != 0 {
+		fsName = host.planetFSName(planetID)
 		if len(fsName) == 0 && genesis == nil {
-			return nil, arc.ErrCode_PlanetFailure.Errorf("planet ID=%v failed to resolve", planetID)
+			return nil, arc.ErrCode_PlanetNotFound.Errorf("planet ID=%v not found", planetID)
 		}
 	} else {
 
@@ -219,17 +262,21 @@ func (host *host) mountPlanet(
 	}
 
 	pl = &planetSess{
+		host:     host,
 		planetID: planetID,
 		dbPath:   path.Join(host.opts.StatePath, string(fsName)),
-		cells:    make(map[arc.CellID]*cellInst),
+		cells:    make(map[cellKey]*cellInst),
 		//newReqs:  make(chan *openReq, 1),
 	}
 
-	// The db should already exist if opening and vice versa
+	// The db should already exist if opening and vice versa (except for the host's home planet, created on first run)
 	_, err := os.Stat(pl.dbPath)
 	if genesis != nil && err == nil {
 		return nil, arc.ErrCode_PlanetFailure.Error("planet db already exists")
 	}
+	if genesis == nil && os.IsNotExist(err) && planetID != host.homePlanetID {
+		return nil, arc.ErrCode_PlanetNotFound.Errorf("planet ID=%v not found", planetID)
+	}
 
 	task := &process.Task{
 		Label: fsName,
@@ -242,8 +289,17 @@ func (host *host) mountPlanet(
 			}
 			return pl.onStart(opts)
 		},
-		OnRun:    pl.onRun,
-		OnClosed: pl.onClosed,
+		OnRun: pl.onRun,
+		OnClosed: func() {
+			// Once closed (e.g. when idle), the planet must be mounted again to be accessed
+			host.plMu.Lock()
+			if host.plSess[pl.planetID] == pl {
+				delete(host.plSess, pl.planetID)
+			}
+			host.plMu.Unlock()
+			host.statusChanged()
+			pl.onClosed()
+		},
 	}
 
 	// Make sure host.home closes last, so make all mounted planets subs
@@ -251,7 +307,7 @@ func (host *host) mountPlanet(
 		host.home = pl
 		pl.Context, err = host.StartChild(task)
 	} else {
-		task.IdleClose = 120 * time.Second
+		task.IdleClose = host.getOpts().PlanetIdleClose
 		pl.Context, err = host.home.StartChild(task)
 	}
 	if err != nil {
@@ -264,66 +320,61 @@ func (host *host) mountPlanet(
 	//
 
 	host.plSess[planetID] = pl
+	host.statusChanged()
 	return pl, nil
 }
 
-func (host *host) RegisterApp(app arc.App) error {
-	appURI := app.AppURI()
-	if appURI == "" {
-		return arc.ErrCode_InvalidURI.Error("invalid app URI")
-	}
-	host.appsByURI[appURI] = app
-
-	for _, modelURI := range app.AttrModelURIs() {
-		if modelURI != "" {
-			host.appsByModel[modelURI] = app
-		}
-	}
-	return nil
+func (host *host) HostPlanet() arc.Planet {
+	return host.home
 }
 
-func (host *host) SelectAppForSchema(schema *arc.AttrSchema) (arc.App, error) {
-	if schema == nil {
-		return nil, arc.ErrCode_AppNotFound.Errorf("missing schema")
-	}
-
-	if schema.AppURI != arc.DefaultAppForDataModel {
-		app := host.appsByURI[schema.AppURI]
-		if app != nil {
-			return app, nil
-		}
+func (host *host) SubQueueStats() arc.SubQueueStats {
+	return arc.SubQueueStats{
+		Coalesced:   atomic.LoadUint64(&host.subStats.Coalesced),
+		DroppedSubs: atomic.LoadUint64(&host.subStats.DroppedSubs),
+		Disconnects: atomic.LoadUint64(&host.subStats.Disconnects),
 	}
-
-	app := host.appsByModel[schema.AttrModelURI]
-	if app == nil {
-		return nil, arc.ErrCode_AppNotFound.Errorf("App not found for schema: %s", schema.SchemaDesc())
-	}
-
-	return app, nil
-}
-
-func (host *host) HostPlanet() arc.Planet {
-	return host.home
 }
 
 func (host *host) StartNewSession(from arc.HostService, via arc.ServerStream) (arc.HostSession, error) {
+	opts := host.getOpts()
 	sess := &hostSess{
 		host:         host,
+		sessID:       atomic.AddUint64(&host.nextSessID, 1),
 		TypeRegistry: arc.NewTypeRegistry(host.home.symTable),
 		msgsIn:       make(chan *arc.Msg),
 		msgsOut:      make(chan *arc.Msg, 8),
+		pinQueue:     make(chan *openReq, opts.MaxQueuedPins),
 		openReqs:     make(map[uint64]*openReq),
+		txns:         make(map[uint64]*pendingTxn),
 	}
+	link := newSessLink(via, sess)
+	sess.link = link
+
+	host.sessMu.Lock()
+	host.sessions[sess] = struct{}{}
+	host.sessMu.Unlock()
+	host.statusChanged()
 
 	var err error
 	sess.Context, err = host.home.StartChild(&process.Task{
 		Label:     "HostSession",
 		IdleClose: time.Nanosecond,
 		OnRun: func(ctx process.Context) {
+
+			// As children, the resolve workers close before this session does
+			for i := 0; i < opts.MaxPinResolves; i++ {
+				ctx.StartChild(&process.Task{
+					Label: fmt.Sprint("resolvePins_", i),
+					OnRun: sess.resolvePins,
+				})
+			}
 			sess.consumeInbox()
 		},
+		OnClosed: sess.onClosed,
 	})
 	if err != nil {
+		sess.onClosed()
 		return nil, err
 	}
 
@@ -333,68 +384,19 @@ func (host *host) StartNewSession(from arc.HostService, via arc.ServerStream) (a
 	// We start them as children of the HostService, not the HostSession since we want to keep the stream running until hostSess completes closing.
 	//
 	// Possible paths:
-	//   - If stream returns ServerStreamClosed (or errors out), initiate hostSess.Close()
-	//   - If hostSess.Close() is called externally, when close is complete, <-sessDone (below) will close the steam.
+	//   - If stream returns ServerStreamClosed (or errors out), the link detaches and hostSess either awaits resumption or closes.
+	//   - If hostSess.Close() is called externally, when close is complete, the send pump will close the steam.
+	//   - If another hostSess is resumed over this stream, the link is retargeted to it (see hostSess.resume).
 	from.StartChild(&process.Task{
 		Label:     fmt.Sprint(via.Desc(), " <- ", hostSessDesc),
 		IdleClose: time.Nanosecond,
-		OnRun: func(ctx process.Context) {
-			sessDone := sess.Done()
-
-			// Forward outgoing msgs from host to stream outlet until the host session says its completely done.
-			for running := true; running; {
-				select {
-				case msg := <-sess.msgsOut:
-					if msg != nil {
-						var err error
-						if flags := msg.Flags; flags&arc.MsgFlags_ValBufShared != 0 {
-							msg.Flags = flags &^ arc.MsgFlags_ValBufShared
-							err = via.SendMsg(msg)
-							msg.Flags = flags
-						} else {
-							err = via.SendMsg(msg)
-						}
-						msg.Reclaim()
-						msg = nil
-						if err != nil /*&& err != ServerStreamClosed */ {
-							ctx.Warnf("ServerStream Send() err: %v", err)
-						}
-					}
-				case <-sessDone:
-					ctx.Info(2, "<-hostDone")
-					via.Close()
-					running = false
-				}
-			}
-		},
+		OnRun:     link.sendMsgs,
 	})
 
 	from.StartChild(&process.Task{
 		Label:     fmt.Sprint(via.Desc(), " -> ", hostSessDesc),
 		IdleClose: time.Nanosecond,
-		OnRun: func(ctx process.Context) {
-			sessDone := sess.Done()
-
-			for running := true; running; {
-				msg, err := via.RecvMsg()
-				if err != nil {
-					if err == arc.ErrStreamClosed {
-						ctx.Info(2, "ServerStream closed")
-					} else {
-						ctx.Warnf("RecvMsg() error: %v", err)
-					}
-					sess.Context.Close()
-					running = false
-				} else if msg != nil {
-					select {
-					case sess.msgsIn <- msg:
-					case <-sessDone:
-						ctx.Info(2, "hostSession done")
-						running = false
-					}
-				}
-			}
-		},
+		OnRun:     link.recvMsgs,
 	})
 
 	return sess, nil
@@ -406,23 +408,35 @@ type hostSess struct {
 	arc.TypeRegistry
 
 	user       arc.User
-	host       *host               // parent host
-	msgsIn     chan *arc.Msg       // msgs inbound to this hostSess
-	msgsOut    chan *arc.Msg       // msgs outbound from this hostSess
-	openReqs   map[uint64]*openReq // ReqID maps to an open request.
-	openReqsMu sync.Mutex          // protects openReqs
+	host       *host                  // parent host
+	sessID     uint64                 // issued by the parent host
+	userPlanet uint64                 // home planet ID of the logged in user (updated atomically)
+	msgsIn     chan *arc.Msg          // msgs inbound to this hostSess
+	msgsOut    chan *arc.Msg          // msgs outbound from this hostSess
+	openReqs   map[uint64]*openReq    // ReqID maps to an open request.
+	openReqsMu sync.Mutex             // protects openReqs
+	txns       map[uint64]*pendingTxn // client txns pending commit (only accessed by consumeInbox)
+	challenge  *loginChallenge        // login pending the client's signed reply (only accessed by consumeInbox)
+	pinQueue   chan *openReq          // pins awaiting a free resolve worker (see resolvePins)
+
+	linkMu      sync.Mutex  // protects the fields below
+	link        *sessLink   // stream currently carrying this session's msgs (nil while detached)
+	resumeToken []byte      // issued at login, allowing this session to be resumed over another stream
+	unsent      []*arc.Msg  // msgs that failed to send, sent first once resumed
+	grace       *time.Timer // closes this session if it isn't resumed in time (set while detached)
 }
 
 // planetSess represents a "mounted" planet (a Cell database), allowing it to be accessed, served, and updated.
 type planetSess struct {
 	process.Context
 
-	symTable symbol.Table             // each planet has separate symbol tables
-	planetID uint64                   // symbol ID (as known by the host's symbol table)
-	dbPath   string                   // local pathname to db
-	db       *badger.DB               // db access
-	cells    map[arc.CellID]*cellInst // cells that recently have one or more active cells (subscriptions)
-	cellsMu  sync.Mutex               // cells mutex
+	host     *host
+	symTable symbol.Table          // each planet has separate symbol tables
+	planetID uint64                // symbol ID (as known by the host's symbol table)
+	dbPath   string                // local pathname to db
+	db       *badger.DB            // db access
+	cells    map[cellKey]*cellInst // cells that recently have one or more active cells (subscriptions)
+	cellsMu  sync.Mutex            // cells mutex
 }
 
 type openReq struct {
@@ -430,9 +444,18 @@ type openReq struct {
 
 	sess   *hostSess
 	cell   *cellInst
+	outbox *subQueue // msgs queued for the client
 	cancel chan struct{}
 	closed uint32
-	next   *openReq // single linked list of same-cell reqs
+	info   arc.ReqInfo              // what this req pins, set once resolved (protected by sess.openReqsMu)
+	pinned bool                     // set once cell state has been pushed (only accessed by the cell's goroutine)
+	ranges map[*arc.AttrSpec]Ranges // pinned SI ranges of series attrs (only accessed by the cell's goroutine)
+	next   *openReq                 // single linked list of same-cell reqs
+
+	resolveMu sync.Mutex // protects the fields below (and orders them with closing)
+	resolving bool       // set while this req's pin is queued or resolving (see resolvePin)
+	deferred  []*arc.Msg // msgs for this req received while resolving, processed in order once resolved
+	waiting   []*openReq // child reqs that resolve once this req has (or failed to)
 
 	//echo   arc.CellSub
 	// err    error
@@ -447,45 +470,208 @@ func (req *openReq) Req() *arc.CellReq {
 	return &req.CellReq
 }
 
-func (req *openReq) PushUpdate(batch *arc.MsgBatch) error {
-	if atomic.LoadUint32(&req.closed) != 0 {
+func (req *openReq) PublishUpdate(batch *arc.MsgBatch) error {
+	cell := req.cell
+	if cell == nil || atomic.LoadUint32(&req.closed) != 0 {
+		batch.Reclaim()
+		return arc.ErrCode_ReqCanceled.Error("request closed")
+	}
+
+	return cell.publish(&cellTxn{
+		batch:    batch,
+		content:  req.ContentSchema,
+		schemaOf: req.schemaOf,
+	})
+}
+
+func (req *openReq) Done() <-chan struct{} {
+	return req.cancel
+}
+
+// schemaOf returns the schema of this req with the given SchemaID (or nil if not found).
+func (req *openReq) schemaOf(schemaID int32) *arc.AttrSchema {
+	if req.ContentSchema != nil && req.ContentSchema.SchemaID == schemaID {
+		return req.ContentSchema
+	}
+	for _, schema := range req.ChildSchemas {
+		if schema.SchemaID == schemaID {
+			return schema
+		}
+	}
+	return nil
+}
+
+// pushTxn queues the given txn for the client (followed by a checkpoint), remapping AttrIDs and SchemaIDs to this req's schemas.
+// Cells and attrs that this req's schemas don't specify are skipped.
+// Since this is called on the cell's goroutine, it never blocks (see pushUpdate).
+func (req *openReq) pushTxn(tx *cellTxn) error {
+	if !req.pinned || atomic.LoadUint32(&req.closed) != 0 {
 		return nil
 	}
 
-	// TODO / FUTURE
-	// Instead of every req running its own goroutine, just have one that round robbins
-	// based on a 'wakeup' channel saying which req sub is actively pushing msgs.
-	//
-	// This also make app sb life easier since msgs are just pushed as they're made rather than building batches
-	// and then sending them all to this for one big PushUpdate.
-	for _, src := range batch.Msgs {
-		msg := arc.CopyMsg(src)
-		err := req.PushMsg(msg)
-		if err != nil {
-			return err
+	var (
+		src, dst *arc.AttrSchema
+		cellID   uint64
+		pinCell  = req.PinCell.U64()
+		msgs     []*arc.Msg
+	)
+	for _, m := range tx.batch.Msgs {
+		msgCell := m.CellID
+		if msgCell == 0 {
+			msgCell = pinCell
+		}
+
+		switch m.Op {
+		case arc.MsgOp_InsertCell:
+			cellID = msgCell
+			src = tx.schemaOf(int32(m.ValInt))
+			dst = nil
+			if src == nil {
+				continue
+			}
+
+			// The client already has the pinned cell, so only its attrs are pushed
+			if cellID == pinCell {
+				dst = req.ContentSchema
+				continue
+			}
+			if dst = req.GetChildSchema(src.AttrModelURI); dst == nil {
+				continue
+			}
+			msg := arc.CopyMsg(m)
+			msg.CellID = cellID
+			msg.ValInt = int64(dst.SchemaID)
+			msgs = append(msgs, msg)
+
+		case arc.MsgOp_PushAttr:
+			if msgCell != cellID {
+				cellID = msgCell
+				src, dst = nil, nil
+				if cellID == pinCell {
+					src, dst = tx.content, req.ContentSchema
+				}
+			}
+			if src == nil || dst == nil {
+				continue
+			}
+			srcAttr := src.LookupAttrByID(m.AttrID, m.SI)
+			if srcAttr == nil {
+				continue
+			}
+			dstAttr := dst.LookupAttr(srcAttr.AttrURI)
+			if dstAttr == nil {
+				continue
+			}
+
+			// Series attr items are only pushed if they fall within a pinned range
+			if dstAttr.SeriesType != arc.SeriesType_Fixed {
+				if !req.ranges[dstAttr].Contains(m.SI, m.FromID) {
+					continue
+				}
+			}
+			msg := arc.CopyMsg(m)
+			msg.CellID = cellID
+			msg.AttrID = dstAttr.AttrID
+			if dstAttr.SeriesType == arc.SeriesType_Fixed {
+				msg.SI = dstAttr.BoundSI
+			}
+			if dstAttr.ValTypeID != 0 {
+				msg.ValType = int32(dstAttr.ValTypeID)
+			}
+			msgs = append(msgs, msg)
 		}
 	}
 
-	return nil
+	checkpoint := arc.NewMsg()
+	checkpoint.Op = arc.MsgOp_Commit
+	checkpoint.CellID = pinCell
+	msgs = append(msgs, checkpoint)
+
+	return req.pushUpdate(msgs)
 }
 
-func (req *openReq) PushMsg(msg *arc.Msg) error {
-	var err error
+var errSubOverflow = arc.ErrCode_ReqCanceled.Error("client not keeping up with updates")
 
-	{
+// pushUpdate queues the given msgs (a live update) for the client without blocking, taking ownership of them.
+// If they don't fit in this req's outbox, the host's SubOverflow policy is applied.
+func (req *openReq) pushUpdate(msgs []*arc.Msg) error {
+	for _, msg := range msgs {
 		msg.ReqID = req.ReqID
+	}
 
-		// If the client backs up, this will back up too which is the desired effect.
-		// Otherwise, something like reading from a db reading would quickly fill up the Msg outbox chan (and have no gain)
-		// Note that we don't need to check on req.cell or req.sess since if either close, all subs will be closed.
-		select {
-		case req.sess.msgsOut <- msg:
-		case <-req.cancel:
-			err = arc.ErrCode_ShuttingDown.Error("request closing")
-		}
+	host := req.sess.host
+	policy := host.getOpts().SubOverflow
+	switch req.outbox.tryPush(msgs, policy == SubOverflow_Coalesce) {
+	case queued:
+		return nil
+	case coalesced:
+		atomic.AddUint64(&host.subStats.Coalesced, 1)
+		return nil
+	case queueClosed:
+		return arc.ErrCode_ShuttingDown.Error("request closing")
 	}
 
-	return err
+	return req.overflowed(policy)
+}
+
+// overflowed applies the given SubOverflow policy (other than coalescing) to this req, whose client isn't keeping up.
+func (req *openReq) overflowed(policy SubOverflow) error {
+	host := req.sess.host
+	if policy == SubOverflow_Disconnect {
+		atomic.AddUint64(&host.subStats.Disconnects, 1)
+		req.sess.Warnf("closing session: ReqID=%d overflowed", req.ReqID)
+		req.sess.Close()
+		return errSubOverflow
+	}
+
+	// The client is sent the close right away, but since the cell's subs may be locked, removing the sub must happen elsewhere
+	atomic.AddUint64(&host.subStats.DroppedSubs, 1)
+	msg := arc.NewMsg()
+	msg.ReqID = req.ReqID
+	msg.Op = arc.MsgOp_CloseReq
+	msg.SetVal(errSubOverflow)
+	req.outbox.close(msg)
+	go func() {
+		req.sess.dropReq(req)
+		req.closeReq(false, nil)
+	}()
+	return errSubOverflow
+}
+
+// pinRange pushes the stored items of the given range and adds it to this req's pinned ranges.
+// Called on the cell's goroutine.
+func (req *openReq) pinRange(cell *cellInst, pin *rangePin) error {
+	pinned, err := cell.pl.readSeries(req.PinCell, req.ContentSchema, pin.attr, pin.rng, pin.limit, req.PushMsg)
+	if err != nil {
+		return err
+	}
+
+	// Separate ranges are kept apart so that items between them (which the client never received) aren't pushed
+	if req.ranges == nil {
+		req.ranges = make(map[*arc.AttrSpec]Ranges)
+	}
+	req.ranges[pin.attr] = req.ranges[pin.attr].Add(pinned)
+	return nil
+}
+
+// PushMsg queues the given msg of the pinned cell's state without blocking, since it's usually called on the cell's goroutine.
+// If the client is so far behind that HostOpts.SnapshotQueueSize msgs of state are queued, the SubOverflow policy is applied
+// (where there's nothing to coalesce the msg with, so the sub is dropped).
+func (req *openReq) PushMsg(msg *arc.Msg) error {
+	msg.ReqID = req.ReqID
+
+	switch req.outbox.pushState(msg) {
+	case queued:
+		return nil
+	case queueClosed:
+		return arc.ErrCode_ShuttingDown.Error("request closing")
+	}
+
+	policy := req.sess.host.getOpts().SubOverflow
+	if policy == SubOverflow_Coalesce {
+		policy = SubOverflow_DropSub
+	}
+	return req.overflowed(policy)
 }
 
 func (req *openReq) closeReq(pushClose bool, msgVal interface{}) {
@@ -493,26 +679,33 @@ func (req *openReq) closeReq(pushClose bool, msgVal interface{}) {
 		return
 	}
 
+	req.resolveMu.Lock()
 	doClose := atomic.CompareAndSwapUint32(&req.closed, 0, 1)
+	resolving := req.resolving
+	req.resolveMu.Unlock()
+
 	if doClose {
 
-		// first, remove this req as a sub if applicable
-		if cell := req.cell; cell != nil {
+		// first, remove this req as a sub if applicable (if still resolving, resolvePin does so once done)
+		if cell := req.cell; cell != nil && !resolving {
 			cell.pl.cancelSub(req)
 		}
 
-		// next, send a close msg to the client
+		// next, queue a close msg for the client (in place of msgs not yet sent)
+		var msg *arc.Msg
 		if pushClose {
-			msg := arc.NewMsg()
+			msg = arc.NewMsg()
+			msg.ReqID = req.ReqID
 			msg.Op = arc.MsgOp_CloseReq
 			if msgVal != nil {
 				msg.SetVal(msgVal)
 			}
-			req.PushMsg(msg)
 		}
+		req.outbox.close(msg)
 
-		// finally, close the cancel chan now that the close msg has been pushed
+		// finally, close the cancel chan now that the close msg has been queued
 		close(req.cancel)
+		req.sess.host.statusChanged()
 	}
 }
 
@@ -525,6 +718,16 @@ func (sess *hostSess) closeReq(reqID uint64, pushClose bool, msgVal interface{})
 	}
 }
 
+// dropReq removes the given req from this session's open reqs if it's still the req open under its ReqID.
+// Unlike closeReq, this can't remove a newer req that the client opened under the same ReqID.
+func (sess *hostSess) dropReq(req *openReq) {
+	sess.openReqsMu.Lock()
+	if sess.openReqs[req.ReqID] == req {
+		sess.openReqs[req.ReqID] = nil
+	}
+	sess.openReqsMu.Unlock()
+}
+
 func (sess *hostSess) pushMsg(reqID uint64, msgOp arc.MsgOp, msgVal interface{}) {
 	msg := arc.NewMsg()
 	msg.ReqID = reqID
@@ -550,11 +753,18 @@ func (sess *hostSess) consumeInbox() {
 		case msg := <-sess.msgsIn:
 			if msg != nil && msg.Op != arc.MsgOp_NoOp {
 				closeReq := true
+				reqID := msg.ReqID
 
 				var err error
 				switch msg.Op {
-				// case arc.MsgOp_PinAttrRange:
-				// 	err = sess.pinAttrRange(msg))
+				case arc.MsgOp_PinAttrRange:
+					if sess.deferUntilResolved(msg) {
+						msg = nil // now owned by the resolving req
+						closeReq = false
+					} else {
+						err = sess.pinAttrRange(msg)
+						closeReq = err != nil
+					}
 				case arc.MsgOp_PinCell:
 					err = sess.pinCell(msg)
 					closeReq = err != nil
@@ -562,13 +772,30 @@ func (sess *hostSess) consumeInbox() {
 					err = sess.resolveAndRegister(msg)
 				case arc.MsgOp_Login:
 					err = sess.login(msg)
+					closeReq = err != nil || sess.user != nil
+				case arc.MsgOp_InsertCell, arc.MsgOp_PushAttr:
+					err = sess.appendToTxn(msg)
+					if err == nil {
+						msg = nil // now owned by the pending txn
+					}
+					closeReq = err != nil
+				case arc.MsgOp_Commit:
+					err = sess.commitTxn(msg)
+					closeReq = err != nil
+				case arc.MsgOp_GrantPlanetRole:
+					err = sess.grantPlanetRole(msg)
+				case arc.MsgOp_CreatePlanet:
+					err = sess.createPlanet(msg)
+				case arc.MsgOp_UnmountPlanet, arc.MsgOp_DeletePlanet:
+					err = sess.unmountPlanet(msg)
 				case arc.MsgOp_CloseReq:
+					sess.discardTxn(reqID)
 				default:
 					err = arc.ErrCode_UnsupportedOp.Errorf("unknown MsgOp: %v", msg.Op)
 				}
 
 				if closeReq {
-					sess.closeReq(msg.ReqID, true, err)
+					sess.closeReq(reqID, true, err)
 				}
 			}
 			msg.Reclaim()
@@ -580,41 +807,171 @@ func (sess *hostSess) consumeInbox() {
 	}
 }
 
-func (host *host) login(msg *arc.Msg) (arc.User, error) {
-	var loginReq arc.LoginReq
-	err := msg.LoadVal(&loginReq)
-	if err != nil {
-		return nil, err
-	}
+// login verifies the given challenge reply was signed by the user's registered key and returns the logged in user.
+// If the user is new, the key in loginReq is registered.
+func (host *host) login(loginReq *arc.LoginReq, nonce []byte, reply *arc.LoginChallenge) (arc.User, error) {
 
 	//
 	// FUTURE: a "user" app would start here and is bound to the userUID on the host's home arc.
 	//
-	seat, err := host.home.getUser(loginReq, true)
-	if err != nil {
+	host.usersMu.Lock()
+	defer host.usersMu.Unlock()
+
+	seat, err := host.home.getUser(*loginReq)
+	if err != nil && err != errUnknownUser {
 		return nil, err
 	}
 
+	// A new user registers the key it logs in with, but an existing seat without a key is refused
+	// rather than trusting whichever key logs in first.
+	pubKey := seat.PubKey
+	if seat.UserID == 0 {
+		pubKey = loginReq.PubKey
+	} else if pubKey == nil {
+		return nil, arc.ErrCode_InvalidLogin.Errorf("user %d has no registered key", seat.UserID)
+	}
+	if err = verifyLogin(pubKey, nonce, reply); err != nil {
+		return nil, err
+	}
+
+	if seat.UserID == 0 {
+		if seat, err = host.newUser(loginReq); err != nil {
+			return nil, err
+		}
+	}
+
 	userPlanet, err := host.getPlanet(seat.HomePlanetID)
 	if err != nil {
 		return nil, err
 	}
 
 	return &user{
+		host: host,
 		home: userPlanet,
+		seat: seat,
+		uid:  append([]byte{}, loginReq.UserUID...),
 	}, nil
 
 }
 
+// newUser creates a seat for the given user along with a new planet that is the user's home planet.
+// Since each user's data is isolated in its own planet db, it can be backed up or deleted on its own.
+func (host *host) newUser(req *arc.LoginReq) (arc.UserSeat, error) {
+	userID := host.home.getUserID(req.UserUID, true)
+	pl, err := host.createPlanet(&arc.PlanetEpoch{
+		CommonName: fmt.Sprintf("User %d", userID),
+	})
+	if err != nil {
+		return arc.UserSeat{}, err
+	}
+
+	seat := arc.UserSeat{
+		UserID:       uint64(userID),
+		HomePlanetID: pl.planetID,
+		PubKey:       req.PubKey,
+	}
+	if err = host.home.putUser(seat); err != nil {
+		return arc.UserSeat{}, err
+	}
+	return seat, nil
+}
+
+// verifyLogin checks that the given challenge reply contains the given nonce signed by the given key.
+func verifyLogin(pubKey *arc.CryptoKey, nonce []byte, reply *arc.LoginChallenge) error {
+	if pubKey == nil || len(pubKey.KeyBytes) == 0 {
+		return arc.ErrCode_InvalidLogin.Error("missing public key")
+	}
+
+	var kitID ski.CryptoKitID
+	switch pubKey.CryptoKitID {
+	case arc.CryptoKit_Signing_NaCl:
+		kitID = ski.CryptoKitID_NaCl
+	case arc.CryptoKit_Signing_ED25519:
+		kitID = ski.CryptoKitID_ED25519
+	default:
+		return arc.ErrCode_InvalidLogin.Errorf("unsupported CryptoKitID %v", pubKey.CryptoKitID)
+	}
+
+	if len(nonce) == 0 || !bytes.Equal(reply.Nonce, nonce) {
+		return arc.ErrCode_InvalidLogin.Error("login challenge mismatch")
+	}
+
+	if err := ski.VerifySignature(kitID, reply.Signature, nonce, pubKey.KeyBytes); err != nil {
+		return arc.ErrCode_InvalidLogin.Errorf("login signature failed: %v", err)
+	}
+
+	return nil
+}
+
+// loginChallenge is a login awaiting the client's signed reply.
+type loginChallenge struct {
+	reqID uint64
+	req   arc.LoginReq
+	nonce []byte
+}
+
 func (sess *hostSess) login(msg *arc.Msg) error {
 	if sess.user != nil {
 		return arc.ErrCode_InvalidLogin.Error("already logged in")
 	}
 
-	var err error
-	sess.user, err = sess.host.login(msg)
-	if err != nil {
-		return err
+	switch msg.ValType {
+
+	// Reply with a challenge for the client to sign
+	case int32(arc.ValType_LoginReq):
+		challenge := &loginChallenge{
+			reqID: msg.ReqID,
+			nonce: make([]byte, 32),
+		}
+		if err := msg.LoadVal(&challenge.req); err != nil {
+			return err
+		}
+		if len(challenge.req.ResumeToken) > 0 {
+			return sess.resume(msg.ReqID, challenge.req.ResumeToken)
+		}
+		if _, err := rand.Read(challenge.nonce); err != nil {
+			return arc.ErrCode_InternalErr.Wrap(err)
+		}
+		sess.challenge = challenge
+		sess.pushMsg(msg.ReqID, arc.MsgOp_Login, &arc.LoginChallenge{
+			Nonce: challenge.nonce,
+		})
+
+	// Verify the client's signed reply
+	case int32(arc.ValType_LoginChallenge):
+		challenge := sess.challenge
+		sess.challenge = nil
+		if challenge == nil || challenge.reqID != msg.ReqID {
+			return arc.ErrCode_InvalidLogin.Error("no login challenge issued")
+		}
+
+		var reply arc.LoginChallenge
+		if err := msg.LoadVal(&reply); err != nil {
+			return err
+		}
+
+		var err error
+		sess.user, err = sess.host.login(&challenge.req, challenge.nonce, &reply)
+		if err != nil {
+			return err
+		}
+		atomic.StoreUint64(&sess.userPlanet, sess.user.HomePlanet().PlanetID())
+		sess.host.statusChanged()
+
+		// Issue a token so the client can resume this session if its stream drops
+		if sess.host.getOpts().ResumeGrace > 0 {
+			token := make([]byte, 32)
+			if _, err = rand.Read(token); err != nil {
+				return arc.ErrCode_InternalErr.Wrap(err)
+			}
+			sess.host.addResumable(token, sess)
+			sess.pushMsg(msg.ReqID, arc.MsgOp_Login, &arc.LoginChallenge{
+				ResumeToken: token,
+			})
+		}
+
+	default:
+		return arc.ErrCode_InvalidLogin.Error("expected LoginReq or LoginChallenge")
 	}
 
 	return nil
@@ -634,6 +991,119 @@ func (sess *hostSess) resolveAndRegister(msg *arc.Msg) error {
 	return nil
 }
 
+// pendingTxn is a client txn pending commit.
+type pendingTxn struct {
+	arc.Txn
+	err error // set once the txn has failed (its msgs are discarded and its commit fails with this error)
+}
+
+// appendToTxn adds the given client InsertCell or PushAttr msg to the txn pending under msg.ReqID, taking ownership of msg.
+func (sess *hostSess) appendToTxn(msg *arc.Msg) error {
+	opts := sess.host.getOpts()
+	txn := sess.txns[msg.ReqID]
+	if txn == nil {
+		if req, _ := sess.getReq(msg.ReqID, getReq); req != nil {
+			return arc.ErrCode_InvalidReq.Error("ReqID already in use")
+		}
+		if len(sess.txns) >= opts.MaxOpenTxns {
+			return arc.ErrCode_InvalidReq.Errorf("too many txns pending commit (max %d)", opts.MaxOpenTxns)
+		}
+		txn = &pendingTxn{}
+		sess.txns[msg.ReqID] = txn
+	}
+
+	// Once a txn fails, its remaining msgs are discarded so that only its commit reports the failure
+	if txn.err == nil && len(txn.Msgs) >= opts.MaxTxnMsgs {
+		txn.err = arc.ErrCode_CommitFailed.Errorf("txn exceeds %d msgs", opts.MaxTxnMsgs)
+		reclaimMsgs(txn.Msgs)
+		txn.Msgs = nil
+	}
+	if txn.err != nil {
+		msg.Reclaim()
+		return nil
+	}
+	txn.Msgs = append(txn.Msgs, msg)
+	return nil
+}
+
+// discardTxn discards the txn pending under the given ReqID (if any).
+func (sess *hostSess) discardTxn(reqID uint64) {
+	if txn := sess.txns[reqID]; txn != nil {
+		reclaimMsgs(txn.Msgs)
+		delete(sess.txns, reqID)
+	}
+}
+
+// reclaimMsgs reclaims each of the given msgs.
+func reclaimMsgs(msgs []*arc.Msg) {
+	for _, msg := range msgs {
+		msg.Reclaim()
+	}
+}
+
+// commitTxn commits the txn pending under msg.ReqID (along with the Txn msg value, if present) and replies with MsgOp_Commit.
+func (sess *hostSess) commitTxn(msg *arc.Msg) error {
+	txn := sess.txns[msg.ReqID]
+	delete(sess.txns, msg.ReqID)
+	if txn == nil {
+		txn = &pendingTxn{}
+	}
+	defer func() {
+		reclaimMsgs(txn.Msgs)
+	}()
+
+	err := txn.err
+	if err == nil {
+		err = sess.commitTxnTo(msg, &txn.Txn)
+	}
+	if err != nil {
+		if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_CommitFailed {
+			err = arc.ErrCode_CommitFailed.Wrap(err)
+		}
+		return err
+	}
+
+	sess.pushMsg(msg.ReqID, arc.MsgOp_Commit, nil)
+	return nil
+}
+
+func (sess *hostSess) commitTxnTo(msg *arc.Msg, txn *arc.Txn) error {
+	if sess.user == nil {
+		return arc.ErrCode_InvalidLogin.Error("not logged in")
+	}
+
+	if msg.ValType == int32(arc.ValType_Txn) {
+		var tail arc.Txn
+		if err := msg.LoadVal(&tail); err != nil {
+			return err
+		}
+		txn.PlanetID = tail.PlanetID
+		txn.Msgs = append(txn.Msgs, tail.Msgs...)
+		if max := sess.host.getOpts().MaxTxnMsgs; len(txn.Msgs) > max {
+			return arc.ErrCode_CommitFailed.Errorf("txn exceeds %d msgs", max)
+		}
+	}
+
+	if txn.PlanetID == 0 {
+		txn.PlanetID = sess.user.HomePlanet().PlanetID()
+	}
+	if err := sess.checkRole(txn.PlanetID, arc.PlanetRole_Member); err != nil {
+		return err
+	}
+
+	pl, err := sess.host.getPlanet(txn.PlanetID)
+	if err != nil {
+		return err
+	}
+
+	if err = pl.commitTxn(txn.Msgs, sess.TypeRegistry); err != nil {
+		return err
+	}
+
+	pl.publishTxn(txn.Msgs, sess.TypeRegistry)
+	return nil
+}
+
 type pinVerb int32
 
 const (
@@ -643,6 +1113,10 @@ const (
 )
 
 func (sess *hostSess) cancelAll() {
+	for reqID := range sess.txns {
+		sess.discardTxn(reqID)
+	}
+
 	sess.openReqsMu.Lock()
 	defer sess.openReqsMu.Unlock()
 
@@ -668,13 +1142,16 @@ func (sess *hostSess) getReq(reqID uint64, verb pinVerb) (req *openReq, err erro
 		} else {
 			switch verb {
 			case insertReq:
+				opts := sess.host.getOpts()
 				req = &openReq{
 					sess:   sess,
+					outbox: newSubQueue(opts.SubQueueSize, opts.SnapshotQueueSize),
 					cancel: make(chan struct{}),
 				}
 				req.ReqID = reqID
 				req.CellSub = req
 				sess.openReqs[reqID] = req
+				go req.outbox.sendTo(sess.msgsOut, sess.Closing())
 			}
 		}
 	}
@@ -696,8 +1173,22 @@ func (sess *hostSess) pinCell(msg *arc.Msg) error {
 		return err
 	}
 
+	// Access is checked against the planet the client asks for (an App may then serve the cell from elsewhere)
+	if sess.user == nil {
+		return arc.ErrCode_InvalidLogin.Error("not logged in")
+	}
+	req.User = sess.user
+	req.PlanetID = pinReq.PlanetID
+	if req.PlanetID == 0 {
+		req.PlanetID = sess.user.HomePlanet().PlanetID()
+	}
+	if err = sess.checkRole(req.PlanetID, arc.PlanetRole_ReadOnly); err != nil {
+		return err
+	}
+
+	var parentReq *openReq
 	if pinReq.ParentReqID != 0 {
-		parentReq, _ := sess.getReq(pinReq.ParentReqID, getReq)
+		parentReq, _ = sess.getReq(pinReq.ParentReqID, getReq)
 		if parentReq == nil {
 			err = arc.ErrCode_InvalidReq.Error("invalid ParentReqID")
 			return err
@@ -710,9 +1201,13 @@ func (sess *hostSess) pinCell(msg *arc.Msg) error {
 		return err
 	}
 
+	// A cell committed under a data model that no App handles is served directly from the planet's cell store (see storedCell).
 	req.ParentApp, err = sess.host.SelectAppForSchema(req.ContentSchema)
 	if err != nil {
-		return err
+		if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_AppNotFound || pinReq.PinCell == 0 {
+			return err
+		}
+		req.ParentApp = nil
 	}
 
 	req.PinCell = arc.CellID(pinReq.PinCell)
@@ -725,13 +1220,142 @@ func (sess *hostSess) pinCell(msg *arc.Msg) error {
 		}
 	}
 
-	err = req.ParentApp.ResolveRequest(&req.CellReq)
+	// Resolving can take any amount of time (e.g. an App accessing a network), so it's done by the session's resolve workers
+	req.resolveMu.Lock()
+	req.resolving = true
+	req.resolveMu.Unlock()
+
+	// A child req is resolved by the worker that resolves its parent, once it has
+	if parentReq != nil {
+		parentReq.resolveMu.Lock()
+		waiting := parentReq.resolving
+		if waiting {
+			parentReq.waiting = append(parentReq.waiting, req)
+		}
+		parentReq.resolveMu.Unlock()
+		if waiting {
+			return nil
+		}
+	}
+
+	select {
+	case sess.pinQueue <- req:
+		return nil
+	default:
+		req.resolveMu.Lock()
+		req.resolving = false
+		req.resolveMu.Unlock()
+		return arc.ErrCode_InvalidReq.Errorf("too many pins awaiting resolve (max %d)", cap(sess.pinQueue))
+	}
+}
+
+// resolvePins resolves queued pins one at a time until this session closes.
+// Each session runs HostOpts.MaxPinResolves of these, so that's how many of its pins resolve at once.
+func (sess *hostSess) resolvePins(ctx process.Context) {
+	for {
+		select {
+		case req := <-sess.pinQueue:
+			select {
+			case <-ctx.Closing():
+				sess.abandonPin(req)
+			default:
+				sess.resolvePin(req)
+			}
+		case <-ctx.Closing():
+
+			// Pins still queued are closed along with the session, so they are released without being resolved
+			for {
+				select {
+				case req := <-sess.pinQueue:
+					sess.abandonPin(req)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// abandonPin closes the given queued req and the child reqs waiting on it without resolving them.
+func (sess *hostSess) abandonPin(req *openReq) {
+	req.resolveMu.Lock()
+	req.resolving = false
+	waiting := req.waiting
+	req.waiting = nil
+	for _, msg := range req.deferred {
+		msg.Reclaim()
+	}
+	req.deferred = nil
+	req.resolveMu.Unlock()
+
+	sess.dropReq(req)
+	req.closeReq(true, arc.ErrCode_ShuttingDown.Error("session closing"))
+	for _, child := range waiting {
+		sess.abandonPin(child)
+	}
+}
+
+// resolvePin resolves the given req and queues it to the cell it pins, closing the req if that fails.
+// Msgs received for the req meanwhile are then processed in the order received (see deferUntilResolved),
+// followed by resolving its child reqs waiting on it.
+func (sess *hostSess) resolvePin(req *openReq) {
+	err := sess.queuePin(req)
 	if err != nil {
-		return err
+		sess.dropReq(req)
+		req.closeReq(true, err)
+	}
+
+	for {
+		req.resolveMu.Lock()
+		if len(req.deferred) == 0 {
+			req.resolving = false
+			closed := atomic.LoadUint32(&req.closed) != 0
+			waiting := req.waiting
+			req.waiting = nil
+			req.resolveMu.Unlock()
+
+			// A req closed while resolving was left for here to remove as a sub
+			if cell := req.cell; cell != nil && closed {
+				cell.pl.cancelSub(req)
+			}
+			for _, child := range waiting {
+				sess.resolvePin(child)
+			}
+			return
+		}
+		msg := req.deferred[0]
+		req.deferred = req.deferred[1:]
+		req.resolveMu.Unlock()
+
+		if atomic.LoadUint32(&req.closed) == 0 {
+			if err = sess.pinAttrRange(msg); err != nil {
+				sess.closeReq(msg.ReqID, true, err)
+			}
+		}
+		msg.Reclaim()
+	}
+}
+
+// queuePin resolves the given req via its App and queues it to the cell it pins.
+// A req closed before it's resolved (e.g. while queued) isn't passed to its App.
+func (sess *hostSess) queuePin(req *openReq) error {
+	errClosed := arc.ErrCode_ReqCanceled.Error("request closed")
+
+	select {
+	case <-req.cancel:
+		return errClosed
+	default:
+	}
+
+	if req.ParentApp != nil {
+		err := req.ParentApp.ResolveRequest(&req.CellReq)
+		if err != nil {
+			return err
+		}
 	}
 
 	if req.PlanetID == 0 {
-		req.PlanetID = sess.user.HomePlanet().PlanetID()
+		req.PlanetID = req.User.HomePlanet().PlanetID()
 		// err = arc.ErrCode_InvalidReq.Error("invalid PlanetID")
 		// return err
 	}
@@ -741,22 +1365,142 @@ func (sess *hostSess) pinCell(msg *arc.Msg) error {
 		return err
 	}
 
-	err = pl.queueReq(nil, req)
-	if err != nil {
-		return err
+	// If there is no App or it didn't assign an AppCell, serve the cell from the planet's cell store
+	if req.PinnedCell == nil {
+		req.PinnedCell = &storedCell{pl}
+	}
+
+	sess.openReqsMu.Lock()
+	req.info = arc.ReqInfo{
+		ReqID:    req.ReqID,
+		PlanetID: req.PlanetID,
+		PinCell:  req.PinCell,
+		ModelURI: req.ContentSchema.AttrModelURI,
+	}
+	sess.openReqsMu.Unlock()
+	sess.host.statusChanged()
+
+	select {
+	case <-req.cancel:
+		return errClosed
+	default:
+	}
+	return pl.queueReq(nil, req)
+}
+
+// cellKey returns the key of the cell this req pins.
+// A cell served by an App (rather than from the planet's cell store) is keyed by its CellID within that App.
+func (req *openReq) cellKey() cellKey {
+	key := cellKey{CellID: req.PinCell}
+	if _, stored := req.PinnedCell.(*storedCell); !stored && req.ParentApp != nil {
+		key.appURI = req.ParentApp.AppURI()
+	}
+	return key
+}
+
+// deferUntilResolved queues the given msg to be processed once its req has resolved, returning false if its req isn't resolving.
+func (sess *hostSess) deferUntilResolved(msg *arc.Msg) bool {
+	req, _ := sess.getReq(msg.ReqID, getReq)
+	if req == nil {
+		return false
+	}
+
+	req.resolveMu.Lock()
+	defer req.resolveMu.Unlock()
+
+	if !req.resolving {
+		return false
+	}
+	req.deferred = append(req.deferred, msg)
+	return true
+}
+
+func (sess *hostSess) pinAttrRange(msg *arc.Msg) error {
+	req, _ := sess.getReq(msg.ReqID, getReq)
+	if req == nil {
+		return arc.ErrCode_ReqNotFound.Error("PinAttrRange requires an open PinCell request")
 	}
 
+	// Errors past this point are pushed as a checkpoint so that the pin remains open
+	pin, err := req.newRangePin(msg)
+	if err == nil {
+		cell := req.cell
+		if cell == nil {
+			err = arc.ErrCode_ReqCanceled.Error("request closed")
+		} else {
+			select {
+			case cell.newPins <- pin:
+			case <-cell.Closing():
+				err = arc.ErrCode_ShuttingDown.Error("cell closing")
+			case <-req.cancel:
+			}
+		}
+	}
+	if err != nil {
+		req.PushCheckpoint(err)
+	}
 	return nil
 }
 
+func (req *openReq) newRangePin(msg *arc.Msg) (*rangePin, error) {
+	var attrRange arc.AttrRange
+	if err := msg.LoadVal(&attrRange); err != nil {
+		return nil, err
+	}
+
+	if msg.CellID != 0 && msg.CellID != req.PinCell.U64() {
+		return nil, arc.ErrCode_InvalidCell.Error("PinAttrRange only supports the pinned cell")
+	}
+
+	var attr *arc.AttrSpec
+	if req.ContentSchema != nil {
+		attr = req.ContentSchema.LookupAttrByID(msg.AttrID, msg.SI)
+	}
+	if attr == nil || attr.SeriesType == arc.SeriesType_Fixed {
+		return nil, arc.ErrCode_BadValue.Errorf("AttrID %d is not a series attr", msg.AttrID)
+	}
+
+	pin := &rangePin{
+		req:   req,
+		attr:  attr,
+		limit: attrRange.SI_BatchLimit,
+		rng: Range{
+			L:     int64(attrRange.SI_StopAt),
+			R:     int64(attrRange.SI_SeekTo),
+			FromR: attrRange.FromID_SeekAfter,
+		},
+	}
+	if attrRange.SI_SeekTo == 0 && attrRange.FromID_SeekAfter == 0 {
+		pin.rng.R = int64(arc.SI_DistantFuture)
+	}
+	if !attrRange.SI_StopAtSet {
+		pin.rng.L = math.MinInt64
+	}
+	if pin.rng.L > pin.rng.R {
+		return nil, arc.ErrCode_BadValue.Error("invalid AttrRange")
+	}
+	return pin, nil
+}
+
 type user struct {
+	host *host
 	home arc.Planet
+	seat arc.UserSeat
+	uid  []byte
 }
 
 func (user *user) HomePlanet() arc.Planet {
 	return user.home
 }
 
+func (user *user) UserUID() []byte {
+	return user.uid
+}
+
+func (user *user) PlanetRole(planetID uint64) (arc.PlanetRole, error) {
+	return user.host.planetRole(user.seat, planetID)
+}
+
 /*
 
 
@@ -813,52 +1557,6 @@ func (sess *hostSess) serveState(req *openReq) error {
 	return nil
 }
 
-*/
-/*
-
-func (sess *hostSess) pinAttrRange(msg *arc.Msg) error {
-	attrRange := arc.AttrRange{}
-
-	if err := msg.LoadValue(&attrRange); err != nil {
-		return err
-	}
-
-	// pin, err := sess.onCellReq(msg.ReqID, getPin)
-	// if err != nil {
-	// 	return err
-	// }
-
-	// err = pin.pinAttrRange(msg.AttrID, attrRange)
-	// if err != nil {
-	// 	return err
-	// }
-
-	return nil
-}
-
-
-	// Get (or make) a new req job using the given ReqID
-	sess.pinsMu.Lock()
-	pin := sess.pins[msg.ReqID]
-	if pin != nil {
-		if cancel {
-			pin.Close()
-			sess.pins[msg.ReqID] = nil
-		} else {
-			err = arc.ErrCode_InvalidReq.ErrWithMsg("ReqID already in use")
-		}
-	} else {
-		if cancel {
-			err = arc.ErrCode_ReqNotFound.Err()
-		} else {
-			pin = &nodeReq{
-				reqCancel: make(chan struct{}),
-			}
-			sess.pins[msg.ReqID] = pin
-		}
-	}
-	sess.pinsMu.Unlock()
-
 */
 
 // func (req *nodeReq) Close() {
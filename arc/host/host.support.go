@@ -1,17 +1,67 @@
 package host
 
+import "sort"
 
+// Range is an inclusive range of SI values.
+// Since series items sharing a SI are ordered by FromID, items having SI == L are only within range if their FromID >= FromL,
+// and if FromR is set, items having SI == R are only within range if their FromID < FromR (see AttrRange.FromID_SeekAfter).
 type Range struct {
-	L       uint64
-	R       uint64
+	L     int64
+	R     int64
+	FromL uint64
+	FromR uint64
 }
 
-// UnionRange unions the given range (inclusive) with current range 
-func (r *Range) UnionRange(L, R uint64) {
-	if L < r.L {
-		r.L = L
+// Contains returns true if the given series item is within this range (inclusive)
+func (r Range) Contains(SI int64, fromID uint64) bool {
+	if SI > r.R || (SI == r.R && r.FromR != 0 && fromID >= r.FromR) {
+		return false
 	}
-	if R > r.R {
-		r.R = R
+	return SI > r.L || (SI == r.L && fromID >= r.FromL)
+}
+
+// Ranges is a set of disjoint Ranges, sorted by L.
+type Ranges []Range
+
+// Add returns this set with the given range added, merging ranges that overlap or abut.
+func (rs Ranges) Add(r Range) Ranges {
+	rs = append(rs, r)
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].L < rs[j].L || (rs[i].L == rs[j].L && rs[i].FromL < rs[j].FromL)
+	})
+
+	merged := rs[:1]
+	for _, next := range rs[1:] {
+		last := &merged[len(merged)-1]
+		if last.abuts(next) {
+			if next.R > last.R || (next.R == last.R && last.FromR != 0 && (next.FromR == 0 || next.FromR > last.FromR)) {
+				last.R, last.FromR = next.R, next.FromR
+			}
+		} else {
+			merged = append(merged, next)
+		}
+	}
+	return merged
+}
+
+// abuts returns true if the given range, which starts no lower than this range, overlaps or abuts this range.
+func (r Range) abuts(next Range) bool {
+	switch {
+	case next.L < r.R:
+		return true
+	case next.L == r.R:
+		return r.FromR == 0 || next.FromL <= r.FromR
+	default:
+		return next.L-1 == r.R && r.FromR == 0 && next.FromL == 0
+	}
+}
+
+// Contains returns true if the given series item is within one of these ranges.
+func (rs Ranges) Contains(SI int64, fromID uint64) bool {
+	for _, r := range rs {
+		if r.Contains(SI, fromID) {
+			return true
+		}
 	}
+	return false
 }
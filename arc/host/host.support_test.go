@@ -0,0 +1,39 @@
+package host
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRangesAdd(t *testing.T) {
+	var rs Ranges
+	rs = rs.Add(Range{L: 5, R: 6})
+	rs = rs.Add(Range{L: 2, R: 3, FromL: 7})
+	rs = rs.Add(Range{L: 10, R: 12})
+	want := Ranges{{L: 2, R: 3, FromL: 7}, {L: 5, R: 6}, {L: 10, R: 12}}
+	if !reflect.DeepEqual(rs, want) {
+		t.Fatalf("got %v", rs)
+	}
+	if rs.Contains(4, 0) || rs.Contains(2, 6) || !rs.Contains(2, 7) || !rs.Contains(11, 0) {
+		t.Fatal("Contains failed")
+	}
+
+	// Ranges that abut or overlap are merged, but not if the lower range only partly covers the upper range's L
+	rs = rs.Add(Range{L: 4, R: 4})
+	rs = rs.Add(Range{L: 7, R: 10, FromL: 1})
+	want = Ranges{{L: 2, R: 6, FromL: 7}, {L: 7, R: 12, FromL: 1}}
+	if !reflect.DeepEqual(rs, want) {
+		t.Fatalf("got %v", rs)
+	}
+
+	// A range ending partway through the items sharing its R abuts a range resuming from there
+	rs = Ranges{{L: 5, R: 8, FromL: 3}}.Add(Range{L: 3, R: 5, FromR: 3})
+	want = Ranges{{L: 3, R: 8, FromL: 0}}
+	if !reflect.DeepEqual(rs, want) {
+		t.Fatalf("got %v", rs)
+	}
+	rs = Ranges{{L: 5, R: 8, FromL: 4}}.Add(Range{L: 3, R: 5, FromR: 3})
+	if len(rs) != 2 || rs.Contains(5, 3) || !rs.Contains(5, 2) || !rs.Contains(5, 4) {
+		t.Fatalf("got %v", rs)
+	}
+}
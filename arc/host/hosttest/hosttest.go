@@ -0,0 +1,31 @@
+// Package hosttest starts hosts for tests of packages that run against one.
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/host"
+)
+
+// StartTestHost starts a host that keeps its state in a temp dir, closing it once the test completes.
+// Each given func can adjust the host's opts before it starts (e.g. to restart a host on the same StatePath).
+func StartTestHost(t testing.TB, withOpts ...func(opts *host.HostOpts)) arc.Host {
+	t.Helper()
+	dir := t.TempDir()
+	opts := host.DefaultHostOpts()
+	opts.StatePath = dir + "/state"
+	opts.CachePath = dir + "/cache"
+	for _, with := range withOpts {
+		with(&opts)
+	}
+	h, err := host.StartNewHost(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		h.Close()
+		<-h.Done()
+	})
+	return h
+}
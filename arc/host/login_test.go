@@ -0,0 +1,122 @@
+package host
+
+import (
+	crypto_rand "crypto/rand"
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/ski"
+)
+
+func TestVerifyLogin(t *testing.T) {
+	kitIDs := map[arc.CryptoKitID]ski.CryptoKitID{
+		arc.CryptoKit_Signing_ED25519: ski.CryptoKitID_ED25519,
+		arc.CryptoKit_Signing_NaCl:    ski.CryptoKitID_NaCl,
+	}
+
+	for arcKitID, skiKitID := range kitIDs {
+		kit, err := ski.GetCryptoKit(skiKitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key := ski.KeyEntry{
+			KeyInfo: &ski.KeyInfo{
+				KeyType:     ski.KeyType_SigningKey,
+				CryptoKitID: skiKitID,
+			},
+		}
+		if err = kit.GenerateNewKey(32, crypto_rand.Reader, &key); err != nil {
+			t.Fatal(err)
+		}
+		pubKey := &arc.CryptoKey{
+			CryptoKitID: arcKitID,
+			KeyBytes:    key.KeyInfo.PubKey,
+		}
+
+		nonce := make([]byte, 32)
+		crypto_rand.Read(nonce)
+		sig, err := kit.Sign(nonce, key.PrivKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply := &arc.LoginChallenge{
+			Nonce:     nonce,
+			Signature: sig,
+		}
+		if err = verifyLogin(pubKey, nonce, reply); err != nil {
+			t.Fatalf("%v: valid signature failed: %v", arcKitID, err)
+		}
+
+		// A signature of a different nonce must fail
+		otherNonce := append([]byte{}, nonce...)
+		otherNonce[0]++
+		if err = verifyLogin(pubKey, otherNonce, reply); err == nil {
+			t.Fatalf("%v: mismatched nonce should fail", arcKitID)
+		}
+
+		reply.Nonce = otherNonce
+		if err = verifyLogin(pubKey, otherNonce, reply); err == nil {
+			t.Fatalf("%v: bad signature should fail", arcKitID)
+		}
+
+		if err = verifyLogin(nil, nonce, reply); err == nil {
+			t.Fatalf("%v: missing key should fail", arcKitID)
+		}
+	}
+}
+
+func TestUserHomePlanet(t *testing.T) {
+	h := startTestHost(t)
+
+	homeOf := func(userUID string) uint64 {
+		ts := newTestSess(t, h)
+		ts.loginAs(userUID)
+		seat, err := h.home.getUser(arc.LoginReq{UserUID: []byte(userUID)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return seat.HomePlanetID
+	}
+
+	alice := homeOf("alice")
+	bob := homeOf("bob")
+	if alice == h.homePlanetID || bob == h.homePlanetID || alice == bob {
+		t.Fatalf("users must each have their own home planet (alice=%d, bob=%d, host=%d)", alice, bob, h.homePlanetID)
+	}
+
+	// A returning user is given the same home planet
+	if again := homeOf("alice"); again != alice {
+		t.Fatalf("alice's home planet changed from %d to %d", alice, again)
+	}
+
+	pl, err := h.getPlanet(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pl.dbPath == h.home.dbPath {
+		t.Fatal("user planet shares the host's home planet db")
+	}
+}
+
+func TestKeylessSeatRefused(t *testing.T) {
+	h := startTestHost(t)
+
+	// A seat without a registered key (e.g. from before login challenges) can't be claimed by whichever key logs in first
+	userID := h.home.getUserID([]byte("carol"), true)
+	if err := h.home.putUser(arc.UserSeat{UserID: uint64(userID)}); err != nil {
+		t.Fatal(err)
+	}
+	ts := newTestSess(t, h)
+	if err := ts.login("carol"); err == nil {
+		t.Fatal("expected login to a seat without a key to fail")
+	}
+	seat, err := h.home.getUser(arc.LoginReq{UserUID: []byte("carol")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seat.PubKey != nil {
+		t.Fatal("expected seat to remain without a key")
+	}
+}
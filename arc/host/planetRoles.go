@@ -0,0 +1,154 @@
+package host
+
+import (
+	"encoding/binary"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/symbol"
+	"github.com/dgraph-io/badger/v3"
+)
+
+// Each planet stores the roles granted on it, keyed by the host-issued UserID (see getUserID):
+//
+//	kPlanetRoles+UserID => PlanetRole (uvarint)
+//
+// A user's role on their home planet is always PlanetRole_Owner and is not stored.
+
+func (host *host) GrantPlanetRole(grant *arc.PlanetGrant) error {
+	if grant == nil || len(grant.UserUID) == 0 {
+		return arc.ErrCode_InvalidReq.Error("missing UserUID")
+	}
+	if _, known := arc.PlanetRole_name[int32(grant.Role)]; !known {
+		return arc.ErrCode_BadValue.Errorf("unknown PlanetRole %v", grant.Role)
+	}
+
+	pl, err := host.getPlanet(grant.PlanetID)
+	if err != nil {
+		return err
+	}
+
+	host.usersMu.Lock()
+	defer host.usersMu.Unlock()
+
+	seat, err := host.home.getUser(arc.LoginReq{UserUID: grant.UserUID})
+	if err != nil && err != errUnknownUser {
+		return err
+	}
+	if seat.HomePlanetID == pl.planetID {
+		return arc.ErrCode_InvalidReq.Error("a user's role on their home planet can't be changed")
+	}
+
+	// A user granted a role before logging in is issued the UserID their seat will have
+	userID := host.home.getUserID(grant.UserUID, true)
+	return pl.putRole(uint64(userID), grant.Role)
+}
+
+func (host *host) GetPlanetRole(planetID uint64, userUID []byte) (arc.PlanetRole, error) {
+	host.usersMu.Lock()
+	seat, err := host.home.getUser(arc.LoginReq{UserUID: userUID})
+	host.usersMu.Unlock()
+
+	if err == errUnknownUser {
+		userID := host.home.getUserID(userUID, false)
+		if userID == 0 {
+			return arc.PlanetRole_None, nil
+		}
+		seat.UserID = uint64(userID)
+	} else if err != nil {
+		return arc.PlanetRole_None, err
+	}
+	return host.planetRole(seat, planetID)
+}
+
+// planetRole returns the role of the user having the given seat on the given planet.
+func (host *host) planetRole(seat arc.UserSeat, planetID uint64) (arc.PlanetRole, error) {
+	if planetID != 0 && planetID == seat.HomePlanetID {
+		return arc.PlanetRole_Owner, nil
+	}
+	pl, err := host.getPlanet(planetID)
+	if err != nil {
+		return arc.PlanetRole_None, err
+	}
+	return pl.getRole(seat.UserID)
+}
+
+// checkRole returns ErrCode_InsufficientPermissions if the logged in user's role on the given planet is below the given role.
+func (sess *hostSess) checkRole(planetID uint64, need arc.PlanetRole) error {
+	if sess.user == nil {
+		return arc.ErrCode_InvalidLogin.Error("not logged in")
+	}
+	role, err := sess.user.PlanetRole(planetID)
+	if err != nil {
+		return err
+	}
+	if role < need {
+		return arc.ErrCode_InsufficientPermissions.Errorf("%v required on planet ID=%v", need, planetID)
+	}
+	return nil
+}
+
+// grantPlanetRole sets the role given by the msg's PlanetGrant, which requires the logged in user own the planet.
+func (sess *hostSess) grantPlanetRole(msg *arc.Msg) error {
+	var grant arc.PlanetGrant
+	if err := msg.LoadVal(&grant); err != nil {
+		return err
+	}
+	if sess.user == nil {
+		return arc.ErrCode_InvalidLogin.Error("not logged in")
+	}
+	if grant.PlanetID == 0 {
+		grant.PlanetID = sess.user.HomePlanet().PlanetID()
+	}
+	if err := sess.checkRole(grant.PlanetID, arc.PlanetRole_Owner); err != nil {
+		return err
+	}
+	return sess.host.GrantPlanetRole(&grant)
+}
+
+func (pl *planetSess) getRole(userID uint64) (arc.PlanetRole, error) {
+	var buf [16]byte
+	key := symbol.ID(userID).WriteTo(append(buf[:0], kPlanetRoles))
+
+	role := arc.PlanetRole_None
+	err := pl.db.View(func(dbTx *badger.Txn) error {
+		item, err := dbTx.Get(key)
+		if err == nil {
+			err = item.Value(func(val []byte) error {
+				v, n := binary.Uvarint(val)
+				if n <= 0 {
+					return arc.ErrCode_DataFailure.Error("malformed planet role")
+				}
+				role = arc.PlanetRole(v)
+				return nil
+			})
+		}
+		return err
+	})
+
+	if err == badger.ErrKeyNotFound {
+		return arc.PlanetRole_None, nil
+	}
+	if err != nil {
+		return arc.PlanetRole_None, arc.ErrCode_DataFailure.Wrap(err)
+	}
+	return role, nil
+}
+
+// putRole stores the given user's role, where PlanetRole_None removes it.
+func (pl *planetSess) putRole(userID uint64, role arc.PlanetRole) error {
+	var buf [16]byte
+	key := symbol.ID(userID).WriteTo(append(buf[:0], kPlanetRoles))
+
+	err := pl.db.Update(func(dbTx *badger.Txn) error {
+		if role == arc.PlanetRole_None {
+			return dbTx.Delete(key)
+		}
+		var val [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(val[:], uint64(role))
+		return dbTx.Set(key, val[:n])
+	})
+	if err != nil {
+		return arc.ErrCode_DataFailure.Wrap(err)
+	}
+	return nil
+}
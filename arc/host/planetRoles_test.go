@@ -0,0 +1,121 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+func TestPlanetRoles(t *testing.T) {
+	h := startTestHost(t)
+	const cellID = 1<<40 + 1
+
+	alice := newTestSess(t, h)
+	alice.loginAs("alice")
+	alice.register(noteSchema)
+	if err := alice.commit(insertCell(cellID, noteSchema), pushAttr(cellID, 1, "hello")); err != nil {
+		t.Fatal(err)
+	}
+	seat, err := h.home.getUser(arc.LoginReq{UserUID: []byte("alice")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	planetID := seat.HomePlanetID
+
+	bob := newTestSess(t, h)
+	bob.loginAs("bob")
+	bob.register(noteSchema)
+
+	pinReq := &arc.PinReq{PinCell: cellID, ContentSchema: noteSchema.SchemaID, PlanetID: planetID}
+	expectPin := func(allowed bool) {
+		t.Helper()
+		_, msgs, err := bob.pin(pinReq)
+		if !allowed {
+			if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_InsufficientPermissions {
+				t.Fatalf("expected pin to be denied, got %v", err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vals := attrVals(msgs, cellID); vals[1] != "hello" {
+			t.Fatalf("unexpected pinned cell: %v", vals)
+		}
+	}
+	expectCommit := func(allowed bool) {
+		t.Helper()
+		bob.lastReqID++
+		reqID := bob.lastReqID
+		for _, msg := range []*arc.Msg{insertCell(cellID, noteSchema), pushAttr(cellID, 2, "from bob")} {
+			msg.ReqID = reqID
+			bob.sendMsg(msg)
+		}
+		bob.send(reqID, arc.MsgOp_Commit, &arc.Txn{PlanetID: planetID})
+		reply := bob.recv(reqID)
+		if committed := reply.Op == arc.MsgOp_Commit && closeErr(reply) == nil; committed != allowed {
+			t.Fatalf("expected commit allowed=%v, got %v", allowed, closeErr(reply))
+		}
+	}
+	grant := func(ts *testSess, role arc.PlanetRole) error {
+		reqID := ts.send(0, arc.MsgOp_GrantPlanetRole, &arc.PlanetGrant{
+			PlanetID: planetID,
+			UserUID:  []byte("bob"),
+			Role:     role,
+		})
+		return ts.await(reqID)
+	}
+
+	// A user has no access to a planet they aren't granted a role on
+	expectPin(false)
+	expectCommit(false)
+
+	// Only the owner can grant roles
+	if err = grant(bob, arc.PlanetRole_Member); err == nil {
+		t.Fatal("expected grant by non-owner to fail")
+	}
+	if err = grant(alice, arc.PlanetRole_ReadOnly); err != nil {
+		t.Fatal(err)
+	}
+	expectPin(true)
+	expectCommit(false)
+
+	if err = grant(alice, arc.PlanetRole_Member); err != nil {
+		t.Fatal(err)
+	}
+	expectPin(true)
+	expectCommit(true)
+	if role, _ := h.GetPlanetRole(planetID, []byte("bob")); role != arc.PlanetRole_Member {
+		t.Fatalf("expected Member, got %v", role)
+	}
+
+	// Revoking a role denies access again
+	if err = grant(alice, arc.PlanetRole_None); err != nil {
+		t.Fatal(err)
+	}
+	expectPin(false)
+	expectCommit(false)
+
+	// A user always owns their home planet
+	if role, _ := h.GetPlanetRole(planetID, []byte("alice")); role != arc.PlanetRole_Owner {
+		t.Fatalf("expected Owner, got %v", role)
+	}
+	if err = h.GrantPlanetRole(&arc.PlanetGrant{PlanetID: planetID, UserUID: []byte("alice")}); err == nil {
+		t.Fatal("expected changing a user's role on their home planet to fail")
+	}
+
+	// A role can be granted to a user before they first log in
+	carolPlanet, err := h.CreatePlanet(&arc.PlanetEpoch{CommonName: "Shared"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = h.GrantPlanetRole(&arc.PlanetGrant{PlanetID: carolPlanet.PlanetID(), UserUID: []byte("carol"), Role: arc.PlanetRole_ReadOnly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	carol := newTestSess(t, h)
+	carol.loginAs("carol")
+	if role, _ := h.GetPlanetRole(carolPlanet.PlanetID(), []byte("carol")); role != arc.PlanetRole_ReadOnly {
+		t.Fatalf("expected ReadOnly, got %v", role)
+	}
+}
@@ -11,19 +11,46 @@ import (
 	"github.com/dgraph-io/badger/v3"
 )
 
+// cellKey identifies a cell of a planet.
+// Since each App issues its own CellIDs, a CellID only identifies a cell within the App serving it.
+type cellKey struct {
+	arc.CellID
+	appURI string // App serving the cell ("" if served from the planet's cell store)
+}
+
 // cellInst is a "mounted" cell servicing requests for a specific cell (typically one).
 // This can be thought of as the controller for one or more active cell pins.
 // cellService?  cellSupe?
 type cellInst struct {
-	arc.CellID
+	cellKey
 	process.Context // TODO: make custom lightweight later
 
-	pl       *planetSess        // parent planet
-	subsHead *openReq           // single linked list of open reqs on this cell
-	subsMu   sync.Mutex         // mutex for subs
-	newReqs  chan *openReq      // new requests waiting for state
-	newTxns  chan *arc.MsgBatch // txns to be pushed to subs
-	idleSecs int32              // ticks up as time passes when there are no subs
+	pl       *planetSess    // parent planet
+	subsHead *openReq       // single linked list of open reqs on this cell
+	subsMu   sync.Mutex     // mutex for subs
+	newReqs  chan *openReq  // new requests waiting for state
+	txns     []*cellTxn     // txns to be pushed to subs (see publish)
+	txnsMu   sync.Mutex     // protects txns
+	txnsCh   chan struct{}  // signaled when a txn is added to txns
+	newPins  chan *rangePin // attr ranges to be pinned for subs
+	closed   bool           // set once this cell takes no more txns (protected by txnsMu)
+	idle     time.Duration  // ticks up as time passes when there are no subs
+}
+
+// rangePin is a request to pin a SI range of a series attr for an open req
+type rangePin struct {
+	req   *openReq
+	attr  *arc.AttrSpec // from req.ContentSchema
+	rng   Range
+	limit uint64
+}
+
+// cellTxn is a batch of cell changes to be pushed to all subs of a cell.
+// Since AttrIDs and SchemaIDs are bound per session, each sub remaps them via AttrURI and AttrModelURI.
+type cellTxn struct {
+	batch    *arc.MsgBatch
+	content  *arc.AttrSchema                      // schema of PushAttr msgs not preceded by an InsertCell msg
+	schemaOf func(schemaID int32) *arc.AttrSchema // resolves the SchemaID of InsertCell msgs
 }
 
 func (pl *planetSess) onStart(opts symbol.TableOpts) error {
@@ -31,10 +58,7 @@ func (pl *planetSess) onStart(opts symbol.TableOpts) error {
 
 	dbOpts := badger.DefaultOptions(pl.dbPath)
 	dbOpts.Logger = nil
-
-	// Limit ValueLogFileSize to ~134mb since badger does a mmap size test on init, causing iOS 13 to error out.
-	// Also, massive value file sizes aren't appropriate for mobile.  TODO: make configurable.
-	dbOpts.ValueLogFileSize = 1 << 27
+	dbOpts.ValueLogFileSize = pl.host.getOpts().ValueLogFileSize
 	pl.db, err = badger.Open(dbOpts)
 	if err != nil {
 		return err
@@ -49,12 +73,16 @@ func (pl *planetSess) onStart(opts symbol.TableOpts) error {
 }
 
 func (pl *planetSess) onRun(process.Context) {
-	const period = 60
-	timer := time.NewTicker(period * time.Second)
+	period := idleCheckPeriod(pl.host.getOpts().CellIdleClose)
+	timer := time.NewTimer(period)
 	for running := true; running; {
 		select {
 		case <-timer.C:
 			pl.closeIdleCells(period)
+
+			// The period follows CellIdleClose, which can be reloaded (see ReloadOpts)
+			period = idleCheckPeriod(pl.host.getOpts().CellIdleClose)
+			timer.Reset(period)
 		case <-pl.Closing():
 			running = false
 		}
@@ -62,6 +90,15 @@ func (pl *planetSess) onRun(process.Context) {
 	timer.Stop()
 }
 
+// idleCheckPeriod returns how often to check for idle cells, so that a cell closes within 1.5x the given CellIdleClose.
+func idleCheckPeriod(idleClose time.Duration) time.Duration {
+	period := idleClose / 2
+	if period > time.Minute {
+		period = time.Minute
+	}
+	return period
+}
+
 func (pl *planetSess) onClosed() {
 	if pl.symTable != nil {
 		pl.symTable.Close()
@@ -89,36 +126,38 @@ func (pl *planetSess) SetSymbolID(value []byte, ID uint64) uint64 {
 	return uint64(pl.symTable.SetSymbolID(value, symbol.ID(ID)))
 }
 
-func (pl *planetSess) closeIdleCells(deltaSecs int32) {
+func (pl *planetSess) closeIdleCells(delta time.Duration) {
 	pl.cellsMu.Lock()
 	defer pl.cellsMu.Unlock()
 
-	const idleCloseDelay = 3*60 - 1
+	idleClose := pl.host.getOpts().CellIdleClose
 
 	// With the cells locked, we can check and close idle cells
 	for _, cell := range pl.cells {
-		if cell.idleTick(deltaSecs) > idleCloseDelay {
-			delete(pl.cells, cell.CellID)
+		if cell.idleTick(delta) >= idleClose {
+			delete(pl.cells, cell.cellKey)
 			cell.Close()
+			pl.host.statusChanged()
 		}
 	}
 }
 
-func (pl *planetSess) getCell(ID arc.CellID) (cell *cellInst, err error) {
+func (pl *planetSess) getCell(key cellKey) (cell *cellInst, err error) {
 	pl.cellsMu.Lock()
 	defer pl.cellsMu.Unlock()
 
 	// If the cell is already open, we're done
-	cell = pl.cells[ID]
+	cell = pl.cells[key]
 	if cell != nil {
 		return
 	}
 
 	cell = &cellInst{
+		cellKey: key,
 		pl:      pl,
-		CellID:  ID,
 		newReqs: make(chan *openReq),
-		newTxns: make(chan *arc.MsgBatch),
+		txnsCh:  make(chan struct{}, 1),
+		newPins: make(chan *rangePin),
 	}
 
 	cell.Context, err = pl.Context.StartChild(&process.Task{
@@ -140,9 +179,17 @@ func (pl *planetSess) getCell(ID arc.CellID) (cell *cellInst, err error) {
 						err = req.PinnedCell.PushCellState(&req.CellReq)
 					}
 					req.PushCheckpoint(err)
+					req.pinned = true
 
-				case tx := <-cell.newTxns:
-					cell.pushToSubs(tx)
+				case pin := <-cell.newPins:
+					err := pin.req.pinRange(cell, pin)
+					pin.req.PushCheckpoint(err)
+
+				case <-cell.txnsCh:
+					for _, tx := range cell.takeTxns() {
+						cell.pushToSubs(tx)
+						tx.batch.Reclaim()
+					}
 
 				case <-cell.Context.Closing():
 					running = false
@@ -150,13 +197,20 @@ func (pl *planetSess) getCell(ID arc.CellID) (cell *cellInst, err error) {
 
 			}
 
+			// Once marked closed, nothing more is queued, so what remains is taken here
+			cell.markClosed()
+			for _, tx := range cell.takeTxns() {
+				tx.batch.Reclaim()
+			}
+
 		},
 	})
 	if err != nil {
 		return
 	}
 
-	pl.cells[ID] = cell
+	pl.cells[key] = cell
+	pl.host.statusChanged()
 
 	return
 }
@@ -168,7 +222,7 @@ func (pl *planetSess) queueReq(cell *cellInst, req *openReq) error {
 
 	var err error
 	if cell == nil {
-		cell, err = pl.getCell(req.PinCell)
+		cell, err = pl.getCell(req.cellKey())
 		if err != nil {
 			return err
 		}
@@ -184,11 +238,17 @@ func (pl *planetSess) queueReq(cell *cellInst, req *openReq) error {
 		}
 		*prev = req
 		req.next = nil
-
-		cell.newReqs <- req
 	}
-	cell.idleSecs = 0
+	cell.idle = 0
 	cell.subsMu.Unlock()
+	pl.host.statusChanged()
+
+	// Send after unlocking since the cell may be pushing a txn to its subs (which locks subsMu)
+	select {
+	case cell.newReqs <- req:
+	case <-cell.Closing():
+		return arc.ErrCode_ShuttingDown.Error("cell closing")
+	}
 
 	return nil
 }
@@ -215,6 +275,7 @@ func (pl *planetSess) cancelSub(req *openReq) {
 		}
 	}
 	cell.subsMu.Unlock()
+	pl.host.statusChanged()
 
 	// N := len(csess.subs)
 	// for i := 0; i < N; i++ {
@@ -228,289 +289,167 @@ func (pl *planetSess) cancelSub(req *openReq) {
 	// }
 }
 
-func (cell *cellInst) idleTick(deltaSecs int32) int32 {
+func (cell *cellInst) idleTick(delta time.Duration) time.Duration {
 	if cell.subsHead != nil {
 		return 0
 	}
-	cell.idleSecs += deltaSecs
-	return cell.idleSecs
+	cell.idle += delta
+	return cell.idle
 }
 
-func (cell *cellInst) pushToSubs(tx *arc.MsgBatch) {
-	cell.subsMu.Lock()
-	defer cell.subsMu.Unlock()
-
-	for sub := cell.subsHead; sub != nil; sub = sub.next {
-		err := sub.PushUpdate(tx)
-		if err != nil {
-			panic(err)
-			// sub.Error("dropping client due to error", err)
-			// sub.Close()  // TODO: prevent deadlock since chSess.subsMu is locked
-			// chSess.subs[i] = nil
-		}
-	}
-
-}
-
-// This will be replaced in the future with generic use of GetCell() with a "user" App type.
-// For now, just make a table with user IDs their respective user record.
-func (pl *planetSess) getUser(req arc.LoginReq, autoCreate bool) (seat arc.UserSeat, err error) {
-	var buf [128]byte
-
-	uid := append(buf[:0], "/UID/"...)
-	uid = append(uid, req.UserUID...)
-	userID := pl.symTable.GetSymbolID(uid, autoCreate)
-	if userID == 0 {
-		return arc.UserSeat{}, arc.ErrCode_InvalidLogin.Error("unknown user")
+// publish queues the given txn to be pushed to this cell's subs, taking ownership of tx.batch.
+// Since publish never blocks, it can be called from any goroutine, including the cell's own (e.g. an App publishing
+// an update from AppCell.PushCellState).  Txns are pushed in the order they are published.
+// Once the cell is closed, publish returns errCellClosing.
+func (cell *cellInst) publish(tx *cellTxn) error {
+	cell.txnsMu.Lock()
+	closed := cell.closed
+	if !closed {
+		cell.txns = append(cell.txns, tx)
 	}
+	cell.txnsMu.Unlock()
 
-	dbTx := pl.db.NewTransaction(true)
-	defer dbTx.Discard()
-
-	// For now, just make a table with user IDs their respective user record.
-	key := append(buf[:0], 0xF1) // User record table
-	key = userID.WriteTo(key)
-	item, err := dbTx.Get(key)
-	if err == badger.ErrKeyNotFound && autoCreate {
-		seat.UserID = uint64(userID)
-		seat.HomePlanetID = pl.planetID /// TODO: do user planet genesis here!
-		seatBytes, _ := seat.Marshal()
-		dbTx.Set(key, seatBytes)
-		err = dbTx.Commit()
-	} else if err == nil {
-		err = item.Value(func(val []byte) error {
-			return seat.Unmarshal(val)
-		})
+	if closed {
+		tx.batch.Reclaim()
+		return errCellClosing
 	}
 
-	if err != nil {
-		panic(err)
+	select {
+	case cell.txnsCh <- struct{}{}:
+	default:
 	}
-
-	return
+	return nil
 }
 
-/*
-	symbol.ID(cellID).WriteTo(csess.keyPrefix[:])
+var errCellClosing = arc.ErrCode_ShuttingDown.Error("cell closing")
 
-	// loads immutable info about this cellInst (namely NodeTypeID)
-	//  The stored NodeTypeID refers
-	{
-		dbTx := pl.db.NewTransaction(false)
-		defer dbTx.Discard()
+// markClosed marks this cell as closed so that publish refuses txns from here on.
+func (cell *cellInst) markClosed() {
+	cell.txnsMu.Lock()
+	cell.closed = true
+	cell.txnsMu.Unlock()
+}
 
-		item, err := dbTx.Get(csess.keyPrefix[:])
-		if err != nil {
-			return nil, arc.ErrCode_CellNotFound.Err()
-		}
+// takeTxns returns the txns published since the last call.
+func (cell *cellInst) takeTxns() []*cellTxn {
+	cell.txnsMu.Lock()
+	txns := cell.txns
+	cell.txns = nil
+	cell.txnsMu.Unlock()
+	return txns
+}
 
-		err = item.Value(func(val []byte) error {
-			return csess.NodeInfo.Unmarshal(val)
-		})
+func (cell *cellInst) pushToSubs(tx *cellTxn) {
+	cell.subsMu.Lock()
+	defer cell.subsMu.Unlock()
 
+	for sub := cell.subsHead; sub != nil; sub = sub.next {
+		err := sub.pushTxn(tx)
 		if err != nil {
-			return nil, arc.ErrCode_DataFailure.ErrWithMsgf("error starting Node %v", csess.NodeInfo.cellID)
+			// An error here means the req is closing, so it will be removed as a sub shortly.
+			cell.Info(2, "dropping txn for closing sub: ", err)
 		}
-
-		// csess.NodeInfo.PlanetID = pl.NodeInfo.cellID
-		// csess.NodeInfo.cellID = cellID
-
-		// NodeSpec := csess.pl.host.getNodeSpec(csess.NodeInfo.ItemTypeID)
-		// if NodeSpec == nil {
-		// 	return nil, arc.ErrCode_NodeCorrupted.ErrWithMsgf("NodeSpec %v not found", csess.NodeInfo.ItemTypeID)
-		// }
-
-		// csess.NodeSpec = *NodeSpec // TODO: unpack into attr map inst
 	}
-
-	// TODO: handle error during StartChild?
-	pl.nodes[cellID] = csess
-
-	err := pl.StartChild(csess, fmt.Sprintf("cellInst %4d", cellID))
-	if err != nil {
-		return nil, err
-	}
-
-	return csess, nil
 }
 
-
-// func (pl *planetSess) getNode(nodeTID arc.TID, autoCreate bool) (arc.Node, error) {
-// 	cellID := pl.Table.GetSymbolID(nodeTID, false)
-// 	if cellID == 9 {
-// 		return nil, arc.ErrCode_NodeNotFound.ErrWithMsgf("Node %s", chTID.Base32())
-// 	}
-
-// }
-
-
-
-// const (
-// 	kNodeSpecID_AttrID = symbol.ID(3)
-// )
-
-func (csess *cellInst) serveState(req *nodeReq) error {
-	//target := req.req.TargetNode()
-	csess.registerPin(sub)
-
-	dbTx := csess.pl.db.NewTransaction(false)
-	defer dbTx.Discard()
-
-	// A node maps to current state where each db itr step corresponds to an attr ID:
-	//    cellID+AttrID           => Msg
-	// An attr declared as a series has keys of the form:
-	//    cellID+AttrID+SI+FromID => Msg  (future: SI+FromID is replaced by SI+CollisionSortByte)
-	//
-	// This means:
-	//    - future machinery can be added to perform multi-node locking txns (that update the state node and push to subs)
-	//    - AttrIDs can be reserved to denote special internal state values -- e.g. revID, NodeType (NodeSpecID)
-	//    - idea: edits to values name the SI, FromID, and RevID they are replacing in order to be accepted?
-
-
-
-	// 2 possible approaches:
-	//    node maps to state: a node ID maps to the "state" node, where each db itr step corresponds to an attr ID
-	//       => a time series is its own node type (where each node entry key is SI rather than an attr ID)
-	//       => machinery to perform multi-node locking txns would then update the node safely (and push to subs) -- but can be deferred (tech debt)
-	//    node embeds all history: node key has AttrID+SI+From suffix (non-trivial impl)
-	//       => reading node state means seeking the latest TSI of each attr
-	//       => merging a Tx only pushes state if its TSI maps/replaces what is already mapped  (non-trivial impl)
-	//       => multi-node locking ops get MESSY
-
-	// Form key for target node to read, seek, and read and send each node entry / attr
-	var keyBuf [64]byte
-	baseKey := append(keyBuf[:0], csess.keyPrefix[:]...)
-
-	// Announce the new node (send SetTypeID, ItemTypeID, map mode etc)
-	msg := arc.NewMsg()
-	msg.Op = arc.MsgOp_AnnounceNode
-	msg.SetValue(&req.target)
-	err := req.pushMsg(msg)
-	if err == nil {
-		return err
+// publishTxn pushes the given committed msgs to the subs of affected cells that are currently open.
+func (pl *planetSess) publishTxn(msgs []*arc.Msg, reg arc.TypeRegistry) {
+	schemaOf := func(schemaID int32) *arc.AttrSchema {
+		schema, _ := reg.GetSchemaByID(schemaID)
+		return schema
 	}
 
-
-	for _, attr := range req.spec.Attrs {
-
-		if req.isClosed() {
-			break
+	var (
+		cell  *cellInst
+		batch *arc.MsgBatch
+	)
+	flush := func() {
+		if batch != nil {
+			cell.publish(&cellTxn{
+				batch:    batch,
+				schemaOf: schemaOf,
+			})
+			batch = nil
 		}
+	}
 
-		attrKey := symbol.ID(attr.AttrID).WriteTo(baseKey)
-
-		if attr.SeriesType == arc.SeriesType_0 {
-			item, getErr := dbTx.Get(attrKey)
-			if getErr == nil {
-				err = req.unmarshalAndPush(item)
-				if err != nil {
-					csess.Error(err)
-				}
-			}
-		} else if attr.AutoPin == arc.AutoPin_All {
-
-			switch attr.SeriesType {
-
-
-
+	for _, msg := range msgs {
+		if msg.Op == arc.MsgOp_InsertCell {
+			flush()
+			pl.cellsMu.Lock()
+			cell = pl.cells[cellKey{CellID: arc.CellID(msg.CellID)}]
+			pl.cellsMu.Unlock()
+			if cell != nil {
+				batch = arc.NewMsgBatch()
 			}
-
 		}
-
-		// case arc.
-
-		// case SeriesType_U16:
-
-		// }
-
-
-		if err != nil {
-			return err
-		}
-		// autoMap := attr.AutoMap
-		// if autoMap == arc.AutoMap_ForType {
-
-		// 	switch arc.Type(attr.SetTypeID) {
-		// 	case arc.Type_TimeSeries:
-
-		// 	case arc.Type_AttrSet,
-		// 		arc.Type_TimeSeries:
-		// 		autoMap = arc.AutoMap_No
-		// 	case arc.Type_NameSet:
-		// 	}
-		// }
-
-		switch attr.AutoPin {
-		case arc.AutoPin_All:
-
+		if batch != nil {
+			batch.Msgs = append(batch.Msgs, arc.CopyMsg(msg))
 		}
 	}
-
-	// Send break when done
-	msg = arc.NewMsg()
-	msg.Op = arc.MsgOp_NodeUpdated
-	err = req.pushMsg(msg)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	flush()
 }
 
-func (sub *cellSub) pinAttrRange(attrID uint64, add arc.AttrRange) error {
+var errUnknownUser = arc.ErrCode_InvalidLogin.Error("unknown user")
 
-	for i, attr := range sub.attrs {
-		if attr.def.AttrID != attrID {
-			continue
-		}
+// This will be replaced in the future with generic use of GetCell() with a "user" App type.
+// For now, just make a table with user IDs their respective user record.
+//
+// If the user has no seat, errUnknownUser is returned.
+func (pl *planetSess) getUser(req arc.LoginReq) (seat arc.UserSeat, err error) {
+	var buf [16]byte
 
-		sub.mu.Lock()
-		attr.targetRange.UnionRange(add)
-		sub.mu.Unlock()
+	userID := pl.getUserID(req.UserUID, false)
+	if userID == 0 {
+		return arc.UserSeat{}, errUnknownUser
 	}
-}
-
-
-func (sub *cellSub) isClosed() bool {
-	return atomic.LoadUint32(&sub.closed) != 0
-}
 
-func (sub *cellSub) unmarshalAndPush(item *badger.Item) error {
-
-	//ch.Infof(2, "GET: %s", item.Key())
-
-	msg := arc.NewMsg()
-
-	err := item.Value(func(val []byte) error {
-		return msg.Unmarshal(val)
+	// For now, just make a table with user IDs their respective user record.
+	key := append(buf[:0], kUserSeats)
+	key = userID.WriteTo(key)
+	err = pl.db.View(func(dbTx *badger.Txn) error {
+		item, err := dbTx.Get(key)
+		if err == nil {
+			err = item.Value(func(val []byte) error {
+				return seat.Unmarshal(val)
+			})
+		}
+		return err
 	})
 
+	if err == badger.ErrKeyNotFound {
+		return arc.UserSeat{}, errUnknownUser
+	}
 	if err != nil {
-		//ch.Errorf("failed to read entry %v: %v", string(msg.Keypath), err)
-		return arc.ErrCode_DataFailure.Err()
+		return arc.UserSeat{}, arc.ErrCode_DataFailure.Wrap(err)
 	}
 
-	return sub.pushMsg(msg)
+	return
 }
 
-func (sub *cellSub) pushMsg(msg *arc.Msg) error {
-	var err error
+// getUserID returns the symbol ID of the given user UID, issuing a new ID if autoIssue is set.
+func (pl *planetSess) getUserID(userUID []byte, autoIssue bool) symbol.ID {
+	var buf [128]byte
 
-	//msg.ReqID = req.pinReq.PinID
+	uid := append(buf[:0], "/UID/"...)
+	uid = append(uid, userUID...)
+	return pl.symTable.GetSymbolID(uid, autoIssue)
+}
 
-	//ch.Infof(2, "PushValue: %s", msg.Keypath)
+// putUser writes the given seat, replacing the existing seat having the same UserID.
+func (pl *planetSess) putUser(seat arc.UserSeat) error {
+	var buf [16]byte
+	key := append(buf[:0], kUserSeats)
+	key = symbol.ID(seat.UserID).WriteTo(key)
 
-	// If the client backs up, this will back up too which is the desired effect.
-	// Otherwise, db reading would quickly fill up the Msg inbox buffer (and have no gain)
-	select {
-	case sub.sess.msgsOut <- msg:
-	// case <-sub.reqCancel:
-	// 	err = arc.ErrCode_ShuttingDown.ErrWithMsg("client closing")
-	case <-sub.sess.Closing():
-		err = arc.ErrCode_ShuttingDown.ErrWithMsg("planet closing")
+	seatBytes, err := seat.Marshal()
+	if err == nil {
+		err = pl.db.Update(func(dbTx *badger.Txn) error {
+			return dbTx.Set(key, seatBytes)
+		})
 	}
-
-	return err
+	if err != nil {
+		return arc.ErrCode_DataFailure.Wrap(err)
+	}
+	return nil
 }
-
-*/
@@ -0,0 +1,175 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// publishingApp publishes an update to each pinned cell while pushing its state (i.e. from the cell's goroutine).
+type publishingApp struct{}
+
+func (app publishingApp) AppURI() string {
+	return "test.arc.tools/publishing.app/v1.0.0"
+}
+
+func (app publishingApp) AttrModelURIs() []string {
+	return []string{noteSchema.AttrModelURI}
+}
+
+func (app publishingApp) StartApp(ctx arc.AppContext) error {
+	return nil
+}
+
+func (app publishingApp) StopApp() {
+}
+
+func (app publishingApp) ResolveRequest(req *arc.CellReq) error {
+	req.PinnedCell = app
+	return nil
+}
+
+func (app publishingApp) PushCellState(req *arc.CellReq) error {
+	req.PushInsertCell(req.PinCell, req.ContentSchema)
+	req.PushAttr(req.PinCell, req.ContentSchema, "title.string", "initial")
+
+	batch := arc.NewMsgBatch()
+	batch.Msgs = append(batch.Msgs, pushAttr(req.PinCell.U64(), 1, "published"))
+	return req.PublishUpdate(batch)
+}
+
+func TestPublishFromCell(t *testing.T) {
+	h := startTestHost(t, publishingApp{})
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.register(noteSchema)
+
+	const cellID = 1<<40 + 1
+	reqID, msgs, err := ts.pin(&arc.PinReq{PinCell: cellID, ContentSchema: noteSchema.SchemaID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals := attrVals(msgs, cellID); vals[1] != "initial" {
+		t.Fatalf("got attrs %v", vals)
+	}
+
+	// The update published during PushCellState follows the cell's initial state
+	var pushed []*arc.Msg
+	for msg := ts.recv(reqID); msg.Op != arc.MsgOp_Commit; msg = ts.recv(reqID) {
+		pushed = append(pushed, msg)
+	}
+	if vals := attrVals(pushed, cellID); vals[1] != "published" {
+		t.Fatalf("got pushed attrs %v", vals)
+	}
+}
+
+func TestPublishAfterClose(t *testing.T) {
+	h := startTestHost(t)
+	cell, err := h.home.getCell(cellKey{CellID: 1<<40 + 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cell.Close()
+	<-cell.Done()
+
+	// Txns published once the cell has closed are refused rather than dropped
+	if err = cell.publish(&cellTxn{batch: arc.NewMsgBatch()}); err != errCellClosing {
+		t.Fatalf("expected errCellClosing, got %v", err)
+	}
+}
+
+// fixedCellApp serves every request as the same CellID, pushing its name as the cell's title.
+type fixedCellApp struct {
+	name   string
+	schema *arc.AttrSchema
+}
+
+const fixedCellID = 1<<40 + 1
+
+func (app *fixedCellApp) AppURI() string {
+	return "test.arc.tools/" + app.name + ".app/v1.0.0"
+}
+
+func (app *fixedCellApp) AttrModelURIs() []string {
+	return []string{app.schema.AttrModelURI}
+}
+
+func (app *fixedCellApp) StartApp(ctx arc.AppContext) error {
+	return nil
+}
+
+func (app *fixedCellApp) StopApp() {
+}
+
+func (app *fixedCellApp) ResolveRequest(req *arc.CellReq) error {
+	req.PinCell = fixedCellID
+	req.PinnedCell = app
+	return nil
+}
+
+func (app *fixedCellApp) PushCellState(req *arc.CellReq) error {
+	req.PushInsertCell(req.PinCell, req.ContentSchema)
+	req.PushAttr(req.PinCell, req.ContentSchema, "title.string", app.name)
+	return nil
+}
+
+func TestCellsPerApp(t *testing.T) {
+	newSchema := func(name string, schemaID int32) *arc.AttrSchema {
+		return &arc.AttrSchema{
+			AttrModelURI: "test/" + name,
+			SchemaName:   name,
+			SchemaID:     schemaID,
+			Attrs: []*arc.AttrSpec{
+				{AttrURI: "title.string", AttrID: 1},
+			},
+		}
+	}
+	a := &fixedCellApp{name: "a", schema: newSchema("a", 2)}
+	b := &fixedCellApp{name: "b", schema: newSchema("b", 3)}
+	h := startTestHost(t, a, b)
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.register(noteSchema, a.schema, b.schema)
+
+	if err := ts.commit(insertCell(fixedCellID, noteSchema), pushAttr(fixedCellID, 1, "stored")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cells of different Apps (and the cell store) having the same CellID are separate cells
+	storedID, _, err := ts.pin(&arc.PinReq{PinCell: fixedCellID, ContentSchema: noteSchema.SchemaID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var appIDs []uint64
+	for _, app := range []*fixedCellApp{a, b} {
+		reqID, msgs, err := ts.pin(&arc.PinReq{ContentSchema: app.schema.SchemaID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vals := attrVals(msgs, fixedCellID); vals[1] != app.name {
+			t.Fatalf("expected App %q's cell, got %v", app.name, vals)
+		}
+		appIDs = append(appIDs, reqID)
+	}
+	if n := len(h.HostStatus().Cells); n != 3 {
+		t.Fatalf("expected 3 active cells, got %d", n)
+	}
+
+	// A commit is only pushed to the stored cell
+	if err = ts.commit(insertCell(fixedCellID, noteSchema), pushAttr(fixedCellID, 1, "updated")); err != nil {
+		t.Fatal(err)
+	}
+	var pushed []*arc.Msg
+	for msg := ts.recv(storedID); msg.Op != arc.MsgOp_Commit; msg = ts.recv(storedID) {
+		pushed = append(pushed, msg)
+	}
+	if vals := attrVals(pushed, fixedCellID); vals[1] != "updated" {
+		t.Fatalf("got pushed attrs %v", vals)
+	}
+	for _, reqID := range appIDs {
+		ts.send(reqID, arc.MsgOp_CloseReq, nil)
+		if msg := ts.recv(reqID); msg.Op != arc.MsgOp_CloseReq {
+			t.Fatalf("expected App cell to not be pushed the commit, got %v", msg)
+		}
+	}
+}
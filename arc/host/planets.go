@@ -0,0 +1,309 @@
+package host
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/symbol"
+	"github.com/dgraph-io/badger/v3"
+)
+
+const hostHomePlanetName = "HostHomePlanet"
+
+// planetFSName returns the name of the given planet's db dir (or "" if the planet ID is unknown).
+func (host *host) planetFSName(planetID uint64) string {
+	if planetID == host.homePlanetID {
+		return hostHomePlanetName
+	}
+	return string(host.home.LookupID(planetID))
+}
+
+func (host *host) ListPlanets() ([]arc.PlanetInfo, error) {
+	entries, err := os.ReadDir(host.opts.StatePath)
+	if err != nil {
+		return nil, arc.ErrCode_PlanetFailure.Wrap(err)
+	}
+
+	host.plMu.RLock()
+	defer host.plMu.RUnlock()
+
+	var planets []arc.PlanetInfo
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		fsName := entry.Name()
+
+		var planetID uint64
+		if fsName == hostHomePlanetName {
+			planetID = host.homePlanetID
+		} else {
+			planetID = host.home.GetSymbolID([]byte(fsName), false)
+		}
+
+		// Skip dirs that aren't planets issued by this host
+		if planetID == 0 || host.planetFSName(planetID) != fsName {
+			continue
+		}
+
+		planets = append(planets, arc.PlanetInfo{
+			PlanetID: planetID,
+			Name:     fsName,
+			Mounted:  host.plSess[planetID] != nil,
+		})
+	}
+	return planets, nil
+}
+
+func (host *host) CreatePlanet(genesis *arc.PlanetEpoch) (arc.Planet, error) {
+	return host.createPlanet(genesis)
+}
+
+// createPlanet creates and mounts a new planet, assigning a random EpochTID if genesis doesn't have one.
+func (host *host) createPlanet(genesis *arc.PlanetEpoch) (*planetSess, error) {
+	if genesis == nil || genesis.CommonName == "" {
+		return nil, arc.ErrCode_PlanetFailure.Error("missing planet CommonName")
+	}
+	if len(genesis.EpochTID) == 0 {
+		genesis.EpochTID = make([]byte, 16)
+		if _, err := rand.Read(genesis.EpochTID); err != nil {
+			return nil, arc.ErrCode_InternalErr.Wrap(err)
+		}
+	}
+	return host.mountPlanet(0, genesis)
+}
+
+func (host *host) UnmountPlanet(planetID uint64) error {
+	if planetID == host.homePlanetID {
+		return arc.ErrCode_PlanetFailure.Error("the host's home planet can't be unmounted")
+	}
+
+	host.plMu.RLock()
+	pl := host.plSess[planetID]
+	host.plMu.RUnlock()
+
+	// Cells and their subs are children of the planet, so they close first
+	if pl != nil {
+		pl.Close()
+		<-pl.Done()
+	}
+	return nil
+}
+
+func (host *host) DeletePlanet(planetID uint64) error {
+	fsName := host.planetFSName(planetID)
+	if fsName == "" {
+		return arc.ErrCode_PlanetNotFound.Errorf("planet ID=%v not found", planetID)
+	}
+
+	// Holding usersMu keeps the planet from being granted or made a user's home planet meanwhile
+	host.usersMu.Lock()
+	defer host.usersMu.Unlock()
+
+	if userID, err := host.home.homePlanetUser(planetID); err != nil {
+		return err
+	} else if userID != 0 {
+		return arc.ErrCode_PlanetFailure.Errorf("planet ID=%v is the home planet of user %d", planetID, userID)
+	}
+
+	if err := host.UnmountPlanet(planetID); err != nil {
+		return err
+	}
+
+	// Holding plMu prevents the planet from being mounted again while it's deleted
+	host.plMu.Lock()
+	defer host.plMu.Unlock()
+
+	if host.plSess[planetID] != nil {
+		return arc.ErrCode_PlanetFailure.Errorf("planet ID=%v was mounted during delete", planetID)
+	}
+
+	// The roles granted on the planet are stored in its db and so go with it
+	dbPath := path.Join(host.opts.StatePath, fsName)
+	if _, err := os.Stat(dbPath); err != nil {
+		return arc.ErrCode_PlanetNotFound.Errorf("planet ID=%v not found", planetID)
+	}
+	if err := os.RemoveAll(dbPath); err != nil {
+		return arc.ErrCode_PlanetFailure.Wrap(err)
+	}
+
+	// Finally, remove what the host's home planet has on record for the planet
+	if err := host.home.removeUserPlanet(planetID); err != nil {
+		return err
+	}
+	host.home.symTable.DeleteID(symbol.ID(planetID))
+	return nil
+}
+
+// Each planet a user creates is recorded on the host's home planet so that users can only create so many (see HostOpts.MaxUserPlanets):
+//
+//	kUserPlanets+UserID+PlanetID => (empty)
+
+// createUserPlanet creates a planet owned by the given user, refusing once they've created HostOpts.MaxUserPlanets planets.
+func (host *host) createUserPlanet(userUID []byte, commonName string) (*planetSess, error) {
+	host.usersMu.Lock()
+	defer host.usersMu.Unlock()
+
+	seat, err := host.home.getUser(arc.LoginReq{UserUID: userUID})
+	if err != nil {
+		return nil, err
+	}
+	planetIDs, err := host.home.userPlanets(seat.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if max := host.getOpts().MaxUserPlanets; len(planetIDs) >= max {
+		return nil, arc.ErrCode_InsufficientPermissions.Errorf("user %d has created the max of %d planets", seat.UserID, max)
+	}
+
+	pl, err := host.createPlanet(&arc.PlanetEpoch{
+		CommonName: commonName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = pl.putRole(seat.UserID, arc.PlanetRole_Owner); err != nil {
+		return nil, err
+	}
+	if err = host.home.putUserPlanet(seat.UserID, pl.planetID); err != nil {
+		return nil, err
+	}
+	return pl, nil
+}
+
+// createPlanet creates a planet owned by the logged in user, replying with the new planet's ID.
+func (sess *hostSess) createPlanet(msg *arc.Msg) error {
+	if sess.user == nil {
+		return arc.ErrCode_InvalidLogin.Error("not logged in")
+	}
+	if msg.ValType != int32(arc.ValType_string) || len(msg.ValBuf) == 0 {
+		return arc.ErrCode_BadValue.Error("missing planet CommonName")
+	}
+	pl, err := sess.host.createUserPlanet(sess.user.UserUID(), string(msg.ValBuf))
+	if err != nil {
+		return err
+	}
+	sess.pushMsg(msg.ReqID, arc.MsgOp_CreatePlanet, int64(pl.planetID))
+	return nil
+}
+
+// unmountPlanet performs MsgOp_UnmountPlanet or MsgOp_DeletePlanet, which requires the logged in user own the given planet.
+func (sess *hostSess) unmountPlanet(msg *arc.Msg) error {
+	if msg.ValType != int32(arc.ValType_int) || msg.ValInt <= 0 {
+		return arc.ErrCode_BadValue.Error("missing PlanetID")
+	}
+	planetID := uint64(msg.ValInt)
+	if err := sess.checkRole(planetID, arc.PlanetRole_Owner); err != nil {
+		return err
+	}
+	if msg.Op == arc.MsgOp_DeletePlanet {
+		return sess.host.DeletePlanet(planetID)
+	}
+	return sess.host.UnmountPlanet(planetID)
+}
+
+// userPlanets returns the IDs of the planets created by the given user.
+func (pl *planetSess) userPlanets(userID uint64) ([]uint64, error) {
+	var buf [16]byte
+	prefix := symbol.ID(userID).WriteTo(append(buf[:0], kUserPlanets))
+
+	var planetIDs []uint64
+	err := pl.db.View(func(dbTx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.Prefix = prefix
+		it := dbTx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var planetID symbol.ID
+			planetID.ReadFrom(it.Item().Key()[len(prefix):])
+			planetIDs = append(planetIDs, uint64(planetID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, arc.ErrCode_DataFailure.Wrap(err)
+	}
+	return planetIDs, nil
+}
+
+func (pl *planetSess) putUserPlanet(userID, planetID uint64) error {
+	var buf [16]byte
+	key := symbol.ID(userID).WriteTo(append(buf[:0], kUserPlanets))
+	key = symbol.ID(planetID).WriteTo(key)
+
+	err := pl.db.Update(func(dbTx *badger.Txn) error {
+		return dbTx.Set(key, nil)
+	})
+	if err != nil {
+		return arc.ErrCode_DataFailure.Wrap(err)
+	}
+	return nil
+}
+
+// removeUserPlanet removes the given planet from the planets recorded for whichever user created it.
+func (pl *planetSess) removeUserPlanet(planetID uint64) error {
+	var buf [8]byte
+	suffix := symbol.ID(planetID).WriteTo(buf[:0])
+
+	err := pl.db.Update(func(dbTx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.Prefix = []byte{kUserPlanets}
+		it := dbTx.NewIterator(opts)
+		defer it.Close()
+
+		var keys [][]byte
+		for it.Rewind(); it.Valid(); it.Next() {
+			if key := it.Item().Key(); bytes.HasSuffix(key, suffix) {
+				keys = append(keys, it.Item().KeyCopy(nil))
+			}
+		}
+		for _, key := range keys {
+			if err := dbTx.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return arc.ErrCode_DataFailure.Wrap(err)
+	}
+	return nil
+}
+
+// homePlanetUser returns the UserID of the user whose home planet is the given planet (or 0 if none).
+func (pl *planetSess) homePlanetUser(planetID uint64) (uint64, error) {
+	var userID uint64
+	err := pl.db.View(func(dbTx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte{kUserSeats}
+		it := dbTx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid() && userID == 0; it.Next() {
+			err := it.Item().Value(func(val []byte) error {
+				var seat arc.UserSeat
+				if err := seat.Unmarshal(val); err != nil {
+					return err
+				}
+				if seat.HomePlanetID == planetID {
+					userID = seat.UserID
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, arc.ErrCode_DataFailure.Wrap(err)
+	}
+	return userID, nil
+}
@@ -0,0 +1,146 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// findPlanet returns the listed info of the given planet (or nil if not listed).
+func findPlanet(t *testing.T, h *host, planetID uint64) *arc.PlanetInfo {
+	t.Helper()
+	planets, err := h.ListPlanets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range planets {
+		if planets[i].PlanetID == planetID {
+			return &planets[i]
+		}
+	}
+	return nil
+}
+
+func TestPlanetLifecycle(t *testing.T) {
+	h := startTestHost(t)
+
+	if info := findPlanet(t, h, h.homePlanetID); info == nil || !info.Mounted {
+		t.Fatalf("host home planet not listed as mounted: %v", info)
+	}
+	if err := h.UnmountPlanet(h.homePlanetID); err == nil {
+		t.Fatal("host home planet should not unmount")
+	}
+
+	pl, err := h.CreatePlanet(&arc.PlanetEpoch{CommonName: "Photos"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	planetID := pl.PlanetID()
+	if info := findPlanet(t, h, planetID); info == nil || !info.Mounted {
+		t.Fatalf("new planet not listed as mounted: %v", info)
+	}
+
+	if err = h.UnmountPlanet(planetID); err != nil {
+		t.Fatal(err)
+	}
+	if info := findPlanet(t, h, planetID); info == nil || info.Mounted {
+		t.Fatalf("unmounted planet not listed as unmounted: %v", info)
+	}
+
+	// An unmounted planet is mounted again on access
+	if _, err = h.getPlanet(planetID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = h.DeletePlanet(planetID); err != nil {
+		t.Fatal(err)
+	}
+	if info := findPlanet(t, h, planetID); info != nil {
+		t.Fatalf("deleted planet still listed: %v", info)
+	}
+	_, err = h.getPlanet(planetID)
+	if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_PlanetNotFound {
+		t.Fatalf("expected ErrCode_PlanetNotFound, got %v", err)
+	}
+}
+
+func TestUserPlanets(t *testing.T) {
+	h := startTestHost(t)
+	h.opts.MaxUserPlanets = 2
+
+	createPlanet := func(ts *testSess, commonName string) (uint64, error) {
+		t.Helper()
+		reqID := ts.send(0, arc.MsgOp_CreatePlanet, commonName)
+		var planetID uint64
+		for {
+			msg := ts.recv(reqID)
+			switch msg.Op {
+			case arc.MsgOp_CreatePlanet:
+				planetID = uint64(msg.ValInt)
+			case arc.MsgOp_CloseReq:
+				return planetID, closeErr(msg)
+			}
+		}
+	}
+	deletePlanet := func(ts *testSess, planetID uint64) error {
+		t.Helper()
+		reqID := ts.send(0, arc.MsgOp_DeletePlanet, int64(planetID))
+		return ts.await(reqID)
+	}
+	expectErr := func(err error, code arc.ErrCode) {
+		t.Helper()
+		if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != code {
+			t.Fatalf("expected %v, got %v", code, err)
+		}
+	}
+
+	alice := newTestSess(t, h)
+	alice.loginAs("alice")
+	bob := newTestSess(t, h)
+	bob.loginAs("bob")
+
+	// A user owns the planets they create, up to MaxUserPlanets
+	photos, err := createPlanet(alice, "Photos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role, _ := h.GetPlanetRole(photos, []byte("alice")); role != arc.PlanetRole_Owner {
+		t.Fatalf("expected Owner, got %v", role)
+	}
+	if _, err = createPlanet(alice, "Music"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = createPlanet(alice, "Videos")
+	expectErr(err, arc.ErrCode_InsufficientPermissions)
+
+	// Only an owner can delete a planet, and a user's home planet can't be deleted
+	expectErr(deletePlanet(bob, photos), arc.ErrCode_InsufficientPermissions)
+	seat, err := h.home.getUser(arc.LoginReq{UserUID: []byte("alice")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectErr(deletePlanet(alice, seat.HomePlanetID), arc.ErrCode_PlanetFailure)
+	if err = h.DeletePlanet(seat.HomePlanetID); err == nil {
+		t.Fatal("expected deleting a user's home planet to fail")
+	}
+	if err = newTestSess(t, h).login("alice"); err != nil {
+		t.Fatalf("expected alice to still log in, got %v", err)
+	}
+
+	// Deleting a planet removes it from the host's records, making room for another
+	fsName := h.planetFSName(photos)
+	if err = deletePlanet(alice, photos); err != nil {
+		t.Fatal(err)
+	}
+	if h.planetFSName(photos) != "" || h.home.GetSymbolID([]byte(fsName), false) != 0 {
+		t.Fatal("deleted planet still has symbol entries")
+	}
+	if planetIDs, _ := h.home.userPlanets(seat.UserID); len(planetIDs) != 1 {
+		t.Fatalf("expected 1 planet recorded for alice, got %v", planetIDs)
+	}
+	_, err = h.getPlanet(photos)
+	expectErr(err, arc.ErrCode_PlanetNotFound)
+	if _, err = createPlanet(alice, "Videos"); err != nil {
+		t.Fatal(err)
+	}
+}
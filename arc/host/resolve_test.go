@@ -0,0 +1,139 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// slowApp resolves each request (to slowCellID) once it's released or canceled, noting each request it starts to resolve.
+type slowApp struct {
+	started  chan *arc.CellReq
+	release  chan struct{}
+	canceled chan *arc.CellReq
+}
+
+var slowSchema = &arc.AttrSchema{
+	AttrModelURI: "test/slow",
+	SchemaName:   "slow",
+	SchemaID:     3,
+	Attrs: []*arc.AttrSpec{
+		{AttrURI: "entry.string", AttrID: 1, SeriesType: arc.SeriesType_I64},
+	},
+}
+
+const slowCellID = 1<<40 + 2
+
+func (app *slowApp) AppURI() string {
+	return "test.arc.tools/slow.app/v1.0.0"
+}
+
+func (app *slowApp) AttrModelURIs() []string {
+	return []string{slowSchema.AttrModelURI}
+}
+
+func (app *slowApp) StartApp(ctx arc.AppContext) error {
+	return nil
+}
+
+func (app *slowApp) StopApp() {
+}
+
+func (app *slowApp) ResolveRequest(req *arc.CellReq) error {
+	app.started <- req
+	select {
+	case <-app.release:
+		req.PinCell = slowCellID
+		return nil
+	case <-req.Done():
+		app.canceled <- req
+		return arc.ErrCode_ReqCanceled.Error("canceled")
+	}
+}
+
+func TestAsyncPinResolve(t *testing.T) {
+	app := &slowApp{
+		started:  make(chan *arc.CellReq, 4),
+		release:  make(chan struct{}),
+		canceled: make(chan *arc.CellReq, 4),
+	}
+	h := startTestHost(t, app)
+	h.opts.MaxPinResolves = 2
+	h.opts.MaxQueuedPins = 1
+
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.register(noteSchema, slowSchema)
+
+	const cellID = 1<<40 + 1
+	if err := ts.commit(insertCell(cellID, noteSchema), pushAttr(cellID, 1, "hello")); err != nil {
+		t.Fatal(err)
+	}
+	awaitReq := func(ch chan *arc.CellReq, what string) *arc.CellReq {
+		t.Helper()
+		select {
+		case req := <-ch:
+			return req
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for resolve to be %s", what)
+			return nil
+		}
+	}
+
+	// Slow resolves don't hold up the session's other requests
+	slowID := ts.send(0, arc.MsgOp_PinCell, &arc.PinReq{ContentSchema: slowSchema.SchemaID})
+	awaitReq(app.started, "started")
+	_, msgs, err := ts.pin(&arc.PinReq{PinCell: cellID, ContentSchema: noteSchema.SchemaID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals := attrVals(msgs, cellID); vals[1] != "hello" {
+		t.Fatalf("unexpected pinned cell: %v", vals)
+	}
+
+	// Only MaxPinResolves resolve at once, and only MaxQueuedPins wait to
+	ts.send(0, arc.MsgOp_PinCell, &arc.PinReq{ContentSchema: slowSchema.SchemaID})
+	awaitReq(app.started, "started")
+	waitingID := ts.send(0, arc.MsgOp_PinCell, &arc.PinReq{ContentSchema: slowSchema.SchemaID})
+	select {
+	case <-app.started:
+		t.Fatal("expected resolve to wait for a free worker")
+	case <-time.After(50 * time.Millisecond):
+	}
+	_, _, err = ts.pin(&arc.PinReq{PinCell: cellID, ContentSchema: noteSchema.SchemaID})
+	if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_InvalidReq {
+		t.Fatalf("expected pin to be refused while the queue is full, got %v", err)
+	}
+
+	// Closing a req cancels its resolve in progress, which frees a worker
+	ts.send(slowID, arc.MsgOp_CloseReq, nil)
+	if msg := ts.recv(slowID); msg.Op != arc.MsgOp_CloseReq || closeErr(msg) != nil {
+		t.Fatalf("expected req to close without error, got %v", msg)
+	}
+	if req := awaitReq(app.canceled, "canceled"); req.PinCell != 0 || req.ContentSchema.AttrModelURI != slowSchema.AttrModelURI {
+		t.Fatalf("unexpected canceled req: %v", req)
+	}
+	awaitReq(app.started, "started")
+
+	// Msgs received for a req while it resolves are processed once it resolves
+	rangeMsg := arc.NewMsg()
+	rangeMsg.ReqID = waitingID
+	rangeMsg.Op = arc.MsgOp_PinAttrRange
+	rangeMsg.AttrID = 1
+	setVal(rangeMsg, &arc.AttrRange{})
+	ts.sendMsg(rangeMsg)
+	close(app.release)
+	for checkpoints := 0; checkpoints < 2; {
+		msg := ts.recv(waitingID)
+		switch msg.Op {
+		case arc.MsgOp_CloseReq:
+			t.Fatalf("unexpected close: %v", closeErr(msg))
+		case arc.MsgOp_Commit:
+			if err = closeErr(msg); err != nil {
+				t.Fatalf("checkpoint %d failed: %v", checkpoints, err)
+			}
+			checkpoints++
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package host
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+var logSchema = &arc.AttrSchema{
+	AttrModelURI: "test/log",
+	SchemaName:   "log",
+	SchemaID:     2,
+	Attrs: []*arc.AttrSpec{
+		{AttrURI: "entry.string", AttrID: 1, SeriesType: arc.SeriesType_I64},
+	},
+}
+
+// logEntry returns a PushAttr msg of a logSchema entry.
+func logEntry(cellID uint64, SI int64, fromID uint64) *arc.Msg {
+	msg := pushAttr(cellID, 1, fmt.Sprintf("%d/%d", SI, fromID))
+	msg.SI = SI
+	msg.FromID = fromID
+	return msg
+}
+
+// pinRange pins the given SI range of the given open pin, returning the series items pushed (as "SI/FromID").
+func (ts *testSess) pinRange(reqID uint64, seekTo, stopAt int64, limit uint64) []string {
+	ts.t.Helper()
+	return ts.pinAttrRange(reqID, &arc.AttrRange{
+		SI_SeekTo:     uint64(seekTo),
+		SI_StopAt:     uint64(stopAt),
+		SI_StopAtSet:  true,
+		SI_BatchLimit: limit,
+	})
+}
+
+// pinAttrRange pins the given AttrRange of the given open pin, returning the series items pushed (as "SI/FromID").
+func (ts *testSess) pinAttrRange(reqID uint64, rng *arc.AttrRange) []string {
+	ts.t.Helper()
+	msg := arc.NewMsg()
+	msg.ReqID = reqID
+	msg.Op = arc.MsgOp_PinAttrRange
+	msg.AttrID = 1
+	setVal(msg, rng)
+	ts.sendMsg(msg)
+	return ts.recvEntries(reqID)
+}
+
+// recvEntries returns the series items pushed to the given req up to its next checkpoint (as "SI/FromID").
+func (ts *testSess) recvEntries(reqID uint64) []string {
+	ts.t.Helper()
+	var entries []string
+	for msg := ts.recv(reqID); msg.Op != arc.MsgOp_Commit; msg = ts.recv(reqID) {
+		if msg.Op == arc.MsgOp_CloseReq {
+			ts.t.Fatalf("pin closed: %v", closeErr(msg))
+		}
+		if msg.Op == arc.MsgOp_PushAttr {
+			entries = append(entries, string(msg.ValBuf))
+		}
+	}
+	return entries
+}
+
+func TestSeriesRanges(t *testing.T) {
+	h := startTestHost(t)
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.register(logSchema)
+
+	const cellID = 1<<40 + 1
+	err := ts.commit(
+		insertCell(cellID, logSchema),
+		logEntry(cellID, 1, 1),
+		logEntry(cellID, 2, 1),
+		logEntry(cellID, 2, 2),
+		logEntry(cellID, 3, 1),
+		logEntry(cellID, 5, 1),
+		logEntry(cellID, 6, 1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqID, _, err := ts.pin(&arc.PinReq{PinCell: cellID, ContentSchema: logSchema.SchemaID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The limit is reached between two items sharing SI 2
+	if got := fmt.Sprint(ts.pinRange(reqID, 3, 1, 2)); got != "[3/1 2/2]" {
+		t.Fatalf("got %v", got)
+	}
+	if got := fmt.Sprint(ts.pinRange(reqID, 6, 5, 0)); got != "[6/1 5/1]" {
+		t.Fatalf("got %v", got)
+	}
+
+	// Only items within the ranges received are pushed: 2/1 and 4/1 were never received (and 2/3 and 5/2 are new)
+	err = ts.commit(
+		insertCell(cellID, logSchema),
+		logEntry(cellID, 2, 1),
+		logEntry(cellID, 2, 3),
+		logEntry(cellID, 4, 1),
+		logEntry(cellID, 5, 2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(ts.recvEntries(reqID)); got != "[2/3 5/2]" {
+		t.Fatalf("got pushed %v", got)
+	}
+
+	// The items left over by the limit remain readable
+	if got := fmt.Sprint(ts.pinRange(reqID, 2, 1, 0)); got != "[2/3 2/2 2/1 1/1]" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestSeriesPaging(t *testing.T) {
+	h := startTestHost(t)
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.register(logSchema)
+
+	const cellID = 1<<40 + 1
+	err := ts.commit(
+		insertCell(cellID, logSchema),
+		logEntry(cellID, -2, 1),
+		logEntry(cellID, -1, 1),
+		logEntry(cellID, 0, 1),
+		logEntry(cellID, 0, 2),
+		logEntry(cellID, 1, 1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqID, _, err := ts.pin(&arc.PinReq{PinCell: cellID, ContentSchema: logSchema.SchemaID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An explicit lower bound of 0 excludes negative SIs
+	if got := fmt.Sprint(ts.pinRange(reqID, 0, 0, 0)); got != "[1/1 0/2 0/1]" {
+		t.Fatalf("got %v", got)
+	}
+
+	// Without a lower bound, paging continues through negative SIs, each page resuming after the last item pushed
+	var pages []string
+	rng := &arc.AttrRange{SI_BatchLimit: 2}
+	for i := 0; i < 3; i++ {
+		page := ts.pinAttrRange(reqID, rng)
+		pages = append(pages, fmt.Sprint(page))
+		if len(page) == 0 {
+			break
+		}
+		var SI int64
+		fmt.Sscanf(page[len(page)-1], "%d/%d", &SI, &rng.FromID_SeekAfter)
+		rng.SI_SeekTo = uint64(SI)
+	}
+	if got := fmt.Sprint(pages); got != "[[1/1 0/2] [0/1 -1/1] [-2/1]]" {
+		t.Fatalf("got %v", got)
+	}
+
+	// The pages received abut, so items anywhere within them are pushed
+	err = ts.commit(
+		insertCell(cellID, logSchema),
+		logEntry(cellID, -3, 1),
+		logEntry(cellID, 0, 3),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(ts.recvEntries(reqID)); got != "[-3/1 0/3]" {
+		t.Fatalf("got pushed %v", got)
+	}
+}
@@ -0,0 +1,310 @@
+package host
+
+import (
+	"sync"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-cedar/process"
+)
+
+// sessLink pumps msgs between a ServerStream and the hostSess it currently carries.
+// A link outlives its first session if another (detached) session is resumed over it (see hostSess.resume).
+type sessLink struct {
+	via      arc.ServerStream
+	mu       sync.Mutex
+	sess     *hostSess     // session this link carries (nil once detached)
+	retarget chan struct{} // signaled when sess changes
+	done     chan struct{} // closed once this link is detached
+}
+
+func newSessLink(via arc.ServerStream, sess *hostSess) *sessLink {
+	return &sessLink{
+		via:      via,
+		sess:     sess,
+		retarget: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// target returns the session this link currently carries (or nil if detached).
+func (link *sessLink) target() *hostSess {
+	link.mu.Lock()
+	sess := link.sess
+	link.mu.Unlock()
+	return sess
+}
+
+// retargetTo has this link carry the given session, returning false if this link has since detached.
+func (link *sessLink) retargetTo(sess *hostSess) bool {
+	link.mu.Lock()
+	ok := link.sess != nil
+	if ok {
+		link.sess = sess
+	}
+	link.mu.Unlock()
+
+	if ok {
+		signal(link.retarget)
+	}
+	return ok
+}
+
+// detach unbinds this link from its session, which then either awaits being resumed over another stream or closes.
+func (link *sessLink) detach() {
+	link.mu.Lock()
+	sess := link.sess
+	link.sess = nil
+	if sess != nil {
+		close(link.done)
+	}
+	link.mu.Unlock()
+
+	if sess != nil {
+		sess.detach(link)
+	}
+}
+
+// sendMsgs forwards msgs outbound from this link's session to the stream until the session is done or this link detaches.
+func (link *sessLink) sendMsgs(ctx process.Context) {
+	var sess *hostSess
+	for {
+		next := link.target()
+		if next == nil {
+			ctx.Info(2, "link detached")
+			link.via.Close()
+			return
+		}
+
+		// When resumed, first send what the previous stream failed to
+		if next != sess {
+			sess = next
+			unsent := sess.takeUnsent()
+			for i, msg := range unsent {
+				if !link.send(ctx, sess, msg) {
+					sess.keepUnsent(unsent[i+1:]...)
+					break
+				}
+			}
+			continue
+		}
+
+		select {
+		case msg := <-sess.msgsOut:
+			if msg != nil {
+				link.send(ctx, sess, msg)
+			}
+		case <-link.retarget:
+		case <-link.done:
+		case <-sess.Done():
+			if link.target() == sess {
+				ctx.Info(2, "<-hostDone")
+				link.via.Close()
+				return
+			}
+		}
+	}
+}
+
+// send sends the given msg to the stream, taking ownership of it.
+// If the send fails, the msg is kept by the session (to be sent if it's resumed) and this link detaches.
+func (link *sessLink) send(ctx process.Context, sess *hostSess, msg *arc.Msg) bool {
+	var err error
+	if flags := msg.Flags; flags&arc.MsgFlags_ValBufShared != 0 {
+		msg.Flags = flags &^ arc.MsgFlags_ValBufShared
+		err = link.via.SendMsg(msg)
+		msg.Flags = flags
+	} else {
+		err = link.via.SendMsg(msg)
+	}
+	if err != nil {
+		ctx.Warnf("ServerStream Send() err: %v", err)
+		sess.keepUnsent(msg)
+		link.detach()
+		return false
+	}
+	msg.Reclaim()
+	return true
+}
+
+// recvMsgs forwards msgs inbound from the stream to this link's session until the stream closes or the session is done.
+func (link *sessLink) recvMsgs(ctx process.Context) {
+	for {
+		msg, err := link.via.RecvMsg()
+		if err != nil {
+			if err == arc.ErrStreamClosed {
+				ctx.Info(2, "ServerStream closed")
+			} else {
+				ctx.Warnf("RecvMsg() error: %v", err)
+			}
+			link.detach()
+			return
+		}
+		if msg != nil && !link.deliver(msg) {
+			ctx.Info(2, "hostSession done")
+			return
+		}
+	}
+}
+
+// deliver passes the given msg to this link's session, returning false if there is no longer a session to deliver to.
+func (link *sessLink) deliver(msg *arc.Msg) bool {
+	for {
+		sess := link.target()
+		if sess == nil {
+			msg.Reclaim()
+			return false
+		}
+		select {
+		case sess.msgsIn <- msg:
+			return true
+		case <-sess.Done():
+			if link.target() == sess {
+				msg.Reclaim()
+				return false
+			}
+		}
+	}
+}
+
+// detach is called when the given link (carrying this session) drops.
+// If this session is resumable, it lives on (along with its open reqs and registered types) for HostOpts.ResumeGrace.
+func (sess *hostSess) detach(link *sessLink) {
+	grace := sess.host.getOpts().ResumeGrace
+
+	sess.linkMu.Lock()
+	if sess.link != link {
+		sess.linkMu.Unlock()
+		return
+	}
+	sess.link = nil
+	resumable := sess.resumeToken != nil && grace > 0 && !sess.isClosing()
+	if resumable {
+		sess.grace = time.AfterFunc(grace, func() {
+			sess.Info(1, "not resumed in time")
+			sess.Close()
+		})
+	}
+	sess.linkMu.Unlock()
+	sess.host.statusChanged()
+
+	if resumable {
+		sess.Infof(1, "stream dropped; awaiting resume for %v", grace)
+	} else {
+		sess.Close()
+	}
+}
+
+// attach binds the given link to this session, which must be awaiting resumption.
+func (sess *hostSess) attach(link *sessLink) error {
+	sess.linkMu.Lock()
+	defer sess.linkMu.Unlock()
+
+	if sess.link != nil {
+		return arc.ErrCode_InvalidLogin.Error("session is still connected")
+	}
+	if sess.grace == nil || !sess.grace.Stop() || sess.isClosing() {
+		return arc.ErrCode_InvalidLogin.Error("session expired")
+	}
+	sess.grace = nil
+	sess.link = link
+	sess.host.statusChanged()
+	return nil
+}
+
+// resume moves this session's stream to the detached session that was issued the given token, after which this session closes.
+// Reqs still open on the resumed session carry on and the msgs queued while it was detached are sent.
+func (sess *hostSess) resume(reqID uint64, token []byte) error {
+	prev := sess.host.getResumable(token)
+	if prev == nil {
+		return arc.ErrCode_InvalidLogin.Error("unknown or expired resume token")
+	}
+
+	sess.linkMu.Lock()
+	link := sess.link
+	sess.linkMu.Unlock()
+	if link == nil {
+		return arc.ErrCode_ShuttingDown.Error("stream closed")
+	}
+
+	if err := prev.attach(link); err != nil {
+		return err
+	}
+
+	// If this stream dropped in the meantime, the resumed session goes back to awaiting resumption
+	if !link.retargetTo(prev) {
+		prev.detach(link)
+	}
+	sess.linkMu.Lock()
+	if sess.link == link {
+		sess.link = nil
+	}
+	sess.linkMu.Unlock()
+	sess.Close()
+
+	prev.Infof(1, "resumed via %v", link.via.Desc())
+	prev.pushMsg(reqID, arc.MsgOp_CloseReq, nil)
+	return nil
+}
+
+func (sess *hostSess) isClosing() bool {
+	select {
+	case <-sess.Closing():
+		return true
+	default:
+		return false
+	}
+}
+
+// keepUnsent retains the given msgs that failed to send so that they are sent if this session is resumed.
+func (sess *hostSess) keepUnsent(msgs ...*arc.Msg) {
+	sess.linkMu.Lock()
+	sess.unsent = append(sess.unsent, msgs...)
+	sess.linkMu.Unlock()
+}
+
+func (sess *hostSess) takeUnsent() []*arc.Msg {
+	sess.linkMu.Lock()
+	msgs := sess.unsent
+	sess.unsent = nil
+	sess.linkMu.Unlock()
+	return msgs
+}
+
+func (sess *hostSess) onClosed() {
+	sess.linkMu.Lock()
+	if sess.grace != nil {
+		sess.grace.Stop()
+		sess.grace = nil
+	}
+	token := sess.resumeToken
+	reclaimMsgs(sess.unsent)
+	sess.unsent = nil
+	sess.linkMu.Unlock()
+
+	host := sess.host
+	host.sessMu.Lock()
+	delete(host.sessions, sess)
+	if token != nil && host.resumable[string(token)] == sess {
+		delete(host.resumable, string(token))
+	}
+	host.sessMu.Unlock()
+	host.statusChanged()
+}
+
+func (host *host) addResumable(token []byte, sess *hostSess) {
+	sess.linkMu.Lock()
+	sess.resumeToken = token
+	sess.linkMu.Unlock()
+
+	host.sessMu.Lock()
+	host.resumable[string(token)] = sess
+	host.sessMu.Unlock()
+}
+
+func (host *host) getResumable(token []byte) *hostSess {
+	host.sessMu.Lock()
+	sess := host.resumable[string(token)]
+	host.sessMu.Unlock()
+	return sess
+}
@@ -0,0 +1,107 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// awaitDetached waits until the session that was issued the given resume token has lost its stream.
+func awaitDetached(t *testing.T, h *host, token []byte) {
+	t.Helper()
+	sess := h.getResumable(token)
+	if sess == nil {
+		t.Fatal("session not resumable")
+	}
+	for timeout := time.After(5 * time.Second); ; {
+		sess.linkMu.Lock()
+		detached := sess.link == nil
+		sess.linkMu.Unlock()
+		if detached {
+			return
+		}
+		select {
+		case <-time.After(time.Millisecond):
+		case <-timeout:
+			t.Fatal("timed out waiting for session to detach")
+		}
+	}
+}
+
+func TestSessionResume(t *testing.T) {
+	h := startTestHost(t)
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	if len(ts.resumeToken) == 0 {
+		t.Fatal("login issued no resume token")
+	}
+	ts.register(noteSchema)
+
+	const cellID = 1<<40 + 1
+	if err := ts.commit(insertCell(cellID, noteSchema), pushAttr(cellID, 1, "hello")); err != nil {
+		t.Fatal(err)
+	}
+	pinID, _, err := ts.pin(&arc.PinReq{PinCell: cellID, ContentSchema: noteSchema.SchemaID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop the stream and commit a change while the session awaits resumption
+	ts.close()
+	awaitDetached(t, h, ts.resumeToken)
+
+	other := newTestSess(t, h)
+	other.loginAs("alice")
+	other.register(noteSchema)
+	if err = other.commit(insertCell(cellID, noteSchema), pushAttr(cellID, 1, "hello again")); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new stream picks up the open pin, along with the update pushed to it in the meantime
+	resumed := newTestSess(t, h)
+	resumed.lastReqID = ts.lastReqID
+	if err = resumed.resume(ts.resumeToken); err != nil {
+		t.Fatal(err)
+	}
+	var pushed []*arc.Msg
+	for msg := resumed.recv(pinID); msg.Op != arc.MsgOp_Commit; msg = resumed.recv(pinID) {
+		pushed = append(pushed, msg)
+	}
+	if vals := attrVals(pushed, cellID); vals[1] != "hello again" {
+		t.Fatalf("got pushed attrs %v", vals)
+	}
+
+	// Types registered before the drop remain registered
+	_, msgs, err := resumed.pin(&arc.PinReq{PinCell: cellID, ContentSchema: noteSchema.SchemaID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals := attrVals(msgs, cellID); vals[1] != "hello again" {
+		t.Fatalf("got attrs %v", vals)
+	}
+
+	// A session can't be resumed while it's connected
+	if err = newTestSess(t, h).resume(ts.resumeToken); err == nil {
+		t.Fatal("expected resume of a connected session to fail")
+	}
+}
+
+func TestSessionResumeExpires(t *testing.T) {
+	h := startTestHost(t)
+	h.opts.ResumeGrace = 20 * time.Millisecond
+
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.close()
+	awaitDetached(t, h, ts.resumeToken)
+	time.Sleep(100 * time.Millisecond)
+
+	err := newTestSess(t, h).resume(ts.resumeToken)
+	if loginErr, _ := err.(*arc.Err); loginErr == nil || loginErr.Code != arc.ErrCode_InvalidLogin {
+		t.Fatalf("expected expired resume to fail, got %v", err)
+	}
+	if err = newTestSess(t, h).resume([]byte("bogus")); err == nil {
+		t.Fatal("expected resume with an unknown token to fail")
+	}
+}
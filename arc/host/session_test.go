@@ -0,0 +1,336 @@
+package host
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/lib_service"
+	"github.com/arcspace/go-arcspace/ski"
+)
+
+// startTestHost starts a host in a temp dir with the given Apps registered, closing it when the test completes.
+func startTestHost(t *testing.T, apps ...arc.App) *host {
+	dir := t.TempDir()
+	opts := DefaultHostOpts()
+	opts.StatePath = dir + "/state"
+	opts.CachePath = dir + "/cache"
+	h, err := startNewHost(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		h.Close()
+		<-h.Done()
+	})
+	for _, app := range apps {
+		if err = h.RegisterApp(app); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return h.(*host)
+}
+
+// testSess is a client session of a test host that exchanges raw msgs with the host (via a lib_service session).
+type testSess struct {
+	t           *testing.T
+	srv         lib_service.LibService
+	sess        lib_service.LibSession
+	in          chan *arc.Msg
+	done        chan struct{}
+	held        map[uint64][]*arc.Msg // msgs received for reqs other than the one being waited on
+	lastReqID   uint64
+	resumeToken []byte // issued by the host at login
+}
+
+func newTestSess(t *testing.T, h arc.Host) *testSess {
+	srv := lib_service.DefaultLibServiceOpts().NewLibService()
+	if err := srv.StartService(h); err != nil {
+		t.Fatal(err)
+	}
+	sess, err := srv.NewLibSession()
+	if err != nil {
+		srv.GracefulStop()
+		t.Fatal(err)
+	}
+	ts := &testSess{
+		t:    t,
+		srv:  srv,
+		sess: sess,
+		in:   make(chan *arc.Msg, 64),
+		done: make(chan struct{}),
+		held: make(map[uint64][]*arc.Msg),
+	}
+	go func() {
+		defer close(ts.in)
+		var buf []byte
+		for {
+			if err := sess.DequeueOutgoing(&buf); err != nil {
+				return
+			}
+			msg := arc.NewMsg()
+			if err := msg.Unmarshal(buf); err != nil {
+				return
+			}
+			select {
+			case ts.in <- msg:
+			case <-ts.done:
+				return
+			}
+		}
+	}()
+	t.Cleanup(ts.close)
+	return ts
+}
+
+func (ts *testSess) close() {
+	select {
+	case <-ts.done:
+	default:
+		close(ts.done)
+		ts.sess.Close()
+		ts.srv.GracefulStop()
+	}
+}
+
+// send sends a msg with the given op and value under the given ReqID (or a new ReqID if 0), returning the ReqID.
+func (ts *testSess) send(reqID uint64, op arc.MsgOp, val interface{}) uint64 {
+	if reqID == 0 {
+		ts.lastReqID++
+		reqID = ts.lastReqID
+	}
+	msg := arc.NewMsg()
+	msg.ReqID = reqID
+	msg.Op = op
+	if val != nil {
+		setVal(msg, val)
+	}
+	ts.sendMsg(msg)
+	return reqID
+}
+
+// setVal sets the value of the given msg, marshalling the request values a client sends itself.
+func setVal(msg *arc.Msg, val interface{}) {
+	var valType arc.ValType
+	switch val.(type) {
+	case *arc.LoginReq:
+		valType = arc.ValType_LoginReq
+	case *arc.Defs:
+		valType = arc.ValType_Defs
+	case *arc.PinReq:
+		valType = arc.ValType_PinReq
+	case *arc.AttrRange:
+		valType = arc.ValType_AttrRange
+	default:
+		msg.SetVal(val)
+		return
+	}
+	m := val.(interface {
+		Size() int
+		MarshalToSizedBuffer([]byte) (int, error)
+	})
+	msg.SetValBuf(valType, m.Size())
+	if _, err := m.MarshalToSizedBuffer(msg.ValBuf); err != nil {
+		panic(err)
+	}
+}
+
+// sendMsg sends the given msg to the host as is.
+func (ts *testSess) sendMsg(msg *arc.Msg) {
+	ts.t.Helper()
+	if err := ts.sess.EnqueueIncoming(msg); err != nil {
+		ts.t.Fatal(err)
+	}
+}
+
+// recv returns the next msg from the host for the given req.
+func (ts *testSess) recv(reqID uint64) *arc.Msg {
+	ts.t.Helper()
+	if held := ts.held[reqID]; len(held) > 0 {
+		ts.held[reqID] = held[1:]
+		return held[0]
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg, ok := <-ts.in:
+			if !ok {
+				ts.t.Fatalf("ReqID=%d: session closed", reqID)
+			}
+			if msg.ReqID == reqID {
+				return msg
+			}
+			ts.held[msg.ReqID] = append(ts.held[msg.ReqID], msg)
+		case <-timeout:
+			ts.t.Fatalf("ReqID=%d: timed out waiting for host", reqID)
+		}
+	}
+}
+
+// closeErr returns the error carried by the given CloseReq or checkpoint msg (or nil if none).
+func closeErr(msg *arc.Msg) error {
+	if msg.ValType != int32(arc.ValType_Err) {
+		return nil
+	}
+	err := &arc.Err{}
+	if loadErr := err.Unmarshal(msg.ValBuf); loadErr != nil {
+		return loadErr
+	}
+	return err
+}
+
+// await receives msgs for the given req until it is closed, returning the error it was closed with.
+func (ts *testSess) await(reqID uint64) error {
+	ts.t.Helper()
+	for {
+		msg := ts.recv(reqID)
+		if msg.Op == arc.MsgOp_CloseReq {
+			return closeErr(msg)
+		}
+	}
+}
+
+// userKey returns a signing key for the given user, seeded from userUID so that each session of a user has the same key.
+func userKey(t *testing.T, userUID string) (ski.CryptoKit, *ski.KeyEntry) {
+	t.Helper()
+	kit, err := ski.GetCryptoKit(ski.CryptoKitID_ED25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed := sha256.Sum256([]byte(userUID))
+	key := &ski.KeyEntry{
+		KeyInfo: &ski.KeyInfo{
+			KeyType:     ski.KeyType_SigningKey,
+			CryptoKitID: ski.CryptoKitID_ED25519,
+		},
+	}
+	if err = kit.GenerateNewKey(32, bytes.NewReader(seed[:]), key); err != nil {
+		t.Fatal(err)
+	}
+	return kit, key
+}
+
+// login logs in as the given user, signing the host's login challenge with the user's key.
+func (ts *testSess) login(userUID string) error {
+	ts.t.Helper()
+	kit, key := userKey(ts.t, userUID)
+	reqID := ts.send(0, arc.MsgOp_Login, &arc.LoginReq{
+		UserUID: []byte(userUID),
+		PubKey: &arc.CryptoKey{
+			CryptoKitID: arc.CryptoKit_Signing_ED25519,
+			KeyBytes:    key.KeyInfo.PubKey,
+		},
+	})
+
+	msg := ts.recv(reqID)
+	if msg.Op == arc.MsgOp_CloseReq {
+		return closeErr(msg)
+	}
+	var challenge arc.LoginChallenge
+	if err := msg.LoadVal(&challenge); err != nil {
+		return err
+	}
+	sig, err := kit.Sign(challenge.Nonce, key.PrivKey)
+	if err != nil {
+		return err
+	}
+	ts.send(reqID, arc.MsgOp_Login, &arc.LoginChallenge{
+		Nonce:     challenge.Nonce,
+		Signature: sig,
+	})
+	for {
+		msg = ts.recv(reqID)
+		switch msg.Op {
+		case arc.MsgOp_Login:
+			var reply arc.LoginChallenge
+			if err = msg.LoadVal(&reply); err != nil {
+				return err
+			}
+			ts.resumeToken = reply.ResumeToken
+		case arc.MsgOp_CloseReq:
+			return closeErr(msg)
+		}
+	}
+}
+
+// resume resumes the session that was issued the given token over this test session's stream.
+func (ts *testSess) resume(token []byte) error {
+	ts.t.Helper()
+	reqID := ts.send(0, arc.MsgOp_Login, &arc.LoginReq{
+		ResumeToken: token,
+	})
+	return ts.await(reqID)
+}
+
+// loginAs logs in as the given user, failing the test if login fails.
+func (ts *testSess) loginAs(userUID string) {
+	ts.t.Helper()
+	if err := ts.login(userUID); err != nil {
+		ts.t.Fatal(err)
+	}
+}
+
+func (ts *testSess) register(schemas ...*arc.AttrSchema) {
+	ts.t.Helper()
+	reqID := ts.send(0, arc.MsgOp_ResolveAndRegister, &arc.Defs{Schemas: schemas})
+	if err := ts.await(reqID); err != nil {
+		ts.t.Fatal(err)
+	}
+}
+
+// pin sends the given PinReq and returns the msgs the host pushes up to the pin's first checkpoint.
+func (ts *testSess) pin(pinReq *arc.PinReq) (uint64, []*arc.Msg, error) {
+	ts.t.Helper()
+	reqID := ts.send(0, arc.MsgOp_PinCell, pinReq)
+	var msgs []*arc.Msg
+	for {
+		msg := ts.recv(reqID)
+		switch msg.Op {
+		case arc.MsgOp_CloseReq:
+			return reqID, msgs, closeErr(msg)
+		case arc.MsgOp_Commit:
+			return reqID, msgs, closeErr(msg)
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+// commit commits the given InsertCell and PushAttr msgs as a single txn.
+func (ts *testSess) commit(msgs ...*arc.Msg) error {
+	ts.t.Helper()
+	ts.lastReqID++
+	reqID := ts.lastReqID
+	for _, msg := range msgs {
+		msg.ReqID = reqID
+		ts.sendMsg(msg)
+	}
+	ts.send(reqID, arc.MsgOp_Commit, nil)
+	reply := ts.recv(reqID)
+	if reply.Op == arc.MsgOp_CloseReq {
+		return closeErr(reply)
+	}
+	return nil
+}
+
+// attrVals returns the string value of each PushAttr msg of the given cell, keyed by AttrID.
+func attrVals(msgs []*arc.Msg, cellID uint64) map[int32]string {
+	vals := make(map[int32]string)
+	for _, msg := range msgs {
+		if msg.Op == arc.MsgOp_PushAttr && msg.CellID == cellID {
+			vals[msg.AttrID] = string(msg.ValBuf)
+		}
+	}
+	return vals
+}
+
+var noteSchema = &arc.AttrSchema{
+	AttrModelURI: "test/note",
+	SchemaName:   "note",
+	SchemaID:     1,
+	Attrs: []*arc.AttrSpec{
+		{AttrURI: "title.string", AttrID: 1},
+		{AttrURI: "body.string", AttrID: 2},
+	},
+}
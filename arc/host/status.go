@@ -0,0 +1,134 @@
+package host
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+func (host *host) HostStatus() arc.HostStatus {
+	var status arc.HostStatus
+
+	host.sessMu.Lock()
+	sessions := make([]*hostSess, 0, len(host.sessions))
+	for sess := range host.sessions {
+		sessions = append(sessions, sess)
+	}
+	host.sessMu.Unlock()
+
+	for _, sess := range sessions {
+		status.Sessions = append(status.Sessions, sess.sessionInfo())
+	}
+	sort.Slice(status.Sessions, func(i, j int) bool {
+		return status.Sessions[i].SessionID < status.Sessions[j].SessionID
+	})
+
+	host.plMu.RLock()
+	planets := make([]*planetSess, 0, len(host.plSess))
+	for _, pl := range host.plSess {
+		planets = append(planets, pl)
+	}
+	host.plMu.RUnlock()
+
+	sort.Slice(planets, func(i, j int) bool {
+		return planets[i].planetID < planets[j].planetID
+	})
+	for _, pl := range planets {
+		status.Planets = append(status.Planets, arc.PlanetInfo{
+			PlanetID: pl.planetID,
+			Name:     host.planetFSName(pl.planetID),
+			Mounted:  true,
+		})
+		status.Cells = append(status.Cells, pl.cellInfos()...)
+	}
+
+	host.appsMu.RLock()
+	for appURI, inst := range host.appsByURI {
+		status.Apps = append(status.Apps, arc.AppInfo{
+			AppURI:        appURI,
+			AttrModelURIs: inst.app.AttrModelURIs(),
+		})
+	}
+	host.appsMu.RUnlock()
+	sort.Slice(status.Apps, func(i, j int) bool {
+		return status.Apps[i].AppURI < status.Apps[j].AppURI
+	})
+
+	return status
+}
+
+func (sess *hostSess) sessionInfo() arc.SessionInfo {
+	info := arc.SessionInfo{
+		SessionID:    sess.sessID,
+		HomePlanetID: atomic.LoadUint64(&sess.userPlanet),
+	}
+
+	sess.linkMu.Lock()
+	if sess.link != nil {
+		info.Desc = sess.link.via.Desc()
+	}
+	sess.linkMu.Unlock()
+
+	// Reqs not yet resolved (or since removed) are skipped
+	sess.openReqsMu.Lock()
+	for _, req := range sess.openReqs {
+		if req != nil && req.info.ReqID != 0 {
+			info.OpenReqs = append(info.OpenReqs, req.info)
+		}
+	}
+	sess.openReqsMu.Unlock()
+
+	sort.Slice(info.OpenReqs, func(i, j int) bool {
+		return info.OpenReqs[i].ReqID < info.OpenReqs[j].ReqID
+	})
+	return info
+}
+
+func (host *host) HostStatusChanged() <-chan struct{} {
+	host.statusMu.Lock()
+	defer host.statusMu.Unlock()
+
+	if host.statusCh == nil {
+		host.statusCh = make(chan struct{})
+	}
+	return host.statusCh
+}
+
+// statusChanged signals those awaiting HostStatusChanged (if any) that the host's status has changed.
+func (host *host) statusChanged() {
+	host.statusMu.Lock()
+	if host.statusCh != nil {
+		close(host.statusCh)
+		host.statusCh = nil
+	}
+	host.statusMu.Unlock()
+}
+
+// cellInfos returns info about each of this planet's active cells.
+func (pl *planetSess) cellInfos() []arc.CellInfo {
+	pl.cellsMu.Lock()
+	infos := make([]arc.CellInfo, 0, len(pl.cells))
+	for _, cell := range pl.cells {
+		info := arc.CellInfo{
+			PlanetID: pl.planetID,
+			CellID:   cell.CellID,
+			AppURI:   cell.appURI,
+		}
+		cell.subsMu.Lock()
+		for sub := cell.subsHead; sub != nil; sub = sub.next {
+			info.Subs++
+		}
+		cell.subsMu.Unlock()
+		infos = append(infos, info)
+	}
+	pl.cellsMu.Unlock()
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].CellID != infos[j].CellID {
+			return infos[i].CellID < infos[j].CellID
+		}
+		return infos[i].AppURI < infos[j].AppURI
+	})
+	return infos
+}
@@ -0,0 +1,157 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/apps/sys"
+)
+
+var (
+	sysHostSchema = &arc.AttrSchema{
+		AttrModelURI: sys.HostModel,
+		SchemaName:   "host",
+		SchemaID:     10,
+		Attrs: []*arc.AttrSpec{
+			{AttrURI: "sessions.int", AttrID: 1},
+		},
+	}
+	sysSessionSchema = &arc.AttrSchema{
+		AttrModelURI: sys.SessionModel,
+		SchemaName:   "session",
+		SchemaID:     11,
+		Attrs: []*arc.AttrSpec{
+			{AttrURI: "session-id.int", AttrID: 1},
+			{AttrURI: "status.string", AttrID: 2},
+		},
+	}
+)
+
+func TestHostStatus(t *testing.T) {
+	h := startTestHost(t)
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.register(noteSchema)
+
+	const cellID = 1<<40 + 1
+	if err := ts.commit(insertCell(cellID, noteSchema), pushAttr(cellID, 1, "hello")); err != nil {
+		t.Fatal(err)
+	}
+	pinID, _, err := ts.pin(&arc.PinReq{PinCell: cellID, ContentSchema: noteSchema.SchemaID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := h.HostStatus()
+	if len(status.Sessions) != 1 {
+		t.Fatalf("expected 1 session, got %v", status.Sessions)
+	}
+	sess := status.Sessions[0]
+	if sess.HomePlanetID == 0 || sess.Desc == "" {
+		t.Fatalf("unexpected session info %+v", sess)
+	}
+	if len(sess.OpenReqs) != 1 || sess.OpenReqs[0].ReqID != pinID || sess.OpenReqs[0].PinCell != cellID {
+		t.Fatalf("unexpected open reqs %+v", sess.OpenReqs)
+	}
+
+	mounted := false
+	for _, pl := range status.Planets {
+		mounted = mounted || pl.PlanetID == sess.HomePlanetID
+	}
+	if !mounted {
+		t.Fatalf("user home planet not listed as mounted: %v", status.Planets)
+	}
+
+	var cell *arc.CellInfo
+	for i := range status.Cells {
+		if status.Cells[i].CellID == cellID {
+			cell = &status.Cells[i]
+		}
+	}
+	if cell == nil || cell.PlanetID != sess.HomePlanetID || cell.Subs != 1 {
+		t.Fatalf("unexpected cell info %+v", cell)
+	}
+}
+
+// intAttr returns the int value of the given attr of the given cell last pushed in msgs (or -1 if none).
+func intAttr(msgs []*arc.Msg, cellID uint64, attrID int32) int64 {
+	val := int64(-1)
+	for _, msg := range msgs {
+		if msg.Op == arc.MsgOp_PushAttr && msg.CellID == cellID && msg.AttrID == attrID {
+			val = msg.ValInt
+		}
+	}
+	return val
+}
+
+func TestSysHostStatus(t *testing.T) {
+	h := startTestHost(t)
+	if err := h.RegisterApp(sys.NewApp()); err != nil {
+		t.Fatal(err)
+	}
+	pinHost := &arc.PinReq{
+		ContentSchema: sysHostSchema.SchemaID,
+		ChildSchemas:  []int32{sysSessionSchema.SchemaID},
+	}
+
+	// Only owners of the host's planet can view its status
+	bob := newTestSess(t, h)
+	bob.loginAs("bob")
+	bob.register(sysHostSchema, sysSessionSchema)
+	_, _, err := bob.pin(pinHost)
+	if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_InsufficientPermissions {
+		t.Fatalf("expected InsufficientPermissions, got %v", err)
+	}
+	bob.close()
+
+	err = h.GrantPlanetRole(&arc.PlanetGrant{PlanetID: h.homePlanetID, UserUID: []byte("alice"), Role: arc.PlanetRole_Owner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := newTestSess(t, h)
+	ts.loginAs("alice")
+	ts.register(sysHostSchema, sysSessionSchema)
+
+	reqID, msgs, err := ts.pin(pinHost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) == 0 || msgs[0].Op != arc.MsgOp_PinCell {
+		t.Fatal("expected pinned cell")
+	}
+	pinned := msgs[0].CellID
+	if n := intAttr(msgs, pinned, 1); n != 1 {
+		t.Fatalf("expected 1 session, got %d", n)
+	}
+
+	// Sessions opening and closing are pushed as they occur
+	other := newTestSess(t, h)
+	var pushed []*arc.Msg
+	for msg := ts.recv(reqID); msg.Op != arc.MsgOp_Commit; msg = ts.recv(reqID) {
+		pushed = append(pushed, msg)
+	}
+	if n := intAttr(pushed, pinned, 1); n != 2 {
+		t.Fatalf("expected 2 sessions, got %d", n)
+	}
+	var otherCell uint64
+	for _, msg := range pushed {
+		if msg.Op == arc.MsgOp_InsertCell && msg.ValInt == int64(sysSessionSchema.SchemaID) {
+			otherCell = msg.CellID
+		}
+	}
+	if otherCell == 0 {
+		t.Fatal("expected new session cell")
+	}
+
+	other.close()
+	pushed = pushed[:0]
+	for msg := ts.recv(reqID); msg.Op != arc.MsgOp_Commit; msg = ts.recv(reqID) {
+		pushed = append(pushed, msg)
+	}
+	if n := intAttr(pushed, pinned, 1); n != 1 {
+		t.Fatalf("expected 1 session, got %d", n)
+	}
+	if vals := attrVals(pushed, otherCell); vals[2] != "closed" {
+		t.Fatalf("expected closed session, got %v", vals)
+	}
+}
@@ -0,0 +1,208 @@
+package host
+
+import (
+	"sync"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// subQueue is a bounded queue of msgs outbound to the client of an open req.
+// Since each req has its own queue (drained by its own goroutine), a client that isn't keeping up only backs up its own reqs.
+//
+// Msgs of a cell's state (see pushState) are pushed far faster than a client can read them, so they're bounded separately from
+// live updates.  Since msgs leave in order, a msg taken from the queue counts against the state msgs queued while there are any.
+type subQueue struct {
+	mu       sync.Mutex
+	msgs     []*arc.Msg    // queued msgs (oldest first)
+	max      int           // max number of queued msgs (not counting state msgs)
+	maxState int           // max number of queued state msgs
+	state    int           // number of queued state msgs
+	closed   bool          // set once no more msgs are to be queued
+	ready    chan struct{} // signaled when msgs are queued or the queue closes
+}
+
+// pushResult is the outcome of subQueue.tryPush()
+type pushResult int32
+
+const (
+	queued pushResult = iota
+	coalesced
+	overflowed
+	queueClosed
+)
+
+func newSubQueue(max, maxState int) *subQueue {
+	return &subQueue{
+		max:      max,
+		maxState: maxState,
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// pushState queues the given msg of a cell's state without blocking, taking ownership of it.
+// If HostOpts.SnapshotQueueSize msgs of state are already queued, the msg is reclaimed and overflowed is returned.
+func (q *subQueue) pushState(msg *arc.Msg) pushResult {
+	q.mu.Lock()
+	result := overflowed
+	switch {
+	case q.closed:
+		result = queueClosed
+	case q.state < q.maxState:
+		q.msgs = append(q.msgs, msg)
+		q.state++
+		msg = nil
+		result = queued
+	}
+	q.mu.Unlock()
+
+	if result == queued {
+		signal(q.ready)
+	}
+	msg.Reclaim()
+	return result
+}
+
+// tryPush queues the given msgs without blocking, taking ownership of them.
+// If they don't fit and coalesce is set, queued attr values are replaced by the given (newer) values (see coalesce).
+// If the msgs are neither queued nor coalesced, the queue is left unchanged and the msgs are reclaimed.
+func (q *subQueue) tryPush(msgs []*arc.Msg, coalesce bool) pushResult {
+	q.mu.Lock()
+	result := overflowed
+	switch {
+	case q.closed:
+		result = queueClosed
+	case len(q.msgs)-q.state+len(msgs) <= q.max:
+		q.msgs = append(q.msgs, msgs...)
+		msgs = nil
+		result = queued
+	case coalesce && q.coalesce(msgs):
+		msgs = nil
+		result = coalesced
+	}
+	q.mu.Unlock()
+
+	if result == queued {
+		signal(q.ready)
+	}
+	reclaimMsgs(msgs)
+	return result
+}
+
+// coalesce replaces the queued msgs that the given msgs supersede and returns true, or returns false if any of the given
+// msgs don't supersede a queued msg (leaving the queue unchanged).
+// A PushAttr msg supersedes a queued PushAttr msg for the same cell attr item and an InsertCell msg supersedes a
+// queued InsertCell for the same cell.  A checkpoint needs no replacing since every queued update ends with one.
+func (q *subQueue) coalesce(msgs []*arc.Msg) bool {
+	idx := make([]int, len(msgs))
+	for i, msg := range msgs {
+		idx[i] = -1
+		if msg.Op == arc.MsgOp_Commit {
+			continue
+		}
+		for j := len(q.msgs) - 1; j >= 0; j-- {
+			if supersedes(msg, q.msgs[j]) {
+				idx[i] = j
+				break
+			}
+		}
+		if idx[i] < 0 {
+			return false
+		}
+	}
+
+	for i, msg := range msgs {
+		if j := idx[i]; j >= 0 {
+			q.msgs[j].Reclaim()
+			q.msgs[j] = msg
+		} else {
+			msg.Reclaim()
+		}
+	}
+	return true
+}
+
+// supersedes returns true if msg replaces the value of the given queued msg.
+func supersedes(msg, queued *arc.Msg) bool {
+	if msg.Op != queued.Op || msg.CellID != queued.CellID {
+		return false
+	}
+	switch msg.Op {
+	case arc.MsgOp_InsertCell:
+		return msg.ValInt == queued.ValInt
+	case arc.MsgOp_PushAttr:
+		return msg.AttrID == queued.AttrID && msg.SI == queued.SI && msg.FromID == queued.FromID
+	}
+	return false
+}
+
+// pop removes and returns the oldest queued msg (or nil if none), also returning true once the queue is closed and empty.
+func (q *subQueue) pop() (*arc.Msg, bool) {
+	q.mu.Lock()
+	var msg *arc.Msg
+	if len(q.msgs) > 0 {
+		msg = q.msgs[0]
+		q.msgs[0] = nil
+		q.msgs = q.msgs[1:]
+		if q.state > 0 {
+			q.state--
+		}
+	}
+	done := msg == nil && q.closed
+	q.mu.Unlock()
+
+	return msg, done
+}
+
+// close discards all queued msgs and then queues the given final msg (if non-nil), after which nothing more is queued.
+func (q *subQueue) close(final *arc.Msg) {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		final.Reclaim()
+		return
+	}
+	q.closed = true
+	reclaimMsgs(q.msgs)
+	q.msgs = nil
+	q.state = 0
+	if final != nil {
+		q.msgs = append(q.msgs, final)
+	}
+	q.mu.Unlock()
+
+	signal(q.ready)
+}
+
+// sendTo forwards queued msgs to the given outbox until the queue is closed and drained (or done is closed).
+func (q *subQueue) sendTo(outbox chan<- *arc.Msg, done <-chan struct{}) {
+	for {
+		msg, finished := q.pop()
+		if finished {
+			return
+		}
+		if msg == nil {
+			select {
+			case <-q.ready:
+				continue
+			case <-done:
+				q.close(nil)
+				return
+			}
+		}
+
+		select {
+		case outbox <- msg:
+		case <-done:
+			msg.Reclaim()
+			q.close(nil)
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// checkpoint returns a checkpoint msg for the given cell.
+func checkpoint(cellID uint64) *arc.Msg {
+	msg := arc.NewMsg()
+	msg.CellID = cellID
+	msg.Op = arc.MsgOp_Commit
+	return msg
+}
+
+func TestSubQueueOverflow(t *testing.T) {
+	q := newSubQueue(4, 4)
+
+	if res := q.tryPush([]*arc.Msg{pushAttr(7, 1, "a1"), pushAttr(7, 2, "b1"), checkpoint(7)}, true); res != queued {
+		t.Fatalf("expected queued, got %v", res)
+	}
+
+	// Newer values of queued attrs replace the queued values
+	if res := q.tryPush([]*arc.Msg{pushAttr(7, 1, "a2"), checkpoint(7)}, true); res != coalesced {
+		t.Fatalf("expected coalesced, got %v", res)
+	}
+
+	// An attr that isn't queued can't be coalesced
+	if res := q.tryPush([]*arc.Msg{pushAttr(7, 3, "c1"), pushAttr(7, 1, "a3"), checkpoint(7)}, true); res != overflowed {
+		t.Fatalf("expected overflowed, got %v", res)
+	}
+	if res := q.tryPush([]*arc.Msg{pushAttr(7, 1, "a3"), checkpoint(7)}, false); res != overflowed {
+		t.Fatalf("expected overflowed, got %v", res)
+	}
+
+	var vals []string
+	for msg, _ := q.pop(); msg != nil; msg, _ = q.pop() {
+		if msg.Op == arc.MsgOp_PushAttr {
+			vals = append(vals, string(msg.ValBuf))
+		}
+	}
+	if len(vals) != 2 || vals[0] != "a2" || vals[1] != "b1" {
+		t.Fatalf("got queued values %v", vals)
+	}
+}
+
+func TestSubQueueState(t *testing.T) {
+	q := newSubQueue(2, 3)
+
+	// A cell's state is queued without blocking, up to its own limit
+	for i := 0; i < 3; i++ {
+		if res := q.pushState(pushAttr(7, 1, "state")); res != queued {
+			t.Fatalf("expected queued, got %v", res)
+		}
+	}
+	if res := q.pushState(pushAttr(7, 1, "state")); res != overflowed {
+		t.Fatalf("expected overflowed, got %v", res)
+	}
+
+	// Live updates aren't held to the state already queued
+	if res := q.tryPush([]*arc.Msg{pushAttr(7, 2, "live"), checkpoint(7)}, false); res != queued {
+		t.Fatalf("expected queued, got %v", res)
+	}
+	if res := q.tryPush([]*arc.Msg{checkpoint(7)}, false); res != overflowed {
+		t.Fatalf("expected overflowed, got %v", res)
+	}
+
+	// Once the client reads what's queued, there's room for more state
+	if msg, _ := q.pop(); msg == nil {
+		t.Fatal("expected a queued msg")
+	}
+	if res := q.pushState(pushAttr(7, 1, "state")); res != queued {
+		t.Fatalf("expected queued, got %v", res)
+	}
+}
+
+func TestSubQueueClose(t *testing.T) {
+	q := newSubQueue(4, 4)
+	q.tryPush([]*arc.Msg{pushAttr(7, 1, "a1"), checkpoint(7)}, false)
+
+	// Closing discards what hasn't been sent and queues the final msg
+	final := arc.NewMsg()
+	final.Op = arc.MsgOp_CloseReq
+	q.close(final)
+	if res := q.tryPush([]*arc.Msg{checkpoint(7)}, false); res != queueClosed {
+		t.Fatalf("expected queueClosed, got %v", res)
+	}
+
+	outbox := make(chan *arc.Msg, 4)
+	q.sendTo(outbox, nil)
+	if len(outbox) != 1 {
+		t.Fatalf("expected only the final msg, got %d msgs", len(outbox))
+	}
+	if msg := <-outbox; msg.Op != arc.MsgOp_CloseReq {
+		t.Fatalf("expected CloseReq, got %v", msg.Op)
+	}
+}
+
+func TestDropReq(t *testing.T) {
+	sess := &hostSess{
+		openReqs: make(map[uint64]*openReq),
+	}
+	dropped, reopened := &openReq{}, &openReq{}
+	dropped.ReqID, reopened.ReqID = 7, 7
+
+	// A req dropped after the client reused its ReqID leaves the newer req open
+	sess.openReqs[7] = reopened
+	sess.dropReq(dropped)
+	if sess.openReqs[7] != reopened {
+		t.Fatal("newer req with the same ReqID was removed")
+	}
+	sess.dropReq(reopened)
+	if sess.openReqs[7] != nil {
+		t.Fatal("req was not removed")
+	}
+}
@@ -0,0 +1,59 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+func TestTxnLimits(t *testing.T) {
+	h := startTestHost(t)
+	h.opts.MaxTxnMsgs = 3
+	h.opts.MaxOpenTxns = 2
+
+	ts := newTestSess(t, h)
+	const cellID = 1<<40 + 1
+
+	// A commit requires a login, even when it names a planet
+	err := ts.commit(insertCell(cellID, noteSchema))
+	if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_CommitFailed {
+		t.Fatalf("expected commit before login to fail, got %v", err)
+	}
+	ts.lastReqID++
+	reqID := ts.send(ts.lastReqID, arc.MsgOp_Commit, &arc.Txn{PlanetID: hackHostPlanetID})
+	if msg := ts.recv(reqID); msg.Op != arc.MsgOp_CloseReq || closeErr(msg) == nil {
+		t.Fatal("expected commit to a named planet before login to fail")
+	}
+
+	ts.loginAs("alice")
+	ts.register(noteSchema)
+
+	// A txn exceeding MaxTxnMsgs fails as a whole
+	err = ts.commit(
+		insertCell(cellID, noteSchema),
+		pushAttr(cellID, 1, "1"),
+		pushAttr(cellID, 1, "2"),
+		pushAttr(cellID, 2, "3"),
+	)
+	if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_CommitFailed {
+		t.Fatalf("expected oversized txn to fail, got %v", err)
+	}
+	_, msgs, err := ts.pin(&arc.PinReq{PinCell: cellID, ContentSchema: noteSchema.SchemaID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals := attrVals(msgs, cellID); len(vals) != 0 {
+		t.Fatalf("failed txn was committed: %v", vals)
+	}
+
+	// A session can only have MaxOpenTxns pending
+	for i := 0; i < 3; i++ {
+		ts.lastReqID++
+		msg := insertCell(cellID, noteSchema)
+		msg.ReqID = ts.lastReqID
+		ts.sendMsg(msg)
+	}
+	if msg := ts.recv(ts.lastReqID); msg.Op != arc.MsgOp_CloseReq || closeErr(msg) == nil {
+		t.Fatal("expected txn beyond MaxOpenTxns to be refused")
+	}
+}
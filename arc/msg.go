@@ -132,6 +132,9 @@ func (msg *Msg) SetVal(val interface{}) {
 		msg.SetValBuf(ValType_string, len(v))
 		copy(msg.ValBuf, v)
 
+	case int64:
+		msg.SetValInt(ValType_int, v)
+
 	case time.Time:
 		msg.SetValInt(ValType_DateTime, int64(ConvertToTimeFS(v)))
 
@@ -146,6 +149,22 @@ func (msg *Msg) SetVal(val interface{}) {
 		// 	msg.SetValBuf(uint64(ValType_CellInfo), v.Size())
 		//     _, err = v.MarshalToSizedBuffer(msg.ValBuf)
 
+	case *Txn:
+		msg.SetValBuf(ValType_Txn, v.Size())
+		_, err = v.MarshalToSizedBuffer(msg.ValBuf)
+
+	case *LoginReq:
+		msg.SetValBuf(ValType_LoginReq, v.Size())
+		_, err = v.MarshalToSizedBuffer(msg.ValBuf)
+
+	case *LoginChallenge:
+		msg.SetValBuf(ValType_LoginChallenge, v.Size())
+		_, err = v.MarshalToSizedBuffer(msg.ValBuf)
+
+	case *PlanetGrant:
+		msg.SetValBuf(ValType_PlanetGrant, v.Size())
+		_, err = v.MarshalToSizedBuffer(msg.ValBuf)
+
 	case *Err:
 		msg.SetValBuf(ValType_Err, v.Size())
 		_, err = v.MarshalToSizedBuffer(msg.ValBuf)
@@ -204,6 +223,24 @@ func (msg *Msg) LoadVal(dst interface{}) error {
 			}
 		}
 
+	case int32(ValType_Txn):
+		if v, match := dst.(*Txn); match {
+			tmp := Txn{}
+			if tmp.Unmarshal(msg.ValBuf) == nil {
+				*v = tmp
+				ok = true
+			}
+		}
+
+	case int32(ValType_AttrRange):
+		if v, match := dst.(*AttrRange); match {
+			tmp := AttrRange{}
+			if tmp.Unmarshal(msg.ValBuf) == nil {
+				*v = tmp
+				ok = true
+			}
+		}
+
 	case int32(ValType_LoginReq):
 		if v, match := dst.(*LoginReq); match {
 			tmp := LoginReq{}
@@ -213,6 +250,24 @@ func (msg *Msg) LoadVal(dst interface{}) error {
 			}
 		}
 
+	case int32(ValType_LoginChallenge):
+		if v, match := dst.(*LoginChallenge); match {
+			tmp := LoginChallenge{}
+			if tmp.Unmarshal(msg.ValBuf) == nil {
+				*v = tmp
+				ok = true
+			}
+		}
+
+	case int32(ValType_PlanetGrant):
+		if v, match := dst.(*PlanetGrant); match {
+			tmp := PlanetGrant{}
+			if tmp.Unmarshal(msg.ValBuf) == nil {
+				*v = tmp
+				ok = true
+			}
+		}
+
 	}
 
 	if !ok {
@@ -0,0 +1,100 @@
+package recorder
+
+import (
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// FileExt is the file extension of a recording.
+const FileExt = ".arcrec"
+
+// MaxRecordSize is the max byte size of a MsgRecord a Reader accepts, guarding against a corrupt or hostile length prefix.
+const MaxRecordSize = 32 << 20
+
+// Create creates a recording file at the given path (and its parent dir if needed).
+// Since a recording holds everything a session sent and received, only the owner can read it.
+func Create(pathname string) (*Writer, error) {
+	if err := os.MkdirAll(path.Dir(pathname), 0700); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(pathname, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriter(file), nil
+}
+
+// NewWriter returns a Writer that appends MsgRecords to the given stream, closing it when the Writer is closed.
+func NewWriter(out io.WriteCloser) *Writer {
+	return &Writer{
+		out: out,
+	}
+}
+
+// NewReader returns a Reader that reads MsgRecords from the given stream, where a record larger than MaxRecordSize is an error.
+func NewReader(in io.Reader) *Reader {
+	return newReader(in)
+}
+
+// ReadFile returns all the MsgRecords in the given recording file.
+func ReadFile(pathname string) ([]*arc.MsgRecord, error) {
+	file, err := os.Open(pathname)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []*arc.MsgRecord
+	rd := NewReader(file)
+	for {
+		rec, err := rd.Next()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, rec)
+	}
+}
+
+// RecordStream wraps the given ServerStream so that each Msg it sends or receives is written to the given Writer.
+// Closing the returned stream closes both the given stream and Writer.
+func RecordStream(via arc.ServerStream, w *Writer) arc.ServerStream {
+	return &recordedStream{
+		via: via,
+		w:   w,
+	}
+}
+
+// ReplayOpts specifies how a recording is replayed.
+type ReplayOpts struct {
+	Timeout time.Duration // max time to wait for the host to send what it sent before the next recorded client msg
+}
+
+func DefaultReplayOpts() ReplayOpts {
+	return ReplayOpts{
+		Timeout: 5 * time.Second,
+	}
+}
+
+// Replay sends the client msgs in the given recording to a new session on the given host, returning what the host sent in reply.
+// Before each client msg is sent, Replay waits (up to opts.Timeout) for the host to send as many msgs as it did before it in the recording.
+//
+// Since a recorded login signed a nonce the replay host won't issue, each LoginReq is given a key derived from its UserUID,
+// which is used to sign the nonce the replay host issues.  Users must therefore be new to the replay host.
+// Resuming a session (LoginReq.ResumeToken) can't be replayed.
+func Replay(host arc.Host, records []*arc.MsgRecord, opts ReplayOpts) ([]*arc.MsgRecord, error) {
+	return replay(host, records, opts)
+}
+
+// Diff compares the msgs the host sent in two recordings of the same client msgs (e.g. a recording and its replay),
+// returning a description of each difference.  Msgs are compared in the order they were sent under each ReqID.
+// Login values (nonces and resume tokens) differ by design and are not compared.
+func Diff(want, got []*arc.MsgRecord) []string {
+	return diff(want, got)
+}
@@ -0,0 +1,130 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// Writer appends MsgRecords to a recording, each preceded by its byte length as a uvarint.
+type Writer struct {
+	mu  sync.Mutex
+	out io.WriteCloser // nil once closed
+	buf []byte
+	err error // first write error (after which writes are dropped)
+}
+
+// Write appends the given Msg to this recording, timestamped now.
+func (w *Writer) Write(dir arc.MsgDir, msg *arc.Msg) error {
+	rec := arc.MsgRecord{
+		TimeFS: int64(arc.TimeNowFS()),
+		Dir:    dir,
+		Msg:    msg,
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.out == nil || w.err != nil {
+		return w.err
+	}
+
+	sz := rec.Size()
+	need := binary.MaxVarintLen64 + sz
+	if need > cap(w.buf) {
+		w.buf = make([]byte, (need+0x3FF)&^0x3FF)
+	}
+	buf := w.buf[:cap(w.buf)]
+	n := binary.PutUvarint(buf, uint64(sz))
+	if _, w.err = rec.MarshalToSizedBuffer(buf[n : n+sz]); w.err == nil {
+		_, w.err = w.out.Write(buf[:n+sz])
+	}
+	return w.err
+}
+
+// Close closes this recording's output stream, after which writes are dropped.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.out == nil {
+		return nil
+	}
+	err := w.out.Close()
+	w.out = nil
+	return err
+}
+
+// Reader reads the MsgRecords of a recording.
+type Reader struct {
+	in  *bufio.Reader
+	buf []byte
+}
+
+func newReader(in io.Reader) *Reader {
+	return &Reader{
+		in: bufio.NewReader(in),
+	}
+}
+
+// Next returns the next MsgRecord in this recording, or io.EOF if there are no more.
+func (rd *Reader) Next() (*arc.MsgRecord, error) {
+	sz, err := binary.ReadUvarint(rd.in)
+	if err != nil {
+		return nil, err
+	}
+	if sz > MaxRecordSize {
+		return nil, arc.ErrCode_BadValue.Errorf("record size %d exceeds max %d", sz, MaxRecordSize)
+	}
+	if sz > uint64(cap(rd.buf)) {
+		rd.buf = make([]byte, sz)
+	}
+	buf := rd.buf[:sz]
+	if _, err = io.ReadFull(rd.in, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	rec := &arc.MsgRecord{}
+	if err = rec.Unmarshal(buf); err != nil {
+		return nil, arc.ErrCode_BadValue.Wrap(err)
+	}
+	return rec, nil
+}
+
+// recordedStream records each Msg sent or received over the ServerStream it wraps.
+// A failed recording does not disrupt the stream.
+type recordedStream struct {
+	via arc.ServerStream
+	w   *Writer
+}
+
+func (rs *recordedStream) Desc() string {
+	return rs.via.Desc()
+}
+
+func (rs *recordedStream) Close() {
+	rs.via.Close()
+	rs.w.Close()
+}
+
+func (rs *recordedStream) SendMsg(msg *arc.Msg) error {
+	err := rs.via.SendMsg(msg)
+	if err == nil {
+		rs.w.Write(arc.MsgDir_ToClient, msg)
+	}
+	return err
+}
+
+func (rs *recordedStream) RecvMsg() (*arc.Msg, error) {
+	msg, err := rs.via.RecvMsg()
+	if err == nil && msg != nil {
+		rs.w.Write(arc.MsgDir_ToHost, msg)
+	}
+	return msg, err
+}
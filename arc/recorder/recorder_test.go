@@ -0,0 +1,229 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/host/hosttest"
+	"github.com/arcspace/go-arcspace/arc/lib_service"
+)
+
+// pipeStream is a ServerStream whose client side is driven by a test directly.
+type pipeStream struct {
+	toHost    chan *arc.Msg
+	toClient  chan *arc.Msg
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func newPipeStream() *pipeStream {
+	return &pipeStream{
+		toHost:   make(chan *arc.Msg),
+		toClient: make(chan *arc.Msg, 64),
+		closing:  make(chan struct{}),
+	}
+}
+
+func (ps *pipeStream) Desc() string {
+	return "pipe"
+}
+
+func (ps *pipeStream) Close() {
+	ps.closeOnce.Do(func() {
+		close(ps.closing)
+	})
+}
+
+func (ps *pipeStream) SendMsg(msg *arc.Msg) error {
+	select {
+	case ps.toClient <- arc.CopyMsg(msg):
+		return nil
+	case <-ps.closing:
+		return arc.ErrStreamClosed
+	}
+}
+
+func (ps *pipeStream) RecvMsg() (*arc.Msg, error) {
+	select {
+	case msg := <-ps.toHost:
+		return msg, nil
+	case <-ps.closing:
+		return nil, arc.ErrStreamClosed
+	}
+}
+
+// send sends the given msg from the client side.
+func (ps *pipeStream) send(t *testing.T, msg *arc.Msg) {
+	t.Helper()
+	select {
+	case ps.toHost <- msg:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out sending to host")
+	}
+}
+
+// sendVal sends a msg with the given ReqID, op and value from the client side.
+func (ps *pipeStream) sendVal(t *testing.T, reqID uint64, op arc.MsgOp, val interface{}) {
+	t.Helper()
+	msg := arc.NewMsg()
+	msg.ReqID = reqID
+	msg.Op = op
+	if pinReq, ok := val.(*arc.PinReq); ok {
+		msg.SetValBuf(arc.ValType_PinReq, pinReq.Size())
+		if _, err := pinReq.MarshalToSizedBuffer(msg.ValBuf); err != nil {
+			t.Fatal(err)
+		}
+	} else if val != nil {
+		msg.SetVal(val)
+	}
+	ps.send(t, msg)
+}
+
+// recvUntil receives msgs on the client side until one with the given ReqID and op arrives, returning it.
+func (ps *pipeStream) recvUntil(t *testing.T, reqID uint64, op arc.MsgOp) *arc.Msg {
+	t.Helper()
+	for timeout := time.After(5 * time.Second); ; {
+		select {
+		case msg := <-ps.toClient:
+			if msg.ReqID == reqID && msg.Op == op {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("ReqID=%d: timed out waiting for %v", reqID, op)
+		}
+	}
+}
+
+var noteSchema = &arc.AttrSchema{
+	AttrModelURI: "test/note",
+	SchemaName:   "note",
+	SchemaID:     1,
+	Attrs: []*arc.AttrSpec{
+		{AttrURI: "title.string", AttrID: 1},
+	},
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	h := hosttest.StartTestHost(t)
+	srv := lib_service.DefaultLibServiceOpts().NewLibService()
+	if err := srv.StartService(h); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.GracefulStop()
+
+	pathname := t.TempDir() + "/sess" + FileExt
+	w, err := Create(pathname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(pathname); err != nil || fi.Mode().Perm() != 0600 {
+		t.Fatalf("expected recording with mode 0600, got %v", err)
+	}
+	pipe := newPipeStream()
+	stream := RecordStream(pipe, w)
+	if _, err = h.StartNewSession(srv, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	// Log in, then store and pin a cell
+	login, err := newReplayLogin([]byte("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipe.sendVal(t, 1, arc.MsgOp_Login, &arc.LoginReq{
+		UserUID: []byte("alice"),
+		PubKey: &arc.CryptoKey{
+			CryptoKitID: arc.CryptoKit_Signing_ED25519,
+			KeyBytes:    login.key.KeyInfo.PubKey,
+		},
+	})
+	var challenge arc.LoginChallenge
+	if err = pipe.recvUntil(t, 1, arc.MsgOp_Login).LoadVal(&challenge); err != nil {
+		t.Fatal(err)
+	}
+	sig, err := login.kit.Sign(challenge.Nonce, login.key.PrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipe.sendVal(t, 1, arc.MsgOp_Login, &arc.LoginChallenge{
+		Nonce:     challenge.Nonce,
+		Signature: sig,
+	})
+	pipe.recvUntil(t, 1, arc.MsgOp_CloseReq)
+
+	pipe.sendVal(t, 2, arc.MsgOp_ResolveAndRegister, &arc.Defs{Schemas: []*arc.AttrSchema{noteSchema}})
+	pipe.recvUntil(t, 2, arc.MsgOp_CloseReq)
+
+	const cellID = 1<<40 + 1
+	insert := arc.NewMsg()
+	insert.ReqID = 3
+	insert.CellID = cellID
+	insert.Op = arc.MsgOp_InsertCell
+	insert.SetValInt(arc.ValType_SchemaID, int64(noteSchema.SchemaID))
+	pipe.send(t, insert)
+	push := arc.NewMsg()
+	push.ReqID = 3
+	push.CellID = cellID
+	push.Op = arc.MsgOp_PushAttr
+	push.AttrID = 1
+	push.SetVal("hello")
+	pipe.send(t, push)
+	pipe.sendVal(t, 3, arc.MsgOp_Commit, nil)
+	pipe.recvUntil(t, 3, arc.MsgOp_Commit)
+
+	pipe.sendVal(t, 4, arc.MsgOp_PinCell, &arc.PinReq{PinCell: cellID, ContentSchema: noteSchema.SchemaID})
+	pipe.recvUntil(t, 4, arc.MsgOp_Commit)
+	stream.Close()
+
+	records, err := ReadFile(pathname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := 0
+	for i, rec := range records {
+		if i > 0 && rec.TimeFS < records[i-1].TimeFS {
+			t.Fatal("records out of order")
+		}
+		if rec.Dir == arc.MsgDir_ToHost {
+			sent++
+		}
+	}
+	if sent != 7 || records[0].Dir != arc.MsgDir_ToHost || records[0].Msg.Op != arc.MsgOp_Login {
+		t.Fatalf("unexpected recording: %v", records)
+	}
+
+	// A fresh host replies the same way
+	got, err := Replay(hosttest.StartTestHost(t), records, DefaultReplayOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diffs := Diff(records, got); len(diffs) != 0 {
+		t.Fatalf("replay differs from recording: %v", diffs)
+	}
+
+	// ... and a host that replies differently is caught
+	for _, rec := range got {
+		if rec.Msg.Op == arc.MsgOp_PushAttr && rec.Msg.ReqID == 4 {
+			rec.Msg.SetVal("goodbye")
+		}
+	}
+	if diffs := Diff(records, got); len(diffs) != 1 {
+		t.Fatalf("expected 1 difference, got %v", diffs)
+	}
+}
+
+func TestReaderMaxRecordSize(t *testing.T) {
+
+	// A length prefix beyond MaxRecordSize is refused before anything is allocated for it
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, 1<<62)
+	_, err := NewReader(bytes.NewReader(buf[:n])).Next()
+	if plErr, _ := err.(*arc.Err); plErr == nil || plErr.Code != arc.ErrCode_BadValue {
+		t.Fatalf("expected ErrCode_BadValue, got %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+package recorder
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/lib_service"
+	"github.com/arcspace/go-arcspace/ski"
+	_ "github.com/arcspace/go-arcspace/ski/ed25519" // replay login signing kit
+)
+
+// replayer plays a recording's client msgs over a lib_service session and collects what the host sends in reply.
+type replayer struct {
+	opts   ReplayOpts
+	sess   lib_service.LibSession
+	mu     sync.Mutex
+	got    []*arc.MsgRecord
+	recvd  chan struct{}           // signaled when a msg is received from the host
+	done   chan struct{}           // closed once the host stops sending
+	logins map[uint64]*replayLogin // by ReqID
+}
+
+// replayLogin is the key a replayed login signs the replay host's challenge with.
+type replayLogin struct {
+	kit ski.CryptoKit
+	key *ski.KeyEntry
+}
+
+func replay(host arc.Host, records []*arc.MsgRecord, opts ReplayOpts) ([]*arc.MsgRecord, error) {
+	srv := lib_service.DefaultLibServiceOpts().NewLibService()
+	if err := srv.StartService(host); err != nil {
+		return nil, err
+	}
+	defer srv.GracefulStop()
+
+	sess, err := srv.NewLibSession()
+	if err != nil {
+		return nil, err
+	}
+
+	rp := &replayer{
+		opts:   opts,
+		sess:   sess,
+		recvd:  make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		logins: make(map[uint64]*replayLogin),
+	}
+	go rp.recvMsgs()
+
+	// Pace each client msg by the number of host msgs sent before it in the recording
+	expected := 0
+	for _, rec := range records {
+		if rec.Dir == arc.MsgDir_ToClient {
+			expected++
+			continue
+		}
+		if rec.Msg == nil {
+			continue
+		}
+		rp.await(rp.recvdAtLeast(expected))
+
+		msg := arc.CopyMsg(rec.Msg)
+		if err = rp.rewriteLogin(msg); err != nil {
+			msg.Reclaim()
+			break
+		}
+
+		// If the host has closed the session, what it sent before closing is all there is to compare
+		if sess.EnqueueIncoming(msg) != nil {
+			msg.Reclaim()
+			break
+		}
+	}
+	if err == nil {
+		rp.await(rp.recvdAtLeast(expected))
+	}
+
+	sess.Close()
+	<-rp.done
+	return rp.got, err
+}
+
+// recvMsgs collects the msgs the host sends until the session closes.
+func (rp *replayer) recvMsgs() {
+	defer close(rp.done)
+
+	var buf []byte
+	for {
+		if err := rp.sess.DequeueOutgoing(&buf); err != nil {
+			return
+		}
+		msg := &arc.Msg{}
+		if err := msg.Unmarshal(buf); err != nil {
+			return
+		}
+		rp.mu.Lock()
+		rp.got = append(rp.got, &arc.MsgRecord{
+			TimeFS: int64(arc.TimeNowFS()),
+			Dir:    arc.MsgDir_ToClient,
+			Msg:    msg,
+		})
+		rp.mu.Unlock()
+
+		select {
+		case rp.recvd <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// await blocks until the given condition is met, the host stops sending, or opts.Timeout elapses.
+func (rp *replayer) await(ready func() bool) {
+	timeout := time.NewTimer(rp.opts.Timeout)
+	defer timeout.Stop()
+
+	for !ready() {
+		select {
+		case <-rp.recvd:
+		case <-rp.done:
+			return
+		case <-timeout.C:
+			return
+		}
+	}
+}
+
+// recvdAtLeast returns a condition met once the host has sent at least n msgs.
+func (rp *replayer) recvdAtLeast(n int) func() bool {
+	return func() bool {
+		rp.mu.Lock()
+		have := len(rp.got)
+		rp.mu.Unlock()
+		return have >= n
+	}
+}
+
+// nonce returns the login nonce the host last issued for the given ReqID (or nil if none).
+func (rp *replayer) nonce(reqID uint64) []byte {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	for i := len(rp.got) - 1; i >= 0; i-- {
+		msg := rp.got[i].Msg
+		if msg.ReqID != reqID || msg.Op != arc.MsgOp_Login {
+			continue
+		}
+		var challenge arc.LoginChallenge
+		if msg.LoadVal(&challenge) == nil && len(challenge.Nonce) > 0 {
+			return challenge.Nonce
+		}
+	}
+	return nil
+}
+
+// rewriteLogin replaces the key and signature of a recorded login with ones the replay host accepts.
+func (rp *replayer) rewriteLogin(msg *arc.Msg) error {
+	if msg.Op != arc.MsgOp_Login {
+		return nil
+	}
+
+	switch msg.ValType {
+
+	case int32(arc.ValType_LoginReq):
+		var req arc.LoginReq
+		if err := msg.LoadVal(&req); err != nil {
+			return err
+		}
+		if len(req.ResumeToken) > 0 {
+			return nil
+		}
+		login, err := newReplayLogin(req.UserUID)
+		if err != nil {
+			return err
+		}
+		rp.logins[msg.ReqID] = login
+		req.PubKey = &arc.CryptoKey{
+			CryptoKitID: arc.CryptoKit_Signing_ED25519,
+			KeyBytes:    login.key.KeyInfo.PubKey,
+		}
+		msg.SetVal(&req)
+
+	case int32(arc.ValType_LoginChallenge):
+		login := rp.logins[msg.ReqID]
+		if login == nil {
+			return nil
+		}
+
+		// The recording may have captured the signed reply before the challenge it answers
+		var nonce []byte
+		rp.await(func() bool {
+			nonce = rp.nonce(msg.ReqID)
+			return nonce != nil
+		})
+		if nonce == nil {
+			return nil
+		}
+		sig, err := login.kit.Sign(nonce, login.key.PrivKey)
+		if err != nil {
+			return err
+		}
+		msg.SetVal(&arc.LoginChallenge{
+			Nonce:     nonce,
+			Signature: sig,
+		})
+	}
+
+	return nil
+}
+
+// newReplayLogin returns a signing key seeded from the given userUID so that each replay of a user has the same key.
+func newReplayLogin(userUID []byte) (*replayLogin, error) {
+	kit, err := ski.GetCryptoKit(ski.CryptoKitID_ED25519)
+	if err != nil {
+		return nil, err
+	}
+	seed := sha256.Sum256(userUID)
+	key := &ski.KeyEntry{
+		KeyInfo: &ski.KeyInfo{
+			KeyType:     ski.KeyType_SigningKey,
+			CryptoKitID: ski.CryptoKitID_ED25519,
+		},
+	}
+	if err = kit.GenerateNewKey(32, bytes.NewReader(seed[:]), key); err != nil {
+		return nil, err
+	}
+	return &replayLogin{
+		kit: kit,
+		key: key,
+	}, nil
+}
+
+func diff(want, got []*arc.MsgRecord) []string {
+	wantByReq := hostMsgsByReq(want)
+	gotByReq := hostMsgsByReq(got)
+
+	reqIDs := make([]uint64, 0, len(wantByReq)+len(gotByReq))
+	for reqID := range wantByReq {
+		reqIDs = append(reqIDs, reqID)
+	}
+	for reqID := range gotByReq {
+		if _, exists := wantByReq[reqID]; !exists {
+			reqIDs = append(reqIDs, reqID)
+		}
+	}
+	sort.Slice(reqIDs, func(i, j int) bool {
+		return reqIDs[i] < reqIDs[j]
+	})
+
+	var diffs []string
+	for _, reqID := range reqIDs {
+		w, g := wantByReq[reqID], gotByReq[reqID]
+		for i := 0; i < len(w) || i < len(g); i++ {
+			switch {
+			case i >= len(g):
+				diffs = append(diffs, fmt.Sprintf("ReqID=%d msg %d missing: %v", reqID, i, w[i]))
+			case i >= len(w):
+				diffs = append(diffs, fmt.Sprintf("ReqID=%d msg %d unexpected: %v", reqID, i, g[i]))
+			case !sameMsg(w[i], g[i]):
+				diffs = append(diffs, fmt.Sprintf("ReqID=%d msg %d differs:\n  want: %v\n   got: %v", reqID, i, w[i], g[i]))
+			}
+		}
+	}
+	return diffs
+}
+
+// hostMsgsByReq returns the msgs the host sent in the given recording, by ReqID.
+func hostMsgsByReq(records []*arc.MsgRecord) map[uint64][]*arc.Msg {
+	byReq := make(map[uint64][]*arc.Msg)
+	for _, rec := range records {
+		if rec.Dir == arc.MsgDir_ToClient && rec.Msg != nil {
+			byReq[rec.Msg.ReqID] = append(byReq[rec.Msg.ReqID], rec.Msg)
+		}
+	}
+	return byReq
+}
+
+// sameMsg reports if the given host msgs are equivalent, ignoring login values (which differ by design).
+func sameMsg(a, b *arc.Msg) bool {
+	if a.Op == arc.MsgOp_Login && b.Op == arc.MsgOp_Login {
+		return true
+	}
+	return a.Equal(b)
+}
@@ -0,0 +1,41 @@
+package sock_service
+
+import (
+	"os"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// SockServerOpts exposes options and params of a HostService listening on a Unix domain socket (or plain TCP).
+//
+// Each Msg is framed as its byte size (as a uvarint) followed by the serialized arc.Msg (see Dial).
+type SockServerOpts struct {
+	ServiceURI    string
+	ListenNetwork string      // "unix" or "tcp"
+	ListenAddr    string      // socket pathname (or host:port for "tcp")
+	SocketMode    os.FileMode // permissions of the socket file, governing which local users can connect ("unix" only)
+	MaxMsgSize    int64       // max byte size of a Msg sent by a client
+	RecordPath    string      // if set, each session's msgs are recorded to a new file in this dir (see recorder.ReadFile)
+}
+
+// DefaultSockServerOpts returns the default options for a SockServer listening on the given socket pathname,
+// where only the user running the host can connect.
+func DefaultSockServerOpts(socketPath string) SockServerOpts {
+	return SockServerOpts{
+		ServiceURI:    "sock",
+		ListenNetwork: "unix",
+		ListenAddr:    socketPath,
+		SocketMode:    0600,
+		MaxMsgSize:    DefaultMaxMsgSize,
+	}
+}
+
+// DefaultMaxMsgSize is the default max byte size of a Msg received over a socket.
+const DefaultMaxMsgSize = 32 << 20
+
+func (opts SockServerOpts) NewSockServer() arc.HostService {
+	return &sockServer{
+		opts:     opts,
+		sessions: make(map[*sockSess]struct{}),
+	}
+}
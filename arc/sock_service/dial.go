@@ -0,0 +1,40 @@
+package sock_service
+
+import (
+	"net"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// Conn is a client connection to a SockServer, carrying a host session.
+type Conn struct {
+	msgStream
+}
+
+// Dial connects to a SockServer listening on the given network ("unix" or "tcp") and address.
+func Dial(network, addr string) (*Conn, error) {
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, arc.ErrCode_Disconnected.Errorf("failed to dial %v %v: %v", network, addr, err)
+	}
+	return &Conn{
+		msgStream: newMsgStream(conn, DefaultMaxMsgSize),
+	}, nil
+}
+
+// SendMsg sends the given Msg to the host, which can be reused once this returns.
+// Only one goroutine can send at a time.
+func (c *Conn) SendMsg(msg *arc.Msg) error {
+	return c.msgStream.SendMsg(msg)
+}
+
+// RecvMsg blocks until the next Msg from the host arrives, returning arc.ErrStreamClosed once the connection closes.
+// Only one goroutine can receive at a time.
+func (c *Conn) RecvMsg() (*arc.Msg, error) {
+	return c.msgStream.RecvMsg()
+}
+
+// Close closes this connection, ending its host session.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,79 @@
+package sock_service
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// msgStream sends and receives Msgs over a socket, each framed as its byte size (as a uvarint) followed by the serialized Msg.
+// SendMsg and RecvMsg can each be called from a different goroutine.
+type msgStream struct {
+	conn       net.Conn
+	rd         *bufio.Reader
+	buf        []byte // reused to frame outbound msgs (only accessed by SendMsg)
+	maxMsgSize int64
+}
+
+func newMsgStream(conn net.Conn, maxMsgSize int64) msgStream {
+	if maxMsgSize <= 0 {
+		maxMsgSize = DefaultMaxMsgSize
+	}
+	return msgStream{
+		conn:       conn,
+		rd:         bufio.NewReader(conn),
+		maxMsgSize: maxMsgSize,
+	}
+}
+
+func (ms *msgStream) SendMsg(msg *arc.Msg) error {
+	sz := msg.Size()
+	need := binary.MaxVarintLen64 + sz
+	if cap(ms.buf) < need {
+		ms.buf = make([]byte, need)
+	}
+	buf := ms.buf[:need]
+	n := binary.PutUvarint(buf, uint64(sz))
+	if _, err := msg.MarshalToSizedBuffer(buf[n : n+sz]); err != nil {
+		return err
+	}
+	if _, err := ms.conn.Write(buf[:n+sz]); err != nil {
+		return streamErr(err)
+	}
+	return nil
+}
+
+func (ms *msgStream) RecvMsg() (*arc.Msg, error) {
+	sz, err := binary.ReadUvarint(ms.rd)
+	if err != nil {
+		return nil, streamErr(err)
+	}
+	if sz > uint64(ms.maxMsgSize) {
+		return nil, arc.ErrCode_BadValue.Errorf("msg size %d exceeds max %d", sz, ms.maxMsgSize)
+	}
+	buf := make([]byte, sz)
+	if _, err = io.ReadFull(ms.rd, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	msg := arc.NewMsg()
+	if err = msg.Unmarshal(buf); err != nil {
+		msg.Reclaim()
+		return nil, arc.ErrCode_BadValue.Wrap(err)
+	}
+	return msg, nil
+}
+
+// streamErr returns arc.ErrStreamClosed if the given error is due to a normal close of the stream.
+func streamErr(err error) error {
+	if err == io.EOF || errors.Is(err, net.ErrClosed) {
+		return arc.ErrStreamClosed
+	}
+	return err
+}
@@ -0,0 +1,290 @@
+package sock_service
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/recorder"
+	"github.com/arcspace/go-cedar/process"
+	"github.com/arcspace/go-cedar/utils"
+)
+
+// sockServer is a HostService that accepts socket connections, each of which carries one host session.
+type sockServer struct {
+	numSess uint64 // number of sessions started (used to name recordings)
+	process.Context
+	lis      net.Listener
+	host     arc.Host
+	opts     SockServerOpts
+	sessions map[*sockSess]struct{} // open sessions
+	sessMu   sync.Mutex             // protects sessions
+	sessWG   sync.WaitGroup         // open sessions (so GracefulStop can wait on them)
+}
+
+func (srv *sockServer) ServiceURI() string {
+	return srv.opts.ServiceURI
+}
+
+func (srv *sockServer) Host() arc.Host {
+	return srv.host
+}
+
+func (srv *sockServer) StartService(on arc.Host) error {
+	if srv.host != nil || srv.lis != nil || srv.Context != nil {
+		panic("already started")
+	}
+	srv.host = on
+
+	var err error
+	srv.lis, err = srv.listen()
+	if err != nil {
+		return err
+	}
+
+	srv.Context, err = srv.host.StartChild(&process.Task{
+		Label:     fmt.Sprint(srv.ServiceURI(), ".HostService"),
+		IdleClose: time.Nanosecond,
+		OnRun: func(ctx process.Context) {
+			srv.Infof(0, "Serving on \x1b[1;32m%v %v\x1b[0m", srv.opts.ListenNetwork, srv.lis.Addr())
+			for {
+				conn, err := srv.lis.Accept()
+				if err != nil {
+					break
+				}
+				go srv.serveConn(conn)
+			}
+			srv.Info(2, "Serve COMPLETE")
+		},
+		OnClosing: func() {
+			srv.Info(1, "Stop")
+			srv.lis.Close()
+			srv.closeSessions()
+			srv.Info(2, "Stop COMPLETE")
+		},
+	})
+	if err != nil {
+		srv.lis.Close()
+		return err
+	}
+
+	return nil
+}
+
+// listen listens on opts.ListenAddr.
+// For a Unix socket, a stale socket file is replaced and the new one is restricted to opts.SocketMode before anyone can connect:
+// it's bound in a new dir only this user can access, and moved to opts.ListenAddr once its mode is set.
+func (srv *sockServer) listen() (net.Listener, error) {
+	addr := srv.opts.ListenAddr
+	if srv.opts.ListenNetwork != "unix" {
+		lis, err := net.Listen(srv.opts.ListenNetwork, addr)
+		if err != nil {
+			return nil, errors.Errorf("failed to listen: %v", err)
+		}
+		return lis, nil
+	}
+
+	dir, err := utils.ExpandAndCheckPath(path.Dir(addr), true)
+	if err != nil {
+		return nil, err
+	}
+	addr = path.Join(dir, path.Base(addr))
+
+	// A socket file left by a host that didn't shut down cleanly refuses connections
+	if err = checkSocketAddr(addr); err != nil {
+		return nil, err
+	}
+	if _, err := os.Lstat(addr); err == nil {
+		if conn, err := net.Dial("unix", addr); err == nil {
+			conn.Close()
+			return nil, errors.Errorf("socket %q already in use", addr)
+		}
+		os.Remove(addr)
+	}
+
+	bindDir, err := os.MkdirTemp(dir, ".bind-")
+	if err != nil {
+		return nil, errors.Errorf("failed to create socket dir: %v", err)
+	}
+	defer os.RemoveAll(bindDir)
+	if err = os.Chmod(bindDir, 0700); err != nil {
+		return nil, errors.Errorf("failed to set socket dir permissions: %v", err)
+	}
+
+	bindAddr := path.Join(bindDir, path.Base(addr))
+	lis, err := net.Listen("unix", bindAddr)
+	if err != nil {
+		return nil, errors.Errorf("failed to listen: %v", err)
+	}
+	lis.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if srv.opts.SocketMode != 0 {
+		err = os.Chmod(bindAddr, srv.opts.SocketMode)
+		if err != nil {
+			err = errors.Errorf("failed to set socket permissions: %v", err)
+		}
+	}
+	if err == nil {
+		err = checkSocketAddr(addr)
+	}
+	if err == nil {
+		if err = os.Rename(bindAddr, addr); err != nil {
+			err = errors.Errorf("failed to move socket into place: %v", err)
+		}
+	}
+	if err != nil {
+		lis.Close()
+		return nil, err
+	}
+	return &unixListener{
+		Listener: lis,
+		pathname: addr,
+	}, nil
+}
+
+// checkSocketAddr returns an error unless the given pathname is absent or a socket, so that moving a socket there
+// doesn't replace some other file (e.g. given by mistake).
+func checkSocketAddr(addr string) error {
+	fi, err := os.Lstat(addr)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Errorf("failed to check socket path: %v", err)
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return errors.Errorf("%q already exists and is not a socket", addr)
+	}
+	return nil
+}
+
+// unixListener removes its socket file once closed since it was bound under another pathname (see listen).
+type unixListener struct {
+	net.Listener
+	pathname  string
+	closeOnce sync.Once
+}
+
+func (lis *unixListener) Close() error {
+	err := lis.Listener.Close()
+	lis.closeOnce.Do(func() {
+		os.Remove(lis.pathname)
+	})
+	return err
+}
+
+// GracefulStop stops accepting new connections and blocks until each open session has closed.
+func (srv *sockServer) GracefulStop() {
+	if srv.lis != nil {
+		srv.Info(0, "GracefulStop")
+		srv.lis.Close()
+		srv.sessWG.Wait()
+	}
+}
+
+// serveConn starts a new host session over the given connection, blocking until the session's stream closes.
+func (srv *sockServer) serveConn(conn net.Conn) {
+	sess := &sockSess{
+		msgStream: newMsgStream(conn, srv.opts.MaxMsgSize),
+		srv:       srv,
+		closing:   make(chan struct{}),
+	}
+
+	var via arc.ServerStream = sess
+	if srv.opts.RecordPath != "" {
+		w, err := srv.newRecording()
+		if err != nil {
+			srv.Warnf("%v", err)
+			conn.Close()
+			return
+		}
+		via = recorder.RecordStream(sess, w)
+	}
+
+	if !srv.addSess(sess) {
+		via.Close()
+		conn.Close()
+		return
+	}
+	defer srv.removeSess(sess)
+
+	var err error
+	sess.hostSess, err = srv.host.StartNewSession(srv, via)
+	if err != nil {
+		srv.Warnf("failed to start session: %v", err)
+		via.Close()
+	}
+
+	// Block until the host closes this stream.
+	// Note the host session may close before then since another session can be resumed over this stream.
+	<-sess.closing
+	conn.Close()
+}
+
+// addSess adds the given session to the open sessions, returning false if this service is closing.
+func (srv *sockServer) addSess(sess *sockSess) bool {
+	srv.sessMu.Lock()
+	defer srv.sessMu.Unlock()
+
+	select {
+	case <-srv.Closing():
+		return false
+	default:
+	}
+	srv.sessions[sess] = struct{}{}
+	srv.sessWG.Add(1)
+	return true
+}
+
+func (srv *sockServer) removeSess(sess *sockSess) {
+	srv.sessMu.Lock()
+	delete(srv.sessions, sess)
+	srv.sessMu.Unlock()
+	srv.sessWG.Done()
+}
+
+// closeSessions closes the stream of each open session.
+func (srv *sockServer) closeSessions() {
+	srv.sessMu.Lock()
+	defer srv.sessMu.Unlock()
+
+	for sess := range srv.sessions {
+		sess.Close()
+	}
+}
+
+// newRecording creates a file in opts.RecordPath to record a new session to.
+func (srv *sockServer) newRecording() (*recorder.Writer, error) {
+	sessNum := atomic.AddUint64(&srv.numSess, 1)
+	name := fmt.Sprintf("%s-%s-%d%s", time.Now().Format("20060102-150405"), srv.ServiceURI(), sessNum, recorder.FileExt)
+	w, err := recorder.Create(path.Join(srv.opts.RecordPath, name))
+	if err != nil {
+		return nil, errors.Errorf("failed to create recording: %v", err)
+	}
+	return w, nil
+}
+
+type sockSess struct {
+	msgStream
+	closed   int32
+	closing  chan struct{}
+	srv      *sockServer
+	hostSess arc.HostSession
+}
+
+func (sess *sockSess) Desc() string {
+	return sess.srv.ServiceURI()
+}
+
+func (sess *sockSess) Close() {
+	if atomic.CompareAndSwapInt32(&sess.closed, 0, 1) {
+		close(sess.closing)
+	}
+}
@@ -0,0 +1,118 @@
+package sock_service
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/host/hosttest"
+)
+
+func TestSockService(t *testing.T) {
+	dir := t.TempDir()
+	h := hosttest.StartTestHost(t)
+
+	// A stale socket file is replaced
+	socketPath := dir + "/archost.sock"
+	stale, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	opts := DefaultSockServerOpts(socketPath)
+	srv := opts.NewSockServer()
+	if err = srv.StartService(h); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := fi.Mode().Perm(); mode != 0600 {
+		t.Fatalf("expected socket mode 0600, got %v", mode)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("expected only the socket in its dir, got %v", entries)
+	}
+
+	// A socket in use isn't replaced
+	if err = DefaultSockServerOpts(socketPath).NewSockServer().StartService(h); err == nil {
+		t.Fatal("expected socket in use to fail")
+	}
+
+	// Nor is a file that isn't a socket
+	notSocket := dir + "/archost.conf"
+	if err = os.WriteFile(notSocket, []byte("conf"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = DefaultSockServerOpts(notSocket).NewSockServer().StartService(h); err == nil {
+		t.Fatal("expected existing file to fail")
+	}
+	if buf, _ := os.ReadFile(notSocket); string(buf) != "conf" {
+		t.Fatalf("expected existing file to be left as is, got %q", buf)
+	}
+
+	tcpOpts := DefaultSockServerOpts("")
+	tcpOpts.ListenNetwork = "tcp"
+	tcpOpts.ListenAddr = "127.0.0.1:0"
+	tcpSrv := tcpOpts.NewSockServer()
+	if err = tcpSrv.StartService(h); err != nil {
+		t.Fatal(err)
+	}
+
+	// The host replies to each framed Msg in kind (here refusing a pin before login)
+	roundTrip := func(conn *Conn) {
+		t.Helper()
+		msg := arc.NewMsg()
+		msg.ReqID = 7
+		msg.Op = arc.MsgOp_PinCell
+		if err := conn.SendMsg(msg); err != nil {
+			t.Fatal(err)
+		}
+		reply, err := conn.RecvMsg()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reply.ReqID != 7 || reply.Op != arc.MsgOp_CloseReq || reply.ValType != int32(arc.ValType_Err) {
+			t.Fatalf("expected req to be closed with an error, got %v", reply)
+		}
+	}
+	tcpAddr := tcpSrv.(*sockServer).lis.Addr()
+	tcpConn, err := Dial(tcpAddr.Network(), tcpAddr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip(tcpConn)
+	tcpConn.Close()
+
+	conn, err := Dial("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	roundTrip(conn)
+
+	// Closing the service closes its streams and removes its socket file
+	srv.Close()
+	<-srv.Done()
+	recvErr := make(chan error, 1)
+	go func() {
+		_, err := conn.RecvMsg()
+		recvErr <- err
+	}()
+	select {
+	case err = <-recvErr:
+		if err != arc.ErrStreamClosed {
+			t.Fatalf("expected ErrStreamClosed, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected stream to close")
+	}
+	if _, err = os.Stat(socketPath); !os.IsNotExist(err) {
+		t.Fatalf("expected socket file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package ws_service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arcspace/go-arcspace/arc"
+)
+
+// WsServerOpts exposes WebSocket server options and params
+type WsServerOpts struct {
+	ServiceURI     string
+	ListenNetwork  string
+	ListenAddr     string
+	Path           string        // URL path that accepts WebSocket connections
+	AllowedOrigins []string      // Origins (e.g. "https://dash.example.com" or just its host) allowed besides the server's own host ("*" allows any)
+	PingPeriod     time.Duration // how often each client is pinged (0 disables keepalive)
+	PongWait       time.Duration // how long a client has to reply to a ping before it is dropped
+	MaxMsgSize     int64         // max byte size of a Msg frame sent by a client
+	RecordPath     string        // if set, each session's msgs are recorded to a new file in this dir (see recorder.ReadFile)
+}
+
+// DefaultWsServerOpts returns the default options for a WsServer, where each Msg is sent as a binary frame
+// containing a serialized arc.Msg.
+//
+// Browsers send an Origin header, so by default only pages served from the same host can connect (see AllowedOrigins).
+// Clients that send no Origin (i.e. not a browser) are always allowed.
+func DefaultWsServerOpts(listenPort int) WsServerOpts {
+	return WsServerOpts{
+		ServiceURI:    "ws",
+		ListenNetwork: "tcp",
+		ListenAddr:    fmt.Sprintf("0.0.0.0:%v", listenPort),
+		Path:          "/",
+		PingPeriod:    25 * time.Second,
+		PongWait:      60 * time.Second,
+		MaxMsgSize:    32 << 20,
+	}
+}
+
+func (opts WsServerOpts) NewWsServer() arc.HostService {
+	return &wsServer{
+		opts:     opts,
+		sessions: make(map[*wsSess]struct{}),
+	}
+}
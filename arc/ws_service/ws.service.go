@@ -0,0 +1,298 @@
+package ws_service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/recorder"
+	"github.com/arcspace/go-cedar/process"
+)
+
+// writeWait is how long a control frame (ping or close) has to be written.
+const writeWait = 10 * time.Second
+
+// wsServer is a HostService that accepts WebSocket connections, each of which carries one host session.
+type wsServer struct {
+	numSess uint64 // number of sessions started (used to name recordings)
+	process.Context
+	server   *http.Server
+	lis      net.Listener
+	upgrader websocket.Upgrader
+	host     arc.Host
+	opts     WsServerOpts
+	sessions map[*wsSess]struct{} // open sessions
+	sessMu   sync.Mutex           // protects sessions
+	sessWG   sync.WaitGroup       // open sessions (so GracefulStop can wait on them)
+}
+
+func (srv *wsServer) ServiceURI() string {
+	return srv.opts.ServiceURI
+}
+
+func (srv *wsServer) Host() arc.Host {
+	return srv.host
+}
+
+func (srv *wsServer) StartService(on arc.Host) error {
+	if srv.host != nil || srv.server != nil || srv.Context != nil {
+		panic("already started")
+	}
+	srv.host = on
+
+	var err error
+	srv.lis, err = net.Listen(srv.opts.ListenNetwork, srv.opts.ListenAddr)
+	if err != nil {
+		return errors.Errorf("failed to listen: %v", err)
+	}
+
+	srv.upgrader = websocket.Upgrader{
+		CheckOrigin: srv.checkOrigin,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(srv.opts.Path, srv.serveWs)
+	srv.server = &http.Server{
+		Handler: mux,
+	}
+
+	srv.Context, err = srv.host.StartChild(&process.Task{
+		Label:     fmt.Sprint(srv.ServiceURI(), ".HostService"),
+		IdleClose: time.Nanosecond,
+		OnRun: func(ctx process.Context) {
+			srv.Infof(0, "Serving on \x1b[1;32m%v %v%v\x1b[0m", srv.opts.ListenNetwork, srv.lis.Addr(), srv.opts.Path)
+			srv.server.Serve(srv.lis)
+			srv.Info(2, "Serve COMPLETE")
+		},
+		OnClosing: func() {
+			srv.Info(1, "Stop")
+			srv.server.Close()
+			srv.closeSessions()
+			srv.Info(2, "Stop COMPLETE")
+		},
+	})
+	if err != nil {
+		srv.lis.Close()
+		return err
+	}
+
+	return nil
+}
+
+// GracefulStop stops accepting new connections and blocks until each open session has closed.
+func (srv *wsServer) GracefulStop() {
+	if srv.server != nil {
+		srv.Info(0, "GracefulStop")
+		srv.server.Shutdown(context.Background())
+		srv.sessWG.Wait()
+	}
+}
+
+// checkOrigin allows clients that send no Origin (i.e. not a browser), pages served from the same host, and opts.AllowedOrigins.
+func (srv *wsServer) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range srv.opts.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) || strings.EqualFold(allowed, u.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// serveWs upgrades the given request to a WebSocket and starts a new host session over it, blocking until the session's stream closes.
+func (srv *wsServer) serveWs(w http.ResponseWriter, r *http.Request) {
+	conn, err := srv.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has replied with an HTTP error
+		srv.Infof(1, "refused WebSocket from %v: %v", r.RemoteAddr, err)
+		return
+	}
+
+	sess := &wsSess{
+		srv:     srv,
+		conn:    conn,
+		closing: make(chan struct{}),
+	}
+
+	var via arc.ServerStream = sess
+	if srv.opts.RecordPath != "" {
+		w, err := srv.newRecording()
+		if err != nil {
+			srv.Warnf("%v", err)
+			conn.Close()
+			return
+		}
+		via = recorder.RecordStream(sess, w)
+	}
+
+	if !srv.addSess(sess) {
+		via.Close()
+		conn.Close()
+		return
+	}
+	defer srv.removeSess(sess)
+
+	if max := srv.opts.MaxMsgSize; max > 0 {
+		conn.SetReadLimit(max)
+	}
+	if srv.opts.PingPeriod > 0 {
+		conn.SetReadDeadline(time.Now().Add(srv.opts.PongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(srv.opts.PongWait))
+		})
+		go sess.keepAlive()
+	}
+
+	sess.hostSess, err = srv.host.StartNewSession(srv, via)
+	if err != nil {
+		srv.Warnf("failed to start session: %v", err)
+		via.Close()
+	}
+
+	// Block until the host closes this stream.
+	// Note the host session may close before then since another session can be resumed over this stream.
+	<-sess.closing
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeWait))
+	conn.Close()
+}
+
+// addSess adds the given session to the open sessions, returning false if this service is closing.
+func (srv *wsServer) addSess(sess *wsSess) bool {
+	srv.sessMu.Lock()
+	defer srv.sessMu.Unlock()
+
+	select {
+	case <-srv.Closing():
+		return false
+	default:
+	}
+	srv.sessions[sess] = struct{}{}
+	srv.sessWG.Add(1)
+	return true
+}
+
+func (srv *wsServer) removeSess(sess *wsSess) {
+	srv.sessMu.Lock()
+	delete(srv.sessions, sess)
+	srv.sessMu.Unlock()
+	srv.sessWG.Done()
+}
+
+// closeSessions closes the stream of each open session.
+func (srv *wsServer) closeSessions() {
+	srv.sessMu.Lock()
+	defer srv.sessMu.Unlock()
+
+	for sess := range srv.sessions {
+		sess.Close()
+	}
+}
+
+// newRecording creates a file in opts.RecordPath to record a new session to.
+func (srv *wsServer) newRecording() (*recorder.Writer, error) {
+	sessNum := atomic.AddUint64(&srv.numSess, 1)
+	name := fmt.Sprintf("%s-%s-%d%s", time.Now().Format("20060102-150405"), srv.ServiceURI(), sessNum, recorder.FileExt)
+	w, err := recorder.Create(path.Join(srv.opts.RecordPath, name))
+	if err != nil {
+		return nil, errors.Errorf("failed to create recording: %v", err)
+	}
+	return w, nil
+}
+
+type wsSess struct {
+	closed   int32
+	closing  chan struct{}
+	srv      *wsServer
+	conn     *websocket.Conn
+	hostSess arc.HostSession
+	buf      []byte // reused to serialize outbound msgs (only accessed by SendMsg)
+}
+
+func (sess *wsSess) Desc() string {
+	return sess.srv.ServiceURI()
+}
+
+func (sess *wsSess) Close() {
+	if atomic.CompareAndSwapInt32(&sess.closed, 0, 1) {
+		close(sess.closing)
+	}
+}
+
+// keepAlive pings the client every opts.PingPeriod until this stream closes.
+// A client that doesn't reply within opts.PongWait fails the next read, closing its stream.
+func (sess *wsSess) keepAlive() {
+	ticker := time.NewTicker(sess.srv.opts.PingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := sess.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+			if err != nil {
+				return
+			}
+		case <-sess.closing:
+			return
+		}
+	}
+}
+
+func (sess *wsSess) SendMsg(msg *arc.Msg) error {
+	sz := msg.Size()
+	if cap(sess.buf) < sz {
+		sess.buf = make([]byte, sz)
+	}
+	buf := sess.buf[:sz]
+	if _, err := msg.MarshalToSizedBuffer(buf); err != nil {
+		return err
+	}
+	err := sess.conn.WriteMessage(websocket.BinaryMessage, buf)
+	if err != nil {
+		return streamErr(err)
+	}
+	return nil
+}
+
+func (sess *wsSess) RecvMsg() (*arc.Msg, error) {
+	msgType, buf, err := sess.conn.ReadMessage()
+	if err != nil {
+		return nil, streamErr(err)
+	}
+	if msgType != websocket.BinaryMessage {
+		return nil, arc.ErrCode_BadValue.Errorf("expected binary WebSocket msg, got type %d", msgType)
+	}
+	msg := arc.NewMsg()
+	if err = msg.Unmarshal(buf); err != nil {
+		msg.Reclaim()
+		return nil, arc.ErrCode_BadValue.Wrap(err)
+	}
+	return msg, nil
+}
+
+// streamErr returns arc.ErrStreamClosed if the given error is due to a normal close of the stream.
+func streamErr(err error) error {
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) || errors.Is(err, net.ErrClosed) || err == websocket.ErrCloseSent {
+		return arc.ErrStreamClosed
+	}
+	return err
+}
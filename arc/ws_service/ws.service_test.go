@@ -0,0 +1,102 @@
+package ws_service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/host/hosttest"
+)
+
+func TestWsService(t *testing.T) {
+	h := hosttest.StartTestHost(t)
+
+	opts := DefaultWsServerOpts(0)
+	opts.ListenAddr = "127.0.0.1:0"
+	opts.AllowedOrigins = []string{"https://dash.example.com"}
+	opts.PingPeriod = 20 * time.Millisecond
+	srv := opts.NewWsServer()
+	if err := srv.StartService(h); err != nil {
+		t.Fatal(err)
+	}
+	url := "ws://" + srv.(*wsServer).lis.Addr().String() + "/"
+
+	// Browser pages from other origins are refused
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://other.example.com"}})
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected other origin to be refused, got %v", err)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://dash.example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	pinged := make(chan struct{}, 1)
+	conn.SetPingHandler(func(data string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	msgs := make(chan *arc.Msg, 8)
+	closed := make(chan error, 1)
+	go func() {
+		for {
+			_, buf, err := conn.ReadMessage()
+			if err != nil {
+				closed <- err
+				return
+			}
+			msg := arc.NewMsg()
+			if err = msg.Unmarshal(buf); err != nil {
+				closed <- err
+				return
+			}
+			msgs <- msg
+		}
+	}()
+
+	// Each binary frame carries a Msg, and the host replies in kind (here refusing a pin before login)
+	msg := arc.NewMsg()
+	msg.ReqID = 7
+	msg.Op = arc.MsgOp_PinCell
+	buf, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = conn.WriteMessage(websocket.BinaryMessage, buf); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case reply := <-msgs:
+		if reply.ReqID != 7 || reply.Op != arc.MsgOp_CloseReq || reply.ValType != int32(arc.ValType_Err) {
+			t.Fatalf("expected req to be closed with an error, got %v", reply)
+		}
+	case err = <-closed:
+		t.Fatalf("stream closed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reply")
+	}
+
+	// The server pings clients to keep them alive
+	select {
+	case <-pinged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a ping")
+	}
+
+	// Closing the service closes its streams
+	srv.Close()
+	<-srv.Done()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected stream to close")
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/arcspace/go-arcspace/arc"
+	"github.com/arcspace/go-arcspace/arc/client"
+	"github.com/arcspace/go-arcspace/ski"
+	"github.com/arcspace/go-cedar/log"
+	"github.com/arcspace/go-cedar/utils"
+)
+
+// arcctl logs in to a running archost, pins each given URI, and prints the cell tree pushed to each pin.
+// In watch mode, updates to pinned cells are printed as they are pushed until interrupted, each pin closes, or -timeout elapses.
+//
+// Each line printed is prefixed by the ReqID of its pin, e.g.
+//
+//	arcctl -schemas filesys.schemas.json -content dir -children dir,file ~/Documents
+func main() {
+	addr := flag.String("addr", fmt.Sprintf("127.0.0.1:%v", arc.Const_DefaultGrpcServicePort), "Address of the archost HostGrpc service")
+	userUID := flag.String("user", "arcctl", "UserUID to log in as")
+	keyPath := flag.String("key", "~/.arcctl/signing.key", "Signing key to log in with (generated if it doesn't exist)")
+	schemasPath := flag.String("schemas", "", "JSON file of the AttrSchemas to register (an arc.Defs); SchemaIDs are assigned and AttrIDs default to each attr's position")
+	content := flag.String("content", "", "SchemaName of the schema to pin each URI with")
+	children := flag.String("children", "", "Comma separated SchemaNames of the child cells to push")
+	cellID := flag.Uint64("cell", 0, "CellID to pin (if the URI doesn't specify one)")
+	planetID := flag.Uint64("planet", 0, "Planet to pin from (or 0 for the user's home planet)")
+	watch := flag.Bool("watch", false, "Print updates until interrupted or each pin closes; a \"close {ReqID}\" line on stdin closes that pin")
+	timeout := flag.Duration("timeout", 0, "If set, closes each pin once the given duration has elapsed")
+	tlsCA := flag.String("tls-ca", "", "If set, connects via TLS, verifying the host's cert against the CAs in the given PEM file (e.g. the host's self-signed tls/cert.pem)")
+	tlsCert := flag.String("tls-cert", "", "PEM cert file to present to the host (if it requires client certs)")
+	tlsKey := flag.String("tls-key", "", "PEM private key file of -tls-cert")
+	token := flag.String("token", "", "Bearer token to present to the host (if it requires one)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] -schemas defs.json -content SchemaName [URI...]\n", path.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *schemasPath == "" || *content == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	defs, err := readDefs(*schemasPath)
+	if err != nil {
+		log.Fatalf("failed to read schemas: %v", err)
+	}
+	key, err := loadKey(*keyPath)
+	if err != nil {
+		log.Fatalf("failed to load signing key: %v", err)
+	}
+
+	var dialOpts []grpc.DialOption
+	if *tlsCA != "" || *tlsCert != "" {
+		cfg, err := tlsConfig(*tlsCA, *tlsCert, *tlsKey)
+		if err != nil {
+			log.Fatalf("failed to load TLS config: %v", err)
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if *token != "" {
+		dialOpts = append(dialOpts, client.WithBearerToken(*token))
+	}
+	tr, err := client.DialGrpc(*addr, dialOpts...)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	c := client.New(tr)
+	defer c.Close()
+
+	if err = c.Login([]byte(*userUID), key); err != nil {
+		log.Fatalf("failed to login: %v", err)
+	}
+	if err = c.RegisterSchemas(defs.Schemas...); err != nil {
+		log.Fatalf("failed to register schemas: %v", err)
+	}
+
+	req := client.PinReq{
+		PinCell:  *cellID,
+		PlanetID: *planetID,
+	}
+	if req.Content, err = attrsFor(defs, *content); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *children != "" {
+		for _, name := range strings.Split(*children, ",") {
+			child, err := attrsFor(defs, strings.TrimSpace(name))
+			if err != nil {
+				log.Fatalf("%v", err)
+			}
+			req.Children = append(req.Children, child)
+		}
+	}
+
+	uris := flag.Args()
+	if len(uris) == 0 {
+		uris = []string{""}
+	}
+	pins := make(map[uint64]*client.Pin)
+	for _, uri := range uris {
+		req.PinURI = uri
+		pr := &pinPrinter{
+			watch: *watch,
+		}
+		pin, err := c.Pin(req, pr.handler())
+		if err != nil {
+			log.Fatalf("failed to pin %q: %v", uri, err)
+		}
+		pins[pin.ReqID] = pin
+	}
+
+	if *watch {
+		awaitPins(pins, *timeout)
+	}
+	for _, pin := range pins {
+		pin.Close()
+		select {
+		case <-pin.Done():
+		case <-c.Done():
+		}
+	}
+}
+
+// readDefs reads the AttrSchemas to register from the given JSON file.
+func readDefs(pathname string) (*arc.Defs, error) {
+	buf, err := os.ReadFile(pathname)
+	if err != nil {
+		return nil, err
+	}
+	defs := &arc.Defs{}
+	if err = json.Unmarshal(buf, defs); err != nil {
+		return nil, err
+	}
+	if len(defs.Schemas) == 0 {
+		return nil, arc.ErrCode_BadSchema.Errorf("no schemas in %q", pathname)
+	}
+	return defs, nil
+}
+
+// attrsFor returns an Attrs of the given registered schema.
+func attrsFor(defs *arc.Defs, schemaName string) (*client.Attrs, error) {
+	for _, schema := range defs.Schemas {
+		if schema.SchemaName == schemaName {
+			return &client.Attrs{Schema: schema}, nil
+		}
+	}
+	return nil, arc.ErrCode_TypeNotFound.Errorf("schema %q not found", schemaName)
+}
+
+// loadKey reads the signing key at the given path, generating and saving a new one if none exists.
+// Since a user's first login registers its key, later logins as the same user must use the same key.
+func loadKey(pathname string) (*ski.KeyEntry, error) {
+	dir, err := utils.ExpandAndCheckPath(path.Dir(pathname), true)
+	if err != nil {
+		return nil, err
+	}
+	pathname = path.Join(dir, path.Base(pathname))
+
+	key := &ski.KeyEntry{}
+	buf, err := os.ReadFile(pathname)
+	if err == nil {
+		err = key.Unmarshal(buf)
+		return key, err
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	kit, err := ski.GetCryptoKit(ski.CryptoKitID_ED25519)
+	if err != nil {
+		return nil, err
+	}
+	key.KeyInfo = &ski.KeyInfo{
+		KeyType:     ski.KeyType_SigningKey,
+		CryptoKitID: ski.CryptoKitID_ED25519,
+	}
+	if err = kit.GenerateNewKey(32, rand.Reader, key); err != nil {
+		return nil, err
+	}
+	if buf, err = key.Marshal(); err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(pathname, buf, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// tlsConfig returns the client-side tls.Config that trusts the given CAs (or the system's if not set) and presents the given cert (if set).
+func tlsConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caFile != "" {
+		buf, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(buf) {
+			return nil, arc.ErrCode_BadValue.Errorf("no PEM certs found in %q", caFile)
+		}
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// awaitPins blocks until each given pin closes, the process is interrupted, or the given timeout elapses (if set).
+// Meanwhile, each "close {ReqID}" line read from stdin closes that pin.
+func awaitPins(pins map[uint64]*client.Pin, timeout time.Duration) {
+	var timedOut <-chan time.Time
+	if timeout > 0 {
+		timedOut = time.After(timeout)
+	}
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) != 2 || fields[0] != "close" {
+				fmt.Fprintf(os.Stderr, "expected \"close {ReqID}\"\n")
+				continue
+			}
+			reqID, _ := strconv.ParseUint(fields[1], 10, 64)
+			if pin := pins[reqID]; pin != nil {
+				pin.Close()
+			} else {
+				fmt.Fprintf(os.Stderr, "no pin with ReqID %v\n", fields[1])
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, pin := range pins {
+		wg.Add(1)
+		go func(pin *client.Pin) {
+			<-pin.Done()
+			wg.Done()
+		}(pin)
+	}
+	allClosed := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(allClosed)
+	}()
+
+	select {
+	case <-allClosed:
+	case <-interrupt:
+	case <-timedOut:
+	}
+}
+
+// pinPrinter prints the cell tree pushed to a pin and, in watch mode, each update that follows.
+type pinPrinter struct {
+	watch  bool
+	reqID  uint64
+	synced bool // set once the pin's initial state has been printed
+}
+
+func (pr *pinPrinter) handler() client.PinHandler {
+	return client.PinHandler{
+		OnInsert: func(cell *client.Cell) {
+			if pr.synced && pr.watch {
+				printCell(pr.reqID, cell, "+ ")
+			}
+		},
+		OnChange: func(cell *client.Cell) {
+			if pr.watch {
+				printCell(pr.reqID, cell, "~ ")
+			}
+		},
+		OnCheckpoint: func(pin *client.Pin, err error) {
+			if !pr.synced {
+				pr.synced = true
+				pr.reqID = pin.ReqID
+				if pin.Cell != nil {
+					printCell(pr.reqID, pin.Cell, "")
+					for _, child := range pin.Cell.Children {
+						printCell(pr.reqID, child, "  ")
+					}
+				}
+			}
+			if err != nil {
+				fmt.Printf("[%d] checkpoint failed: %v\n", pin.ReqID, err)
+			}
+		},
+		OnClose: func(pin *client.Pin, err error) {
+			if err != nil && pr.watch {
+				fmt.Printf("[%d] closed: %v\n", pin.ReqID, err)
+			}
+		},
+	}
+}
+
+// printCell prints the given cell and its attr values, prefixed by the ReqID of the pin it was pushed to.
+func printCell(reqID uint64, cell *client.Cell, prefix string) {
+	attrs := cell.Value.(*client.Attrs)
+	var b strings.Builder
+	for _, attr := range attrs.Schema.Attrs {
+		val, ok := attrs.Vals[attr.AttrURI]
+		if !ok {
+			continue
+		}
+		b.WriteByte(' ')
+		b.WriteString(attr.AttrURI)
+		b.WriteByte('=')
+		switch v := val.(type) {
+		case string:
+			b.WriteString(strconv.Quote(v))
+		case time.Time:
+			b.WriteString(v.Format(time.RFC3339))
+		case []byte:
+			fmt.Fprintf(&b, "%x", v)
+		default:
+			fmt.Fprint(&b, v)
+		}
+	}
+	fmt.Printf("[%d] %s%d %s%s\n", reqID, prefix, cell.CellID, attrs.Schema.SchemaName, b.String())
+}
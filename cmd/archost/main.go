@@ -2,14 +2,15 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"os/signal"
 	"path"
+	"syscall"
 	"time"
 
 	"github.com/arcspace/go-arcspace/arc"
 	"github.com/arcspace/go-arcspace/arc/archost"
-	"github.com/arcspace/go-arcspace/arc/grpc_service"
-	"github.com/arcspace/go-arcspace/arc/host"
 	"github.com/arcspace/go-cedar/log"
 	"github.com/arcspace/go-cedar/process"
 	"github.com/arcspace/go-cedar/utils"
@@ -27,6 +28,14 @@ func main() {
 	hostPort := flag.Int("host-port", int(arc.Const_DefaultGrpcServicePort), "Sets the port used to bind HostGrpc service")
 	showTree := flag.Int("show-tree", 0, "Prints the process tree periodically, checking every given number of seconds")
 	dataPath := flag.String("data-path", defaultDataPath, "Specifies the path for all file access and storage")
+	recordPath := flag.String("record-path", "", "If set, each session's msgs are recorded to a new file in the given dir (see arcreplay)")
+	configPath := flag.String("config", "", "If set, the JSON config file selecting the apps and services to start and host opts (see archost.Config); SIGHUP reloads it")
+	useTLS := flag.Bool("tls", false, "Secures the HostGrpc service via TLS, using a self-signed cert generated in {data-path}/tls unless -tls-cert is set")
+	tlsCert := flag.String("tls-cert", "", "PEM cert file the HostGrpc service presents (implies -tls)")
+	tlsKey := flag.String("tls-key", "", "PEM private key file of -tls-cert")
+	tlsClientCA := flag.String("tls-client-ca", "", "If set, PEM file of the CAs that client certs are verified against (implies -tls)")
+	requireClientCert := flag.Bool("tls-require-client-cert", false, "Requires clients to present a cert verified by -tls-client-ca")
+	reflection := flag.Bool("grpc-reflection", false, "Enables gRPC server reflection on the HostGrpc service (e.g. for grpcurl)")
 
 	flag.Set("logtostderr", "true")
 	flag.Set("v", "2")
@@ -43,40 +52,99 @@ func main() {
 	klog.Flush()
 	flag.Parse()
 
-	hostOpts := host.DefaultHostOpts()
-	hostOpts.StatePath = *dataPath
-	host := archost.StartNewHost(hostOpts)
+	// Flags give the default config, which a config file can override
+	loadConfig := func() (archost.Config, error) {
+		cfg := archost.DefaultConfig()
+		cfg.Host.StatePath = *dataPath
+		if *configPath != "" {
+			if err := cfg.ReadFile(*configPath); err != nil {
+				return cfg, err
+			}
+		}
+		if cfg.Services == nil {
+			sc := archost.ServiceConfig{
+				Service:    "grpc",
+				ListenAddr: fmt.Sprintf("0.0.0.0:%v", *hostPort),
+				RecordPath: *recordPath,
+				Reflection: *reflection,
+			}
+			if *useTLS || *tlsCert != "" || *tlsClientCA != "" || *requireClientCert {
+				sc.TLS = &archost.TLSConfig{
+					CertFile:          *tlsCert,
+					KeyFile:           *tlsKey,
+					ClientCAFile:      *tlsClientCA,
+					RequireClientCert: *requireClientCert,
+				}
+			}
+			cfg.Services = []archost.ServiceConfig{sc}
+		}
+		return cfg, nil
+	}
 
-	opts := grpc_service.DefaultGrpcServerOpts(*hostPort)
-	srv := opts.NewGrpcServer()
-	err = srv.StartService(host)
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	host, err := cfg.StartHost()
 	if err != nil {
-		srv.Fatalf("failed to start grpc service: %v", err)
+		log.Fatalf("failed to start host: %v", err)
+	}
+	services, err := cfg.NewServices()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	for _, srv := range services {
+		if err = srv.StartService(host); err != nil {
+			srv.Fatalf("failed to start %s service: %v", srv.ServiceURI(), err)
+		}
 	}
 
+	// SIGHUP reloads the host opts that can change while running
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			cfg, err := loadConfig()
+			if err == nil {
+				err = cfg.Reload(host)
+			}
+			if err != nil {
+				host.Warnf("failed to reload config: %v", err)
+			} else {
+				host.Info(0, "reloaded config")
+			}
+		}
+	}()
+
 	gracefulStop, immediateStop := log.AwaitInterrupt()
 
 	host.Infof(0, "Graceful stop: \x1b[1m^C\x1b[0m or \x1b[1mkill -s SIGINT %d\x1b[0m" /*, or \x1b[1mkill -9 %d\x1b[0m", os.Getpid(),*/, os.Getpid())
 
 	go func() {
 		<-gracefulStop
-		srv.Info(2, "<-gracefulStop")
-		srv.GracefulStop()
-		srv.Close()
+		for _, srv := range services {
+			srv.Info(2, "<-gracefulStop")
+			srv.GracefulStop()
+			srv.Close()
+		}
 	}()
 
 	go func() {
 		<-immediateStop
-		srv.Info(2, "<-immediateStop")
-		srv.Close()
+		for _, srv := range services {
+			srv.Info(2, "<-immediateStop")
+			srv.Close()
+		}
 	}()
 
 	if *showTree > 0 {
 		go process.PrintTreePeriodically(host, time.Duration(*showTree)*time.Second, 2)
 	}
 
-	// Block on grpc shutdown completion, then initiate host shutdown.
-	<-srv.Done()
+	// Block on service shutdown completion, then initiate host shutdown.
+	for _, srv := range services {
+		<-srv.Done()
+	}
 	host.Close()
 
 	// Block on host shutdown completion
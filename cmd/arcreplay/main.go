@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/arcspace/go-arcspace/arc/archost"
+	"github.com/arcspace/go-arcspace/arc/host"
+	"github.com/arcspace/go-arcspace/arc/recorder"
+	"github.com/arcspace/go-cedar/log"
+)
+
+// arcreplay replays each given session recording (see archost -record-path) against a fresh host and prints how the host's replies differ.
+// Exits with status 1 if any replay differs from its recording.
+func main() {
+	timeout := flag.Duration("timeout", recorder.DefaultReplayOpts().Timeout, "Max time to wait for the host to send what it sent before each recorded client msg")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] recording%s...\n", path.Base(os.Args[0]), recorder.FileExt)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := recorder.DefaultReplayOpts()
+	opts.Timeout = *timeout
+
+	differs := false
+	for _, pathname := range flag.Args() {
+		diffs, err := replayFile(pathname, opts)
+		if err != nil {
+			log.Fatalf("%v: %v", pathname, err)
+		}
+		if len(diffs) == 0 {
+			fmt.Printf("%v: OK\n", pathname)
+			continue
+		}
+		differs = true
+		fmt.Printf("%v: %d differences\n", pathname, len(diffs))
+		for _, diff := range diffs {
+			fmt.Println(diff)
+		}
+	}
+
+	if differs {
+		os.Exit(1)
+	}
+}
+
+// replayFile replays the given recording against a new host in a temp dir, returning how the host's replies differ.
+func replayFile(pathname string, opts recorder.ReplayOpts) ([]string, error) {
+	records, err := recorder.ReadFile(pathname)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "arcreplay")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	hostOpts := host.DefaultHostOpts()
+	hostOpts.StatePath = path.Join(dir, "state")
+	hostOpts.CachePath = path.Join(dir, "cache")
+	h := archost.StartNewHost(hostOpts)
+	defer func() {
+		h.Close()
+		<-h.Done()
+	}()
+
+	got, err := recorder.Replay(h, records, opts)
+	if err != nil {
+		return nil, err
+	}
+	return recorder.Diff(records, got), nil
+}
@@ -15,9 +15,11 @@ require (
 	github.com/brynbellomy/klog v0.0.0-20200414031930-87fbf2e555ae
 	github.com/dgraph-io/badger/v3 v3.2103.4
 	github.com/gogo/protobuf v1.3.2
+	github.com/gorilla/websocket v1.5.0
 	github.com/pkg/errors v0.9.1
 	github.com/zmb3/spotify/v2 v2.3.1
 	golang.org/x/crypto v0.5.0
+	golang.org/x/sys v0.4.0
 	google.golang.org/grpc v1.51.0
 )
 
@@ -37,7 +39,6 @@ require (
 	golang.org/x/net v0.5.0 // indirect
 	golang.org/x/oauth2 v0.4.0 // indirect
 	golang.org/x/sync v0.1.0 // indirect
-	golang.org/x/sys v0.4.0 // indirect
 	golang.org/x/text v0.6.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20230104163317-caabf589fcbf // indirect
@@ -66,6 +66,10 @@ type Table interface {
 	// If ID is invalid or not found, nil is returned.
 	LookupID(ID ID) []byte
 
+	// Removes the given symbol ID along with every value associated with it (e.g. once what it identifies is deleted).
+	// The ID is not issued again.
+	DeleteID(ID ID)
+
 	// Releases internal references to the underlying database.
 	// Subsequent access to this Table instance is defined but limited to what is already cached.
 	Close()
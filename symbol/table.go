@@ -334,6 +334,66 @@ func (st *symbolTable) LookupID(symID ID) []byte {
 	return st.bufForEntry(&kv)
 }
 
+func (st *symbolTable) DeleteID(symID ID) {
+	if symID == 0 {
+		return
+	}
+
+	if st.db != nil {
+		var (
+			idBuf  [8]byte
+			keyBuf [3]byte
+		)
+		idBuf[0] = st.opts.DbKeyPrefix
+		idKey := symID.WriteTo(idBuf[:1])
+		keyBuf[0] = st.opts.DbKeyPrefix
+		keyBuf[1] = 0xFF
+		keyBuf[2] = xValueIndex
+
+		// Each value associated with symID is found by scanning the value index, so this isn't intended for frequent use
+		err := st.db.Update(func(txn *badger.Txn) error {
+			itOpts := badger.DefaultIteratorOptions
+			itOpts.Prefix = keyBuf[:]
+			it := txn.NewIterator(itOpts)
+			defer it.Close()
+
+			var valKeys [][]byte
+			for it.Rewind(); it.Valid(); it.Next() {
+				item := it.Item()
+				item.Value(func(buf []byte) error {
+					if bytes.Equal(buf, idKey[1:]) {
+						valKeys = append(valKeys, item.KeyCopy(nil))
+					}
+					return nil
+				})
+			}
+			for _, valKey := range valKeys {
+				if err := txn.Delete(valKey); err != nil {
+					return err
+				}
+			}
+			return txn.Delete(idKey)
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	st.tokenCacheMu.Lock()
+	delete(st.tokenCache, symID)
+	st.tokenCacheMu.Unlock()
+
+	// Entries are left in place so that colliding entries after them are still found, but no longer resolve to symID
+	st.valueCacheMu.Lock()
+	for hash, kv := range st.valueCache {
+		if kv.symID == symID {
+			kv.symID = 0
+			st.valueCache[hash] = kv
+		}
+	}
+	st.valueCacheMu.Unlock()
+}
+
 func max(a, b int32) int32 {
 	if a > b {
 		return a
@@ -196,3 +196,43 @@ func checkTable(db *badger.DB, vals [][]byte, IDs []symbol.ID) {
 		running.Wait()
 	}
 }
+
+func TestDeleteID(t *testing.T) {
+	opts := badger.DefaultOptions(t.TempDir())
+	opts.Logger = nil
+	db, err := badger.Open(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	table, err := symbol.OpenTable(db, symbol.DefaultTableOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	symID := table.GetSymbolID([]byte("planet"), true)
+	table.SetSymbolID([]byte("planet-alias"), symID)
+	otherID := table.GetSymbolID([]byte("other"), true)
+
+	table.DeleteID(symID)
+	if table.LookupID(symID) != nil || table.GetSymbolID([]byte("planet"), false) != 0 || table.GetSymbolID([]byte("planet-alias"), false) != 0 {
+		t.Fatal("deleted ID still resolves")
+	}
+	if !bytes.Equal(table.LookupID(otherID), []byte("other")) {
+		t.Fatal("other ID no longer resolves")
+	}
+	table.Close()
+
+	// The deletion is persistent and the ID isn't issued again
+	table, err = symbol.OpenTable(db, symbol.DefaultTableOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Close()
+	if table.LookupID(symID) != nil || table.GetSymbolID([]byte("planet"), false) != 0 {
+		t.Fatal("deleted ID resolves once reopened")
+	}
+	if newID := table.GetSymbolID([]byte("planet"), true); newID == symID || newID == 0 {
+		t.Fatalf("expected a newly issued ID, got %v", newID)
+	}
+}